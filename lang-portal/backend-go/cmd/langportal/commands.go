@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	_ "github.com/jeevanions/lang-portal/backend-go/docs" // Import swagger docs
+	"github.com/jeevanions/lang-portal/backend-go/internal/config"
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/seeder"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
+	"github.com/jeevanions/lang-portal/backend-go/internal/server"
+)
+
+// exportPageSize is how many group words are loaded at a time when exporting
+const exportPageSize = 500
+
+func serve(ctx context.Context, cfg *config.Config, args []string) error {
+	if _, err := parseFlags(flag.NewFlagSet("serve", flag.ContinueOnError), args, 0); err != nil {
+		return err
+	}
+	db, err := openDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return server.Run(cfg, db)
+}
+
+func migrate(ctx context.Context, cfg *config.Config, args []string) error {
+	args, err := parseFlags(flag.NewFlagSet("migrate", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	db, err := openDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	switch args[0] {
+	case "up":
+		applied, err := db.MigrateUp(ctx)
+		for _, migration := range applied {
+			fmt.Printf("applied %s\n", migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("no migrations to apply")
+		}
+		return err
+	case "down":
+		rolledBack, err := db.MigrateDown(ctx)
+		switch {
+		case err != nil:
+			return err
+		case rolledBack == nil:
+			fmt.Println("no migrations to roll back")
+		default:
+			fmt.Printf("rolled back %s\n", rolledBack.Name)
+		}
+		return nil
+	case "status":
+		statuses, err := db.MigrationStatuses(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied " + status.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-45s %s\n", status.Name, state)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: migrate takes up, down or status", errUsage)
+	}
+}
+
+func seed(ctx context.Context, cfg *config.Config, args []string) error {
+	if _, err := parseFlags(flag.NewFlagSet("seed", flag.ContinueOnError), args, 0); err != nil {
+		return err
+	}
+	db, err := openDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := seeder.New(db).Seed(ctx); err != nil {
+		return err
+	}
+	fmt.Println("seed packs applied")
+	return nil
+}
+
+func importWords(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	group := flags.String("group", "", "ID or name of the group to add the words to; a new name creates the group")
+	file := flags.String("file", "", "JSON file of words, as export writes them, or - for stdin")
+	courseID := flags.Int64("course", models.DefaultCourseID, "course of the words")
+	args, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	if args[0] != "words" || *group == "" || *file == "" {
+		return fmt.Errorf("%w: import words needs --group and --file", errUsage)
+	}
+
+	var r io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	var words exportedGroup
+	if err := json.NewDecoder(r).Decode(&words); err != nil {
+		return fmt.Errorf("failed to read words: %w", err)
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Groups named rather than numbered are created when missing
+	groupID, err := findGroup(ctx, db, *group)
+	if _, parseErr := strconv.ParseInt(*group, 10, 64); errors.Is(err, models.ErrNotFound) && parseErr != nil {
+		var created *models.GroupResponse
+		if created, err = services.NewGroupService(db).CreateGroup(ctx, *group); err == nil {
+			groupID = created.ID
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	req := &models.ImportWordsRequest{GroupID: groupID, CourseID: *courseID}
+	for _, word := range words.Words {
+		req.Words = append(req.Words, models.WordResponse{Term: word.Term, Translation: word.Translation, Parts: word.Parts})
+	}
+	result, err := services.NewWordService(db).ImportWords(ctx, req)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("imported %d words into group %d, skipped %d\n", result.ImportedCount, groupID, result.SkippedCount)
+	return nil
+}
+
+// findGroup returns the ID of the group identified by an ID or a name
+func findGroup(ctx context.Context, db *repository.SQLRepository, group string) (int64, error) {
+	if id, err := strconv.ParseInt(group, 10, 64); err == nil {
+		if _, err := db.GetGroupByID(ctx, id); err != nil {
+			return 0, err
+		}
+		return id, nil
+	}
+	id, err := db.GetGroupIDByName(ctx, group)
+	if err == nil && id == 0 {
+		return 0, models.NotFoundError("group")
+	}
+	return id, err
+}
+
+// exportedGroup is a group's words as export writes them and import reads
+// them, in the format of a content pack's words.json
+type exportedGroup struct {
+	Name  string         `json:"name"`
+	Words []exportedWord `json:"words"`
+}
+
+type exportedWord struct {
+	Term        string                 `json:"term"`
+	Translation string                 `json:"translation"`
+	Parts       map[string]interface{} `json:"parts,omitempty"`
+}
+
+func exportGroup(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	file := flags.String("file", "-", "file to write, - for stdout")
+	args, err := parseFlags(flags, args, 2)
+	if err != nil {
+		return err
+	}
+	if args[0] != "group" {
+		return fmt.Errorf("%w: export takes group ID|NAME", errUsage)
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	groupID, err := findGroup(ctx, db, args[1])
+	if err != nil {
+		return err
+	}
+
+	groups := services.NewGroupService(db)
+	group, err := groups.GetGroupByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	export := exportedGroup{Name: group.Name, Words: []exportedWord{}}
+	for offset := 0; ; offset += exportPageSize {
+		page, err := groups.GetGroupWords(ctx, groupID, exportPageSize, offset)
+		if err != nil {
+			return err
+		}
+		for _, word := range page.Items {
+			export.Words = append(export.Words, exportedWord{Term: word.Term, Translation: word.Translation, Parts: word.Parts})
+		}
+		if len(page.Items) < exportPageSize {
+			break
+		}
+	}
+
+	w := io.Writer(os.Stdout)
+	if *file != "-" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
+
+func backup(ctx context.Context, cfg *config.Config, args []string) error {
+	args, err := parseFlags(flag.NewFlagSet("backup", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	db, err := openDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := db.Backup(ctx, args[0]); err != nil {
+		return err
+	}
+	fmt.Printf("backed up %s to %s\n", cfg.DBPath, args[0])
+	return nil
+}
+
+func restore(ctx context.Context, cfg *config.Config, args []string) error {
+	args, err := parseFlags(flag.NewFlagSet("restore", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+	if cfg.DBDriver == "postgres" {
+		return fmt.Errorf("restore PostgreSQL databases with pg_restore")
+	}
+
+	if err := repository.Restore(ctx, args[0], cfg.DBPath); err != nil {
+		return err
+	}
+	fmt.Printf("restored %s from %s\n", cfg.DBPath, args[0])
+	return nil
+}
+
+// statistics is the output of the stats command, the figures of the
+// dashboard
+type statistics struct {
+	QuickStats       *models.DashboardQuickStats       `json:"quick_stats"`
+	StudyProgress    *models.DashboardStudyProgress    `json:"study_progress"`
+	LastStudySession *models.DashboardLastStudySession `json:"last_study_session"`
+}
+
+func stats(ctx context.Context, cfg *config.Config, args []string) error {
+	if _, err := parseFlags(flag.NewFlagSet("stats", flag.ContinueOnError), args, 0); err != nil {
+		return err
+	}
+	db, err := openDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	dashboard := services.NewDashboardService(db)
+	var result statistics
+	if result.QuickStats, err = dashboard.GetQuickStats(ctx); err != nil {
+		return err
+	}
+	if result.StudyProgress, err = dashboard.GetStudyProgress(ctx); err != nil {
+		return err
+	}
+	if result.LastStudySession, err = dashboard.GetLastStudySession(ctx); err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// createUser creates the profile of the portal's single learner
+func createUser(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("user", flag.ContinueOnError)
+	name := flags.String("name", "", "name of the learner")
+	email := flags.String("email", "", "email of the learner, optional")
+	args, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	if args[0] != "create" || *name == "" {
+		return fmt.Errorf("%w: user create needs --name", errUsage)
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	learner, err := services.NewLearnerService(db).CreateLearner(ctx, &models.CreateLearnerRequest{Name: *name, Email: *email})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("created learner %s\n", learner.Name)
+	return nil
+}
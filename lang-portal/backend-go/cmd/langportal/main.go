@@ -0,0 +1,119 @@
+// Command langportal runs the lang-portal server and its operational tasks:
+// migrations, seeding, importing and exporting vocabulary, backups and
+// statistics. It is configured through the same environment variables as
+// the server. Its user create command sets up the learner's profile.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/config"
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+)
+
+const usage = `Usage: langportal <command> [arguments]
+
+Commands:
+  serve                                  run the API server
+  migrate up|down|status                 apply, roll back the latest, or list schema migrations
+  seed                                   apply the built-in seed packs
+  import words --group ID|NAME --file PATH
+                                         add the words of an export to a group, creating it by name
+  export group ID|NAME [--file PATH]     write a group's words as JSON
+  backup PATH                            copy the SQLite database to PATH while it is in use
+  restore PATH                           replace the SQLite database with a backup; stop the server first
+  stats                                  print study statistics as JSON
+  user create --name NAME [--email EMAIL]
+                                         create the profile of the portal's single learner
+
+The database is selected by DB_DRIVER, DB_PATH and DATABASE_URL, as for the server.
+`
+
+// errUsage reports a command line that does not match the usage
+var errUsage = errors.New("invalid usage")
+
+// command runs a subcommand with its arguments
+type command func(ctx context.Context, cfg *config.Config, args []string) error
+
+var commands = map[string]command{
+	"serve":   serve,
+	"migrate": migrate,
+	"seed":    seed,
+	"import":  importWords,
+	"export":  exportGroup,
+	"backup":  backup,
+	"restore": restore,
+	"stats":   stats,
+	"user":    createUser,
+}
+
+func main() {
+	// Logs go to stderr, so the output of commands can be piped
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339})
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	run, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	// Interrupting a command cancels it; the server handles signals itself
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	err := run(ctx, config.Load(), os.Args[2:])
+	stop()
+
+	switch {
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(os.Stderr, "%v\n\n%s", err, usage)
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "langportal %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+// openDB opens the configured database
+func openDB(cfg *config.Config) (*repository.SQLRepository, error) {
+	db, err := repository.Open(cfg.DBDriver, cfg.DatabaseDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return db, nil
+}
+
+// parseFlags parses the flags of a subcommand, which may come before or
+// after its positional arguments, and returns the positional arguments,
+// which must number want
+func parseFlags(flags *flag.FlagSet, args []string, want int) ([]string, error) {
+	flags.SetOutput(os.Stderr)
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+	if len(positional) != want {
+		return nil, fmt.Errorf("%w: %s takes %d argument(s)", errUsage, flags.Name(), want)
+	}
+	return positional, nil
+}
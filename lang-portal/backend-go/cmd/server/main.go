@@ -4,28 +4,21 @@
 package main
 
 import (
-	"context"
-	"fmt"
-	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
-	"github.com/jeevanions/lang-portal/backend-go/internal/db/seeder"
-
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	_ "github.com/jeevanions/lang-portal/backend-go/docs" // Import swagger docs
-	"github.com/jeevanions/lang-portal/backend-go/internal/api/router"
 	"github.com/jeevanions/lang-portal/backend-go/internal/config"
 	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/server"
 )
 
-// @title Italian Language Learning Portal API
+// @title Language Learning Portal API
 // @version 1.0
-// @description API for the Italian Language Learning Portal
+// @description API for the Language Learning Portal
 // @host localhost:8080
 // @BasePath /
 // @schemes http https
@@ -37,44 +30,13 @@ func main() {
 	cfg := config.Load()
 
 	// Initialize database
-	db, err := repository.NewDB(cfg.DBPath)
+	db, err := repository.Open(cfg.DBDriver, cfg.DatabaseDSN())
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to initialize database")
 	}
 	defer db.Close()
 
-	// Initialize seeder
-	seeder := seeder.New(db)
-
-	// Initialize router
-	r := router.Setup(db, seeder)
-
-	// Initialize HTTP server
-	srv := &http.Server{
-		Addr:    fmt.Sprintf(":%d", cfg.Port),
-		Handler: r,
-	}
-
-	// Start server in a goroutine
-	go func() {
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatal().Err(err).Msg("Failed to start server")
-		}
-	}()
-
-	// Wait for interrupt signal to gracefully shutdown the server
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	log.Info().Msg("Shutting down server...")
-
-	// The context is used to inform the server it has 5 seconds to finish
-	// the request it is currently handling
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal().Err(err).Msg("Server forced to shutdown")
+	if err := server.Run(cfg, db); err != nil {
+		log.Fatal().Err(err).Msg("Server failed")
 	}
-
-	log.Info().Msg("Server exiting")
 }
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
+)
+
+type AnalyzerHandler struct {
+	service services.AnalyzerServiceInterface
+}
+
+func NewAnalyzerHandler(service services.AnalyzerServiceInterface) *AnalyzerHandler {
+	return &AnalyzerHandler{service: service}
+}
+
+// AnalyzeText godoc
+// @Summary Find known and unknown words in a text
+// @Description Splits a text into words, separating elisions such as l'amico and dell'anno, and maps each word to the course vocabulary through its lemma, recorded forms or stem. Returns the share of known words, the known words with their mastery and the unknown words.
+// @Tags analyze
+// @Accept json
+// @Produce json
+// @Param request body models.TextAnalysisRequest true "Text to analyze"
+// @Success 200 {object} models.TextAnalysisResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/analyze/text [post]
+func (h *AnalyzerHandler) AnalyzeText(c *gin.Context) {
+	var req models.TextAnalysisRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	response, err := h.service.AnalyzeText(c.Request.Context(), &req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AddUnknownWords godoc
+// @Summary Add unknown words from a text to a group
+// @Description Adds words picked from a text analysis, with their translations, to the vocabulary and a group. Words that are forms of existing vocabulary entries are reported as already known instead.
+// @Tags analyze
+// @Accept json
+// @Produce json
+// @Param request body models.AddUnknownWordsRequest true "Words to add"
+// @Success 200 {object} models.AddUnknownWordsResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/analyze/unknown_words [post]
+func (h *AnalyzerHandler) AddUnknownWords(c *gin.Context) {
+	var req models.AddUnknownWordsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	response, err := h.service.AddUnknownWords(c.Request.Context(), &req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type MockAnalyzerService struct {
+	mock.Mock
+}
+
+func (m *MockAnalyzerService) AnalyzeText(_ context.Context, req *models.TextAnalysisRequest) (*models.TextAnalysisResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TextAnalysisResponse), args.Error(1)
+}
+
+func (m *MockAnalyzerService) AddUnknownWords(_ context.Context, req *models.AddUnknownWordsRequest) (*models.AddUnknownWordsResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AddUnknownWordsResponse), args.Error(1)
+}
+
+func TestAnalyzerHandler_AnalyzeText(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockSetup  func(*MockAnalyzerService)
+		wantStatus int
+	}{
+		{
+			name: "analyzed",
+			body: `{"text": "L'amico di mia sorella"}`,
+			mockSetup: func(m *MockAnalyzerService) {
+				m.On("AnalyzeText", &models.TextAnalysisRequest{Text: "L'amico di mia sorella"}).
+					Return(&models.TextAnalysisResponse{TotalTokens: 5, KnownTokens: 2, Coverage: 40}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing text",
+			body:       `{"course_id": 1}`,
+			mockSetup:  func(m *MockAnalyzerService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown course",
+			body: `{"text": "ciao", "course_id": 9}`,
+			mockSetup: func(m *MockAnalyzerService) {
+				m.On("AnalyzeText", mock.Anything).Return(nil, models.NotFoundError("course"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAnalyzerService)
+			tt.mockSetup(mockService)
+			handler := NewAnalyzerHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/analyze/text", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			serve(c, handler.AnalyzeText)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnalyzerHandler_AddUnknownWords(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockSetup  func(*MockAnalyzerService)
+		wantStatus int
+	}{
+		{
+			name: "added",
+			body: `{"group_id": 1, "words": [{"term": "amico", "translation": "friend"}]}`,
+			mockSetup: func(m *MockAnalyzerService) {
+				m.On("AddUnknownWords", mock.Anything).Return(&models.AddUnknownWordsResponse{AddedCount: 1, AlreadyKnown: []string{}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing translation",
+			body:       `{"group_id": 1, "words": [{"term": "amico"}]}`,
+			mockSetup:  func(m *MockAnalyzerService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "no words",
+			body:       `{"group_id": 1, "words": []}`,
+			mockSetup:  func(m *MockAnalyzerService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "group not found",
+			body: `{"group_id": 99, "words": [{"term": "amico", "translation": "friend"}]}`,
+			mockSetup: func(m *MockAnalyzerService) {
+				m.On("AddUnknownWords", mock.Anything).Return(nil, models.NotFoundError("group"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAnalyzerService)
+			tt.mockSetup(mockService)
+			handler := NewAnalyzerHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/analyze/unknown_words", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			serve(c, handler.AddUnknownWords)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
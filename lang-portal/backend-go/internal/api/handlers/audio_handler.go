@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
+)
+
+type AudioHandler struct {
+	service services.AudioServiceInterface
+}
+
+func NewAudioHandler(service services.AudioServiceInterface) *AudioHandler {
+	return &AudioHandler{service: service}
+}
+
+// GetWordAudio godoc
+// @Summary Get word pronunciation audio
+// @Description Streams the pronunciation of a word, synthesizing it with the configured TTS provider on first use. Supports Range requests.
+// @Tags words
+// @Produce audio/wav,audio/mpeg,audio/ogg,audio/webm
+// @Param id path int true "Word ID"
+// @Param voice query string false "TTS voice (default: TTS_VOICE)"
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
+// @Success 200 {file} binary
+// @Success 206 {file} binary
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 416 {string} string "Range not satisfiable"
+// @Failure 502 {object} ProblemResponse
+// @Failure 503 {object} ProblemResponse
+// @Router /api/words/{id}/audio [get]
+func (h *AudioHandler) GetWordAudio(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid word ID")
+		invalid(c, "Invalid word ID")
+		return
+	}
+
+	audio, err := h.service.GetWordAudio(c.Request.Context(), id, c.Query("voice"))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	file, err := os.Open(audio.Path)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	// ServeContent handles Range, If-Range and If-Modified-Since
+	c.Header("Content-Type", audio.ContentType)
+	c.Header("Cache-Control", "public, max-age=86400")
+	http.ServeContent(c.Writer, c.Request, filepath.Base(audio.Path), info.ModTime(), file)
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type MockAudioService struct {
+	mock.Mock
+}
+
+func (m *MockAudioService) GetWordAudio(ctx context.Context, wordID int64, voice string) (*models.WordAudio, error) {
+	args := m.Called(wordID, voice)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WordAudio), args.Error(1)
+}
+
+func (m *MockAudioService) GenerateGroupAudio(ctx context.Context, groupID int64, voice string, progress func(done, total int)) (*models.GenerateAudioJobResult, error) {
+	args := m.Called(groupID, voice)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GenerateAudioJobResult), args.Error(1)
+}
+
+func TestAudioHandler_GetWordAudio(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	path := filepath.Join(t.TempDir(), "1.wav")
+	require.NoError(t, os.WriteFile(path, []byte("0123456789"), 0o644))
+	audio := &models.WordAudio{Path: path, ContentType: "audio/wav"}
+
+	tests := []struct {
+		name        string
+		url         string
+		rangeHeader string
+		mockSetup   func(*MockAudioService)
+		wantStatus  int
+		wantBody    string
+		wantHeaders map[string]string
+	}{
+		{
+			name: "whole file",
+			url:  "/api/words/1/audio",
+			mockSetup: func(m *MockAudioService) {
+				m.On("GetWordAudio", int64(1), "").Return(audio, nil)
+			},
+			wantStatus:  http.StatusOK,
+			wantBody:    "0123456789",
+			wantHeaders: map[string]string{"Content-Type": "audio/wav", "Accept-Ranges": "bytes"},
+		},
+		{
+			name:        "byte range",
+			url:         "/api/words/1/audio?voice=anna",
+			rangeHeader: "bytes=2-5",
+			mockSetup: func(m *MockAudioService) {
+				m.On("GetWordAudio", int64(1), "anna").Return(audio, nil)
+			},
+			wantStatus:  http.StatusPartialContent,
+			wantBody:    "2345",
+			wantHeaders: map[string]string{"Content-Type": "audio/wav", "Content-Range": "bytes 2-5/10"},
+		},
+		{
+			name:        "unsatisfiable range",
+			url:         "/api/words/1/audio",
+			rangeHeader: "bytes=20-30",
+			mockSetup: func(m *MockAudioService) {
+				m.On("GetWordAudio", int64(1), "").Return(audio, nil)
+			},
+			wantStatus: http.StatusRequestedRangeNotSatisfiable,
+		},
+		{
+			name:       "invalid word ID",
+			url:        "/api/words/abc/audio",
+			mockSetup:  func(m *MockAudioService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "word not found",
+			url:  "/api/words/99/audio",
+			mockSetup: func(m *MockAudioService) {
+				m.On("GetWordAudio", int64(99), "").Return(nil, models.NotFoundError("word"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "tts not configured",
+			url:  "/api/words/1/audio",
+			mockSetup: func(m *MockAudioService) {
+				m.On("GetWordAudio", int64(1), "").Return(nil, models.NewError(models.ErrUnavailable, "tts_not_configured", "text-to-speech is not configured"))
+			},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "provider failure",
+			url:  "/api/words/1/audio",
+			mockSetup: func(m *MockAudioService) {
+				m.On("GetWordAudio", int64(1), "").Return(nil, models.WrapError(models.ErrUpstream, "tts_failed", "failed to synthesize audio", errors.New("timeout")))
+			},
+			wantStatus: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAudioService)
+			tt.mockSetup(mockService)
+			handler := NewAudioHandler(mockService)
+
+			router := gin.New()
+			router.Use(Problems())
+			router.GET("/api/words/:id/audio", handler.GetWordAudio)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.rangeHeader != "" {
+				req.Header.Set("Range", tt.rangeHeader)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+			for header, value := range tt.wantHeaders {
+				assert.Equal(t, value, w.Header().Get(header), header)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
+)
+
+type CourseHandler struct {
+	service services.CourseServiceInterface
+}
+
+func NewCourseHandler(service services.CourseServiceInterface) *CourseHandler {
+	return &CourseHandler{service: service}
+}
+
+// GetLanguages godoc
+// @Summary List languages
+// @Description Returns the languages courses can be created for
+// @Tags courses
+// @Produce json
+// @Success 200 {object} models.LanguageListResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/languages [get]
+func (h *CourseHandler) GetLanguages(c *gin.Context) {
+	languages, err := h.service.GetLanguages(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, languages)
+}
+
+// CreateLanguage godoc
+// @Summary Add a language
+// @Description Adds a language with the writing system its words are validated against
+// @Tags courses
+// @Accept json
+// @Produce json
+// @Param request body models.CreateLanguageRequest true "Language to add"
+// @Success 201 {object} models.LanguageResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 409 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/languages [post]
+func (h *CourseHandler) CreateLanguage(c *gin.Context) {
+	var req models.CreateLanguageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	language, err := h.service.CreateLanguage(c.Request.Context(), &req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, language)
+}
+
+// GetCourses godoc
+// @Summary List courses
+// @Description Returns every course with its source and target language
+// @Tags courses
+// @Produce json
+// @Success 200 {object} models.CourseListResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/courses [get]
+func (h *CourseHandler) GetCourses(c *gin.Context) {
+	courses, err := h.service.GetCourses(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, courses)
+}
+
+// GetCourse godoc
+// @Summary Get a course
+// @Description Returns a course with its source and target language
+// @Tags courses
+// @Produce json
+// @Param id path int true "Course ID"
+// @Success 200 {object} models.CourseResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/courses/{id} [get]
+func (h *CourseHandler) GetCourse(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid course ID")
+		invalid(c, "Invalid course ID")
+		return
+	}
+
+	course, err := h.service.GetCourse(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, course)
+}
+
+// CreateCourse godoc
+// @Summary Add a course
+// @Description Adds a course teaching the target language to speakers of the source language
+// @Tags courses
+// @Accept json
+// @Produce json
+// @Param request body models.CreateCourseRequest true "Course to add"
+// @Success 201 {object} models.CourseResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 409 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/courses [post]
+func (h *CourseHandler) CreateCourse(c *gin.Context) {
+	var req models.CreateCourseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	course, err := h.service.CreateCourse(c.Request.Context(), &req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, course)
+}
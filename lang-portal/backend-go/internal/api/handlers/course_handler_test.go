@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type MockCourseService struct {
+	mock.Mock
+}
+
+func (m *MockCourseService) GetLanguages(_ context.Context) (*models.LanguageListResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LanguageListResponse), args.Error(1)
+}
+
+func (m *MockCourseService) CreateLanguage(_ context.Context, req *models.CreateLanguageRequest) (*models.LanguageResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LanguageResponse), args.Error(1)
+}
+
+func (m *MockCourseService) GetCourses(_ context.Context) (*models.CourseListResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseListResponse), args.Error(1)
+}
+
+func (m *MockCourseService) GetCourse(_ context.Context, id int64) (*models.CourseResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseResponse), args.Error(1)
+}
+
+func (m *MockCourseService) CreateCourse(_ context.Context, req *models.CreateCourseRequest) (*models.CourseResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseResponse), args.Error(1)
+}
+
+func TestCourseHandler_CreateCourse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockSetup  func(*MockCourseService)
+		wantStatus int
+	}{
+		{
+			name: "created",
+			body: `{"name": "Japanese for English speakers", "source_language": "en", "target_language": "ja"}`,
+			mockSetup: func(m *MockCourseService) {
+				m.On("CreateCourse", &models.CreateCourseRequest{Name: "Japanese for English speakers", SourceLanguage: "en", TargetLanguage: "ja"}).
+					Return(&models.CourseResponse{ID: 2, Name: "Japanese for English speakers"}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "missing target language",
+			body:       `{"name": "Japanese", "source_language": "en"}`,
+			mockSetup:  func(m *MockCourseService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown language",
+			body: `{"name": "Klingon", "source_language": "en", "target_language": "tlh"}`,
+			mockSetup: func(m *MockCourseService) {
+				m.On("CreateCourse", mock.Anything).Return(nil, models.ValidationError("invalid_course", "invalid course: unknown language tlh"))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "already exists",
+			body: `{"name": "Italian", "source_language": "en", "target_language": "it"}`,
+			mockSetup: func(m *MockCourseService) {
+				m.On("CreateCourse", mock.Anything).Return(nil, models.ConflictError("course_exists", "course for en to it already exists"))
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockCourseService)
+			tt.mockSetup(mockService)
+			handler := NewCourseHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/courses", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			serve(c, handler.CreateCourse)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
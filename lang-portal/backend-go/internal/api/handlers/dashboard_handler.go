@@ -4,8 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/rs/zerolog/log"
 
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
 )
 
@@ -26,13 +26,13 @@ func NewDashboardHandler(service services.DashboardServiceInterface) *DashboardH
 // @Success 200 {object} models.DashboardLastStudySession
 // @Router /api/dashboard/last_study_session [get]
 func (h *DashboardHandler) GetLastStudySession(c *gin.Context) {
-	session, err := h.service.GetLastStudySession()
+	session, err := h.service.GetLastStudySession(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
 	if session == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No study sessions found"})
+		fail(c, models.NewError(models.ErrNotFound, "no_study_sessions", "no study sessions found"))
 		return
 	}
 	c.JSON(http.StatusOK, session)
@@ -47,10 +47,9 @@ func (h *DashboardHandler) GetLastStudySession(c *gin.Context) {
 // @Success 200 {object} models.DashboardStudyProgress
 // @Router /api/dashboard/study_progress [get]
 func (h *DashboardHandler) GetStudyProgress(c *gin.Context) {
-	progress, err := h.service.GetStudyProgress()
+	progress, err := h.service.GetStudyProgress(c.Request.Context())
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get study progress")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		fail(c, err)
 		return
 	}
 
@@ -66,10 +65,9 @@ func (h *DashboardHandler) GetStudyProgress(c *gin.Context) {
 // @Success 200 {object} models.DashboardQuickStats
 // @Router /api/dashboard/quick-stats [get]
 func (h *DashboardHandler) GetQuickStats(c *gin.Context) {
-	stats, err := h.service.GetQuickStats()
+	stats, err := h.service.GetQuickStats(c.Request.Context())
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get quick stats")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		fail(c, err)
 		return
 	}
 
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -24,7 +25,7 @@ type MockDashboardService struct {
 // Verify that MockDashboardService implements services.DashboardServiceInterface
 var _ services.DashboardServiceInterface = (*MockDashboardService)(nil)
 
-func (m *MockDashboardService) GetLastStudySession() (*models.DashboardLastStudySession, error) {
+func (m *MockDashboardService) GetLastStudySession(_ context.Context) (*models.DashboardLastStudySession, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -32,7 +33,7 @@ func (m *MockDashboardService) GetLastStudySession() (*models.DashboardLastStudy
 	return args.Get(0).(*models.DashboardLastStudySession), args.Error(1)
 }
 
-func (m *MockDashboardService) GetStudyProgress() (*models.DashboardStudyProgress, error) {
+func (m *MockDashboardService) GetStudyProgress(_ context.Context) (*models.DashboardStudyProgress, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -40,7 +41,7 @@ func (m *MockDashboardService) GetStudyProgress() (*models.DashboardStudyProgres
 	return args.Get(0).(*models.DashboardStudyProgress), args.Error(1)
 }
 
-func (m *MockDashboardService) GetQuickStats() (*models.DashboardQuickStats, error) {
+func (m *MockDashboardService) GetQuickStats(_ context.Context) (*models.DashboardQuickStats, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -69,7 +70,7 @@ func TestDashboardHandler_GetLastStudySession(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 
 		// Act
-		handler.GetLastStudySession(c)
+		serve(c, handler.GetLastStudySession)
 
 		// Assert
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -89,16 +90,17 @@ func TestDashboardHandler_GetLastStudySession(t *testing.T) {
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/api/dashboard/last_study_session", nil)
 
 		// Act
-		handler.GetLastStudySession(c)
+		serve(c, handler.GetLastStudySession)
 
 		// Assert
 		assert.Equal(t, http.StatusNotFound, w.Code)
-		var response gin.H
+		var response ProblemResponse
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "No study sessions found", response["error"])
+		assert.Equal(t, "no_study_sessions", response.Code)
 		mockService.AssertExpectations(t)
 	})
 }
@@ -121,7 +123,7 @@ func TestDashboardHandler_GetStudyProgress(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 
 		// Act
-		handler.GetStudyProgress(c)
+		serve(c, handler.GetStudyProgress)
 
 		// Assert
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -143,7 +145,7 @@ func TestDashboardHandler_GetStudyProgress(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 
 		// Act
-		handler.GetStudyProgress(c)
+		serve(c, handler.GetStudyProgress)
 
 		// Assert
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -162,7 +164,7 @@ func TestDashboardHandler_GetStudyProgress(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 
 		// Act
-		handler.GetStudyProgress(c)
+		serve(c, handler.GetStudyProgress)
 
 		// Assert
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -197,7 +199,7 @@ func TestDashboardHandler_GetQuickStats(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 
 		// Act
-		handler.GetQuickStats(c)
+		serve(c, handler.GetQuickStats)
 
 		// Assert
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -221,7 +223,7 @@ func TestDashboardHandler_GetQuickStats(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 
 		// Act
-		handler.GetQuickStats(c)
+		serve(c, handler.GetQuickStats)
 
 		// Assert
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -242,7 +244,7 @@ func TestDashboardHandler_GetQuickStats(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 
 		// Act
-		handler.GetQuickStats(c)
+		serve(c, handler.GetQuickStats)
 
 		// Assert
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestContextKey stores the request context as it was before any deadline
+// was applied
+const requestContextKey = "handlers.requestContext"
+
+// Deadline bounds the request context by d, so services and repositories stop
+// working on the request once it expires. A deadline set on a route replaces
+// the one of its group instead of nesting within it, so routes can allow more
+// time than their group. A zero d removes the deadline.
+func Deadline(d time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parent, ok := c.Value(requestContextKey).(context.Context)
+		if !ok {
+			parent = c.Request.Context()
+			c.Set(requestContextKey, parent)
+		}
+
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if d > 0 {
+			ctx, cancel = context.WithTimeout(parent, d)
+		} else {
+			ctx, cancel = context.WithCancel(parent)
+		}
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		// Failures are described before cancel, while the context still tells
+		// whether the deadline passed
+		writeProblem(c)
+	}
+}
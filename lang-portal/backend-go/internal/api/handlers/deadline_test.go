@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// waitForDeadline blocks like a slow query until the request context ends
+	waitForDeadline := func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			fail(c, c.Request.Context().Err())
+		case <-time.After(time.Second):
+			c.Status(http.StatusOK)
+		}
+	}
+
+	router := gin.New()
+	router.Use(Problems())
+	api := router.Group("/api", Deadline(10*time.Millisecond))
+	api.GET("/slow", waitForDeadline)
+	api.GET("/longer", Deadline(50*time.Millisecond), func(c *gin.Context) {
+		deadline, _ := c.Request.Context().Deadline()
+		c.JSON(http.StatusOK, gin.H{"remaining_ms": time.Until(deadline).Milliseconds()})
+	})
+	api.GET("/unbounded", Deadline(0), func(c *gin.Context) {
+		_, ok := c.Request.Context().Deadline()
+		c.JSON(http.StatusOK, gin.H{"has_deadline": ok})
+	})
+
+	t.Run("expired deadline", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/slow", nil))
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+		var problem ProblemResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, "request_timeout", problem.Code)
+	})
+
+	t.Run("route deadline replaces the group deadline", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/longer", nil))
+
+		var response struct {
+			RemainingMs int64 `json:"remaining_ms"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Greater(t, response.RemainingMs, int64(10))
+	})
+
+	t.Run("zero removes the deadline", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/unbounded", nil))
+
+		assert.JSONEq(t, `{"has_deadline": false}`, w.Body.String())
+	})
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// DataVersions reports the versions ETags are derived from: the combined
+// version of tables, which changes with every write to one of them, and the
+// version of a group
+type DataVersions interface {
+	GetTableVersion(ctx context.Context, tables ...string) (int64, error)
+	GetGroupVersion(ctx context.Context, id int64) (int64, error)
+}
+
+// ETagFunc returns the ETag of the response to a request, or "" to leave it
+// untagged
+type ETagFunc func(c *gin.Context) (string, error)
+
+// ETags tags GET and HEAD responses with the ETag that tag returns. Requests
+// whose If-None-Match holds the current tag are answered with 304 Not
+// Modified without running the handler. Responses must depend only on the URL
+// and the data the tag covers.
+func ETags(tag ETagFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		current, err := tag(c)
+		if err != nil {
+			fail(c, err)
+			return
+		}
+		if current == "" {
+			c.Next()
+			return
+		}
+
+		c.Header("ETag", current)
+		c.Header("Cache-Control", "no-cache")
+		if matchesAny(c.GetHeader("If-None-Match"), current, false) {
+			c.AbortWithStatus(http.StatusNotModified)
+			return
+		}
+
+		c.Next()
+
+		// Failures are written later, by the Problems middleware, and are
+		// not tagged
+		if len(c.Errors) > 0 {
+			c.Writer.Header().Del("ETag")
+			c.Writer.Header().Del("Cache-Control")
+		}
+	}
+}
+
+// TablesETag tags responses read from tables with their combined version, so
+// writes to other tables leave the tags as they are
+func TablesETag(versions DataVersions, tables ...string) ETagFunc {
+	return func(c *gin.Context) (string, error) {
+		version, err := versions.GetTableVersion(c.Request.Context(), tables...)
+		if err != nil {
+			return "", err
+		}
+		return etag(version), nil
+	}
+}
+
+// DailyTablesETag works like TablesETag for responses that also change with
+// the day, like the dashboard's study streak, by adding the UTC date to the
+// tags. They cannot be used with If-Match.
+func DailyTablesETag(versions DataVersions, tables ...string) ETagFunc {
+	return func(c *gin.Context) (string, error) {
+		version, err := versions.GetTableVersion(c.Request.Context(), tables...)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(`"%d-%s"`, version, time.Now().UTC().Format("20060102")), nil
+	}
+}
+
+// GroupETag tags responses describing the group in the id parameter with the
+// group's version, which moves when the group is renamed or its words change.
+// Renames with If-Match are checked against it. Invalid IDs are left to the
+// handler to reject.
+func GroupETag(versions DataVersions) ETagFunc {
+	return func(c *gin.Context) (string, error) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			return "", nil
+		}
+		version, err := versions.GetGroupVersion(c.Request.Context(), id)
+		if err != nil {
+			return "", err
+		}
+		return etag(version), nil
+	}
+}
+
+// etag returns the strong ETag of a version
+func etag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// matchesAny reports whether the list of entity tags in an If-None-Match or
+// If-Match header holds tag or *. The strong comparison of If-Match never
+// matches weak tags.
+func matchesAny(header, tag string, strong bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if weak := strings.TrimPrefix(candidate, "W/"); weak != candidate {
+			if strong {
+				continue
+			}
+			candidate = weak
+		}
+		if candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion returns the version the If-Match header of a write
+// conditions it on, or 0 when the write is unconditional. Tags that cannot
+// match a version, e.g. weak or dated ones, fail the request.
+func ifMatchVersion(c *gin.Context) (int64, error) {
+	header := c.GetHeader("If-Match")
+	if header == "" || matchesAny(header, "*", true) {
+		return 0, nil
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if len(candidate) < 2 || !strings.HasPrefix(candidate, `"`) || !strings.HasSuffix(candidate, `"`) {
+			continue
+		}
+		version, err := strconv.ParseInt(candidate[1:len(candidate)-1], 10, 64)
+		if err == nil && version > 0 {
+			return version, nil
+		}
+	}
+	return 0, models.VersionMismatchError()
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// dataVersions are table and group versions that tests can move
+type dataVersions struct {
+	tables map[string]int64
+	groups map[int64]int64
+	err    error
+}
+
+func (v *dataVersions) GetTableVersion(_ context.Context, tables ...string) (int64, error) {
+	var version int64
+	for _, table := range tables {
+		version += v.tables[table]
+	}
+	return version, v.err
+}
+
+func (v *dataVersions) GetGroupVersion(_ context.Context, id int64) (int64, error) {
+	version, ok := v.groups[id]
+	if !ok {
+		return 0, models.NotFoundError("group")
+	}
+	return version, v.err
+}
+
+func TestETags(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	versions := &dataVersions{tables: map[string]int64{"groups": 3, "words_groups": 4, "words": 10}}
+	calls := 0
+	router := gin.New()
+	router.Use(Problems())
+	api := router.Group("/api", ETags(TablesETag(versions, "groups", "words_groups")))
+	api.GET("/groups", func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusOK, gin.H{"items": []string{}})
+	})
+	api.GET("/broken", func(c *gin.Context) {
+		fail(c, errors.New("boom"))
+	})
+	api.POST("/groups", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	get := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("tags responses", func(t *testing.T) {
+		w := get("/api/groups", "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, etag(7), w.Header().Get("ETag"))
+		assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		calls = 0
+		for _, header := range []string{etag(7), `"1-20200101", ` + etag(7), "W/" + etag(7), "*"} {
+			w := get("/api/groups", header)
+
+			assert.Equal(t, http.StatusNotModified, w.Code, header)
+			assert.Empty(t, w.Body.String())
+			assert.Equal(t, etag(7), w.Header().Get("ETag"))
+		}
+		assert.Zero(t, calls)
+	})
+
+	t.Run("modified since", func(t *testing.T) {
+		old := etag(7)
+		versions.tables["words_groups"]++
+		defer func() { versions.tables["words_groups"]-- }()
+
+		w := get("/api/groups", old)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, etag(8), w.Header().Get("ETag"))
+	})
+
+	t.Run("other tables written", func(t *testing.T) {
+		versions.tables["words"]++
+		defer func() { versions.tables["words"]-- }()
+
+		w := get("/api/groups", etag(7))
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("failures are not tagged", func(t *testing.T) {
+		w := get("/api/broken", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get("ETag"))
+	})
+
+	t.Run("version unavailable", func(t *testing.T) {
+		versions.err = errors.New("database is locked")
+		defer func() { versions.err = nil }()
+
+		w := get("/api/groups", "")
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Empty(t, w.Header().Get("ETag"))
+	})
+
+	t.Run("writes pass through", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/groups", nil))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Empty(t, w.Header().Get("ETag"))
+	})
+}
+
+func TestGroupETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	versions := &dataVersions{groups: map[int64]int64{1: 5}}
+	router := gin.New()
+	router.Use(Problems())
+	router.GET("/api/groups/:id", ETags(GroupETag(versions)), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantETag   string
+	}{
+		{name: "group version", path: "/api/groups/1", wantStatus: http.StatusOK, wantETag: etag(5)},
+		{name: "group not found", path: "/api/groups/2", wantStatus: http.StatusNotFound},
+		{name: "invalid ID left to the handler", path: "/api/groups/abc", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantETag, w.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestDailyTablesETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	versions := &dataVersions{tables: map[string]int64{"study_sessions": 2, "word_review_items": 5}}
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/dashboard/quick-stats", nil)
+
+	tag, err := DailyTablesETag(versions, "study_sessions", "word_review_items")(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `"7-`+time.Now().UTC().Format("20060102")+`"`, tag)
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    int64
+		wantErr bool
+	}{
+		{name: "absent", want: 0},
+		{name: "any", ifMatch: "*", want: 0},
+		{name: "current tag", ifMatch: etag(42), want: 42},
+		{name: "list", ifMatch: `"x", ` + etag(42), want: 42},
+		{name: "weak tag", ifMatch: "W/" + etag(42), wantErr: true},
+		{name: "dated tag", ifMatch: `"42-20200101"`, wantErr: true},
+		{name: "malformed", ifMatch: "42", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			version, err := ifMatchVersion(c)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, version)
+		})
+	}
+}
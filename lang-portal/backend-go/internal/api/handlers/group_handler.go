@@ -27,16 +27,22 @@ func NewGroupHandler(service services.GroupServiceInterface) *GroupHandler {
 // @Produce json
 // @Param limit query int false "Number of items per page" default(100)
 // @Param offset query int false "Offset for pagination" default(0)
+// @Param page query int false "Page number counted from 1, instead of offset"
+// @Param sort query string false "Field to sort by" Enums(id, name, word_count)
+// @Param order query string false "Sort order" Enums(asc, desc)
 // @Success 200 {object} models.GroupListResponse
+// @Failure 400 {object} handlers.ProblemResponse
 // @Router /api/groups [get]
 func (h *GroupHandler) GetGroups(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	params, err := parseListParams(c, listQuery{sortFields: models.GroupSortFields})
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
 
-	groups, err := h.service.GetGroups(limit, offset)
+	groups, err := h.service.GetGroups(c.Request.Context(), params)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get groups")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		fail(c, err)
 		return
 	}
 
@@ -56,19 +62,13 @@ func (h *GroupHandler) GetGroupByID(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Error().Err(err).Msg("Invalid group ID")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID"})
+		invalid(c, "Invalid group ID")
 		return
 	}
 
-	group, err := h.service.GetGroupByID(id)
+	group, err := h.service.GetGroupByID(c.Request.Context(), id)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get group")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		return
-	}
-
-	if group == nil {
-		c.JSON(http.StatusOK, models.GroupDetailResponse{})
+		fail(c, err)
 		return
 	}
 
@@ -84,23 +84,26 @@ func (h *GroupHandler) GetGroupByID(c *gin.Context) {
 // @Param id path int true "Group ID"
 // @Param limit query int false "Number of items per page" default(100)
 // @Param offset query int false "Offset for pagination" default(0)
+// @Param page query int false "Page number counted from 1, instead of offset"
 // @Success 200 {object} models.GroupWordsResponse
 // @Router /api/groups/{id}/words [get]
 func (h *GroupHandler) GetGroupWords(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Error().Err(err).Msg("Invalid group ID")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID"})
+		invalid(c, "Invalid group ID")
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	params, err := parseListParams(c, listQuery{})
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
 
-	words, err := h.service.GetGroupWords(groupID, limit, offset)
+	words, err := h.service.GetGroupWords(c.Request.Context(), groupID, params.Limit, params.Offset)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get group words")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		fail(c, err)
 		return
 	}
 
@@ -116,29 +119,77 @@ func (h *GroupHandler) GetGroupWords(c *gin.Context) {
 // @Param id path int true "Group ID"
 // @Param limit query int false "Number of items per page" default(100)
 // @Param offset query int false "Offset for pagination" default(0)
+// @Param page query int false "Page number counted from 1, instead of offset"
 // @Success 200 {object} models.GroupStudySessionsResponse
 // @Router /api/groups/{id}/study_sessions [get]
 func (h *GroupHandler) GetGroupStudySessions(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Error().Err(err).Msg("Invalid group ID")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid group ID"})
+		invalid(c, "Invalid group ID")
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	params, err := parseListParams(c, listQuery{})
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
 
-	sessions, err := h.service.GetGroupStudySessions(groupID, limit, offset)
+	sessions, err := h.service.GetGroupStudySessions(c.Request.Context(), groupID, params.Limit, params.Offset)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get group study sessions")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		fail(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, sessions)
 }
 
+// UpdateGroup godoc
+// @Summary Rename a group
+// @Description Renames a group. With If-Match set to the ETag of GET /api/groups/{id}, the group is only renamed if it was not renamed and its words did not change since, so edits made elsewhere are not silently overwritten.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param If-Match header string false "ETag of the group the edit is based on"
+// @Param group body models.UpdateGroupRequest true "New name of the group"
+// @Success 200 {object} models.GroupDetailResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Failure 404 {object} handlers.ProblemResponse
+// @Failure 412 {object} handlers.ProblemResponse
+// @Router /api/groups/{id} [put]
+func (h *GroupHandler) UpdateGroup(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid group ID")
+		invalid(c, "Invalid group ID")
+		return
+	}
+
+	var req models.UpdateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	ifVersion, err := ifMatchVersion(c)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	group, version, err := h.service.UpdateGroup(c.Request.Context(), id, &req, ifVersion)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.Header("ETag", etag(version))
+	c.JSON(http.StatusOK, group)
+}
+
 // CreateGroup godoc
 // @Summary Create a new thematic group
 // @Description Creates a new group for organizing vocabulary words
@@ -147,18 +198,18 @@ func (h *GroupHandler) GetGroupStudySessions(c *gin.Context) {
 // @Produce json
 // @Param name query string true "Name of the thematic group"
 // @Success 200 {object} models.GroupResponse
-// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 400 {object} handlers.ProblemResponse
 // @Router /api/groups [post]
 func (h *GroupHandler) CreateGroup(c *gin.Context) {
 	name := c.Query("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "group name is required"})
+		invalid(c, "group name is required")
 		return
 	}
 
-	group, err := h.service.CreateGroup(name)
+	group, err := h.service.CreateGroup(c.Request.Context(), name)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		fail(c, err)
 		return
 	}
 
@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -18,15 +20,15 @@ type MockGroupService struct {
 	mock.Mock
 }
 
-func (m *MockGroupService) GetGroups(limit, offset int) (*models.GroupListResponse, error) {
-	args := m.Called(limit, offset)
+func (m *MockGroupService) GetGroups(_ context.Context, params models.ListParams) (*models.GroupListResponse, error) {
+	args := m.Called(params)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.GroupListResponse), args.Error(1)
 }
 
-func (m *MockGroupService) GetGroupByID(id int64) (*models.GroupDetailResponse, error) {
+func (m *MockGroupService) GetGroupByID(_ context.Context, id int64) (*models.GroupDetailResponse, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -34,7 +36,7 @@ func (m *MockGroupService) GetGroupByID(id int64) (*models.GroupDetailResponse,
 	return args.Get(0).(*models.GroupDetailResponse), args.Error(1)
 }
 
-func (m *MockGroupService) GetGroupWords(groupID int64, limit, offset int) (*models.GroupWordsResponse, error) {
+func (m *MockGroupService) GetGroupWords(_ context.Context, groupID int64, limit, offset int) (*models.GroupWordsResponse, error) {
 	args := m.Called(groupID, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -42,7 +44,7 @@ func (m *MockGroupService) GetGroupWords(groupID int64, limit, offset int) (*mod
 	return args.Get(0).(*models.GroupWordsResponse), args.Error(1)
 }
 
-func (m *MockGroupService) GetGroupStudySessions(groupID int64, limit, offset int) (*models.GroupStudySessionsResponse, error) {
+func (m *MockGroupService) GetGroupStudySessions(_ context.Context, groupID int64, limit, offset int) (*models.GroupStudySessionsResponse, error) {
 	args := m.Called(groupID, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -50,7 +52,7 @@ func (m *MockGroupService) GetGroupStudySessions(groupID int64, limit, offset in
 	return args.Get(0).(*models.GroupStudySessionsResponse), args.Error(1)
 }
 
-func (m *MockGroupService) CreateGroup(name string) (*models.GroupResponse, error) {
+func (m *MockGroupService) CreateGroup(_ context.Context, name string) (*models.GroupResponse, error) {
 	args := m.Called(name)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -58,6 +60,14 @@ func (m *MockGroupService) CreateGroup(name string) (*models.GroupResponse, erro
 	return args.Get(0).(*models.GroupResponse), args.Error(1)
 }
 
+func (m *MockGroupService) UpdateGroup(_ context.Context, id int64, req *models.UpdateGroupRequest, ifVersion int64) (*models.GroupDetailResponse, int64, error) {
+	args := m.Called(id, req, ifVersion)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).(*models.GroupDetailResponse), args.Get(1).(int64), args.Error(2)
+}
+
 func TestGroupHandler_GetGroups(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -74,7 +84,7 @@ func TestGroupHandler_GetGroups(t *testing.T) {
 			limit:  "10",
 			offset: "0",
 			mockSetup: func(m *MockGroupService) {
-				m.On("GetGroups", 10, 0).Return(&models.GroupListResponse{
+				m.On("GetGroups", models.ListParams{Limit: 10}).Return(&models.GroupListResponse{
 					Items: []models.GroupResponse{
 						{ID: 1, Name: "Basic Words", WordCount: 10},
 					},
@@ -104,7 +114,7 @@ func TestGroupHandler_GetGroups(t *testing.T) {
 			limit:  "10",
 			offset: "0",
 			mockSetup: func(m *MockGroupService) {
-				m.On("GetGroups", 10, 0).Return(nil, errors.New("service error"))
+				m.On("GetGroups", models.ListParams{Limit: 10}).Return(nil, errors.New("service error"))
 			},
 			wantStatus: http.StatusInternalServerError,
 		},
@@ -120,7 +130,7 @@ func TestGroupHandler_GetGroups(t *testing.T) {
 			c, _ := gin.CreateTestContext(w)
 			c.Request = httptest.NewRequest("GET", "/?limit="+tt.limit+"&offset="+tt.offset, nil)
 
-			handler.GetGroups(c)
+			serve(c, handler.GetGroups)
 
 			assert.Equal(t, tt.wantStatus, w.Code)
 			if tt.wantBody != nil {
@@ -169,10 +179,9 @@ func TestGroupHandler_GetGroupByID(t *testing.T) {
 			name:    "group not found",
 			groupID: "999",
 			mockSetup: func(m *MockGroupService) {
-				m.On("GetGroupByID", int64(999)).Return(nil, nil)
+				m.On("GetGroupByID", int64(999)).Return(nil, models.NotFoundError("group"))
 			},
-			wantStatus: http.StatusOK,
-			wantBody:   &models.GroupDetailResponse{},
+			wantStatus: http.StatusNotFound,
 		},
 		{
 			name:    "invalid id",
@@ -195,7 +204,7 @@ func TestGroupHandler_GetGroupByID(t *testing.T) {
 			c.Params = []gin.Param{{Key: "id", Value: tt.groupID}}
 			c.Request = httptest.NewRequest("GET", "/groups/"+tt.groupID, nil)
 
-			handler.GetGroupByID(c)
+			serve(c, handler.GetGroupByID)
 
 			assert.Equal(t, tt.wantStatus, w.Code)
 			if tt.wantBody != nil {
@@ -209,6 +218,102 @@ func TestGroupHandler_GetGroupByID(t *testing.T) {
 	}
 }
 
+func TestGroupHandler_UpdateGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	req := &models.UpdateGroupRequest{Name: "Food"}
+	group := &models.GroupDetailResponse{ID: 1, Name: "Food"}
+
+	tests := []struct {
+		name       string
+		groupID    string
+		body       string
+		ifMatch    string
+		mockSetup  func(*MockGroupService)
+		wantStatus int
+		wantETag   string
+	}{
+		{
+			name:    "unconditional",
+			groupID: "1",
+			body:    `{"name": "Food"}`,
+			mockSetup: func(m *MockGroupService) {
+				m.On("UpdateGroup", int64(1), req, int64(0)).Return(group, int64(8), nil)
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   etag(8),
+		},
+		{
+			name:    "if match",
+			groupID: "1",
+			body:    `{"name": "Food"}`,
+			ifMatch: etag(7),
+			mockSetup: func(m *MockGroupService) {
+				m.On("UpdateGroup", int64(1), req, int64(7)).Return(group, int64(8), nil)
+			},
+			wantStatus: http.StatusOK,
+			wantETag:   etag(8),
+		},
+		{
+			name:    "changed since read",
+			groupID: "1",
+			body:    `{"name": "Food"}`,
+			ifMatch: etag(6),
+			mockSetup: func(m *MockGroupService) {
+				m.On("UpdateGroup", int64(1), req, int64(6)).Return(nil, int64(0), models.VersionMismatchError())
+			},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "dated tag",
+			groupID:    "1",
+			body:       `{"name": "Food"}`,
+			ifMatch:    `"6-20200101"`,
+			mockSetup:  func(m *MockGroupService) {},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "group not found",
+			groupID: "999",
+			body:    `{"name": "Food"}`,
+			mockSetup: func(m *MockGroupService) {
+				m.On("UpdateGroup", int64(999), req, int64(0)).Return(nil, int64(0), models.NotFoundError("group"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "missing name",
+			groupID:    "1",
+			body:       `{}`,
+			mockSetup:  func(m *MockGroupService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockGroupService)
+			tt.mockSetup(mockService)
+			handler := NewGroupHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = []gin.Param{{Key: "id", Value: tt.groupID}}
+			c.Request = httptest.NewRequest(http.MethodPut, "/groups/"+tt.groupID, strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			serve(c, handler.UpdateGroup)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantETag, w.Header().Get("ETag"))
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestGroupHandler_GetGroupWords(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -229,7 +334,7 @@ func TestGroupHandler_GetGroupWords(t *testing.T) {
 			mockSetup: func(m *MockGroupService) {
 				m.On("GetGroupWords", int64(1), 10, 0).Return(&models.GroupWordsResponse{
 					Items: []models.WordResponse{
-						{ID: 1, Italian: "ciao", English: "hello"},
+						{ID: 1, Term: "ciao", Translation: "hello"},
 					},
 					Pagination: models.PaginationResponse{
 						CurrentPage:  1,
@@ -242,7 +347,7 @@ func TestGroupHandler_GetGroupWords(t *testing.T) {
 			wantStatus: http.StatusOK,
 			wantBody: &models.GroupWordsResponse{
 				Items: []models.WordResponse{
-					{ID: 1, Italian: "ciao", English: "hello"},
+					{ID: 1, Term: "ciao", Translation: "hello"},
 				},
 				Pagination: models.PaginationResponse{
 					CurrentPage:  1,
@@ -285,7 +390,7 @@ func TestGroupHandler_GetGroupWords(t *testing.T) {
 			c.Params = []gin.Param{{Key: "id", Value: tt.groupID}}
 			c.Request = httptest.NewRequest("GET", "/groups/"+tt.groupID+"/words?limit="+tt.limit+"&offset="+tt.offset, nil)
 
-			handler.GetGroupWords(c)
+			serve(c, handler.GetGroupWords)
 
 			assert.Equal(t, tt.wantStatus, w.Code)
 			if tt.wantBody != nil {
@@ -387,7 +492,7 @@ func TestGroupHandler_GetGroupStudySessions(t *testing.T) {
 			c.Params = []gin.Param{{Key: "id", Value: tt.groupID}}
 			c.Request = httptest.NewRequest("GET", "/groups/"+tt.groupID+"/study_sessions?limit="+tt.limit+"&offset="+tt.offset, nil)
 
-			handler.GetGroupStudySessions(c)
+			serve(c, handler.GetGroupStudySessions)
 
 			assert.Equal(t, tt.wantStatus, w.Code)
 			if tt.wantBody != nil {
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
+)
+
+type JobHandler struct {
+	service services.JobServiceInterface
+}
+
+func NewJobHandler(service services.JobServiceInterface) *JobHandler {
+	return &JobHandler{service: service}
+}
+
+// CreateJob godoc
+// @Summary Queue a background job
+// @Description Queues word generation, sentence generation or a word import to run in the background. Poll the returned job for status, progress and result.
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Param request body models.CreateJobRequest true "Job type and payload"
+// @Success 202 {object} models.Job
+// @Failure 400 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/jobs [post]
+func (h *JobHandler) CreateJob(c *gin.Context) {
+	var req models.CreateJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	job, err := h.service.CreateJob(c.Request.Context(), &req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetJobs godoc
+// @Summary List background jobs
+// @Description Returns a paginated list of jobs, newest first
+// @Tags jobs
+// @Produce json
+// @Param status query string false "Only jobs with this status" Enums(pending, running, completed, failed, cancelled)
+// @Param limit query int false "Number of items per page" default(100)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param page query int false "Page number counted from 1, instead of offset"
+// @Param cursor query string false "Cursor of the page to fetch, from pagination.next_cursor of the previous page"
+// @Success 200 {object} models.JobListResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/jobs [get]
+func (h *JobHandler) GetJobs(c *gin.Context) {
+	status, err := queryEnum(c, "status", "",
+		models.JobStatusPending, models.JobStatusRunning, models.JobStatusCompleted, models.JobStatusFailed, models.JobStatusCancelled)
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
+
+	params, err := parseListParams(c, listQuery{cursor: true})
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
+
+	jobs, err := h.service.GetJobs(c.Request.Context(), status, params)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetJob godoc
+// @Summary Get a background job
+// @Description Returns the status, progress and, once finished, the result or error of a job
+// @Tags jobs
+// @Produce json
+// @Param id path int true "Job ID"
+// @Success 200 {object} models.Job
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/jobs/{id} [get]
+func (h *JobHandler) GetJob(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid job ID")
+		invalid(c, "Invalid job ID")
+		return
+	}
+
+	job, err := h.service.GetJob(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// CancelJob godoc
+// @Summary Cancel a background job
+// @Description Cancels a pending job or stops a running one
+// @Tags jobs
+// @Produce json
+// @Param id path int true "Job ID"
+// @Success 200 {object} models.Job
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 409 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/jobs/{id}/cancel [post]
+func (h *JobHandler) CancelJob(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid job ID")
+		invalid(c, "Invalid job ID")
+		return
+	}
+
+	job, err := h.service.CancelJob(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type MockJobService struct {
+	mock.Mock
+}
+
+func (m *MockJobService) CreateJob(_ context.Context, req *models.CreateJobRequest) (*models.Job, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Job), args.Error(1)
+}
+
+func (m *MockJobService) GetJob(_ context.Context, id int64) (*models.Job, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Job), args.Error(1)
+}
+
+func (m *MockJobService) GetJobs(_ context.Context, status string, params models.ListParams) (*models.JobListResponse, error) {
+	args := m.Called(status, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.JobListResponse), args.Error(1)
+}
+
+func (m *MockJobService) CancelJob(_ context.Context, id int64) (*models.Job, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Job), args.Error(1)
+}
+
+func TestJobHandler_CreateJob(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockSetup  func(*MockJobService)
+		wantStatus int
+	}{
+		{
+			name: "queued",
+			body: `{"type": "generate_words", "payload": {"category": "food"}}`,
+			mockSetup: func(m *MockJobService) {
+				m.On("CreateJob", mock.Anything).Return(&models.Job{ID: 1, Type: "generate_words", Status: "pending"}, nil)
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "unknown type",
+			body:       `{"type": "translate", "payload": {}}`,
+			mockSetup:  func(m *MockJobService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid payload",
+			body: `{"type": "generate_words", "payload": {}}`,
+			mockSetup: func(m *MockJobService) {
+				m.On("CreateJob", mock.Anything).Return(nil, models.ValidationError("invalid_job_payload", "invalid job payload: category is required"))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockJobService)
+			tt.mockSetup(mockService)
+			handler := NewJobHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/jobs", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			serve(c, handler.CreateJob)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJobHandler_CancelJob(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		id         string
+		mockSetup  func(*MockJobService)
+		wantStatus int
+	}{
+		{
+			name: "cancelled",
+			id:   "1",
+			mockSetup: func(m *MockJobService) {
+				m.On("CancelJob", int64(1)).Return(&models.Job{ID: 1, Status: "cancelled"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "already finished",
+			id:   "1",
+			mockSetup: func(m *MockJobService) {
+				m.On("CancelJob", int64(1)).Return(nil, models.ConflictError("job_not_cancellable", "job cannot be cancelled: job is already completed"))
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "not found",
+			id:   "9",
+			mockSetup: func(m *MockJobService) {
+				m.On("CancelJob", int64(9)).Return(nil, models.NotFoundError("job"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockJobService)
+			tt.mockSetup(mockService)
+			handler := NewJobHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = []gin.Param{{Key: "id", Value: tt.id}}
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/jobs/"+tt.id+"/cancel", nil)
+
+			serve(c, handler.CancelJob)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
+)
+
+type ListeningHandler struct {
+	service services.ListeningServiceInterface
+}
+
+func NewListeningHandler(service services.ListeningServiceInterface) *ListeningHandler {
+	return &ListeningHandler{service: service}
+}
+
+// GetExercises godoc
+// @Summary Get listening exercises
+// @Description Returns a paginated list of listening comprehension exercises, newest first, optionally only those of one course
+// @Tags listening
+// @Produce json
+// @Param course_id query int false "Only exercises of this course"
+// @Param limit query int false "Number of items per page" default(100)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param page query int false "Page number counted from 1, instead of offset"
+// @Success 200 {object} models.ListeningExerciseListResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Failure 500 {object} handlers.ProblemResponse
+// @Router /api/listening/exercises [get]
+func (h *ListeningHandler) GetExercises(c *gin.Context) {
+	params, err := parseListParams(c, listQuery{})
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
+
+	courseID, err := queryID(c, "course_id")
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
+
+	exercises, err := h.service.GetExercises(c.Request.Context(), courseID, params.Limit, params.Offset)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, exercises)
+}
+
+// GetExercise godoc
+// @Summary Get a listening exercise
+// @Description Returns a listening exercise with its timed transcript and its multiple-choice questions. The correct options are only returned when a question is answered.
+// @Tags listening
+// @Produce json
+// @Param id path int true "Exercise ID"
+// @Success 200 {object} models.ListeningExerciseResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Failure 404 {object} handlers.ProblemResponse
+// @Failure 500 {object} handlers.ProblemResponse
+// @Router /api/listening/exercises/{id} [get]
+func (h *ListeningHandler) GetExercise(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		invalid(c, "Invalid exercise ID")
+		return
+	}
+
+	exercise, err := h.service.GetExercise(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, exercise)
+}
+
+// ImportExercise godoc
+// @Summary Import listening-comp pipeline output
+// @Description Stores a structured_<timestamp>.json file written by the listening-comp pipeline as a listening exercise. Importing the same file again returns the existing exercise with status 200. With group_id, the file's vocabulary is added to the group, skipping words already known.
+// @Tags listening
+// @Accept json
+// @Produce json
+// @Param course_id query int false "Course of the exercise, defaults to the Italian course"
+// @Param group_id query int false "Group to add the vocabulary to"
+// @Param source_url query string false "URL of the video the exercise was built from"
+// @Param output body models.ListeningPipelineOutput true "Pipeline output"
+// @Success 201 {object} models.ListeningImportResponse
+// @Success 200 {object} models.ListeningImportResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Failure 404 {object} handlers.ProblemResponse
+// @Failure 500 {object} handlers.ProblemResponse
+// @Router /api/listening/exercises/import [post]
+func (h *ListeningHandler) ImportExercise(c *gin.Context) {
+	var opts models.ListeningImportOptions
+	var err error
+	if opts.CourseID, err = queryID(c, "course_id"); err != nil {
+		invalid(c, err.Error())
+		return
+	}
+	if opts.GroupID, err = queryID(c, "group_id"); err != nil {
+		invalid(c, err.Error())
+		return
+	}
+	opts.SourceURL = c.Query("source_url")
+
+	var output models.ListeningPipelineOutput
+	if err := c.ShouldBindJSON(&output); err != nil {
+		log.Error().Err(err).Msg("Invalid pipeline output")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	response, err := h.service.ImportPipelineOutput(c.Request.Context(), &output, opts)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if !response.Created {
+		status = http.StatusOK
+	}
+	c.JSON(status, response)
+}
+
+// SubmitAnswer godoc
+// @Summary Answer a listening question
+// @Description Grades the chosen option of a listening exercise question and records the answer in the study session
+// @Tags study_sessions
+// @Accept json
+// @Produce json
+// @Param id path int true "Study Session ID"
+// @Param answer body models.ListeningAnswerRequest true "Chosen option"
+// @Success 200 {object} models.ListeningAnswerResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Failure 404 {object} handlers.ProblemResponse
+// @Failure 500 {object} handlers.ProblemResponse
+// @Router /api/study_sessions/{id}/listening_answers [post]
+func (h *ListeningHandler) SubmitAnswer(c *gin.Context) {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		invalid(c, "Invalid study session ID")
+		return
+	}
+
+	var req models.ListeningAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	response, err := h.service.SubmitAnswer(c.Request.Context(), sessionID, &req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
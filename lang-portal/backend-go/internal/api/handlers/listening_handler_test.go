@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type MockListeningService struct {
+	mock.Mock
+}
+
+func (m *MockListeningService) GetExercises(_ context.Context, courseID int64, limit, offset int) (*models.ListeningExerciseListResponse, error) {
+	args := m.Called(courseID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ListeningExerciseListResponse), args.Error(1)
+}
+
+func (m *MockListeningService) GetExercise(_ context.Context, id int64) (*models.ListeningExerciseResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ListeningExerciseResponse), args.Error(1)
+}
+
+func (m *MockListeningService) ImportPipelineOutput(_ context.Context, output *models.ListeningPipelineOutput, opts models.ListeningImportOptions) (*models.ListeningImportResponse, error) {
+	args := m.Called(output, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ListeningImportResponse), args.Error(1)
+}
+
+func (m *MockListeningService) SubmitAnswer(_ context.Context, sessionID int64, req *models.ListeningAnswerRequest) (*models.ListeningAnswerResponse, error) {
+	args := m.Called(sessionID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ListeningAnswerResponse), args.Error(1)
+}
+
+func TestListeningHandler_ImportExercise(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := `{"metadata": {"video_title": "Roma Metro"}, "dialogue": [{"italian_text": "Ciao", "audio_timestamp": "00:05"}], "exercises": []}`
+
+	tests := []struct {
+		name       string
+		query      string
+		body       string
+		mockSetup  func(*MockListeningService)
+		wantStatus int
+	}{
+		{
+			name:  "created",
+			query: "?group_id=3",
+			body:  body,
+			mockSetup: func(m *MockListeningService) {
+				m.On("ImportPipelineOutput", mock.Anything, models.ListeningImportOptions{GroupID: 3}).
+					Return(&models.ListeningImportResponse{ExerciseID: 1, Created: true}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "already imported",
+			body: body,
+			mockSetup: func(m *MockListeningService) {
+				m.On("ImportPipelineOutput", mock.Anything, mock.Anything).
+					Return(&models.ListeningImportResponse{ExerciseID: 1}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing title",
+			body:       `{"metadata": {}, "dialogue": []}`,
+			mockSetup:  func(m *MockListeningService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "answer not an option",
+			body: body,
+			mockSetup: func(m *MockListeningService) {
+				m.On("ImportPipelineOutput", mock.Anything, mock.Anything).
+					Return(nil, models.ValidationError("invalid_listening_exercise", "invalid listening exercise: the answer to question 1 is not one of its options"))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "group not found",
+			query: "?group_id=99",
+			body:  body,
+			mockSetup: func(m *MockListeningService) {
+				m.On("ImportPipelineOutput", mock.Anything, mock.Anything).Return(nil, models.NotFoundError("group"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockListeningService)
+			tt.mockSetup(mockService)
+			handler := NewListeningHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/listening/exercises/import"+tt.query, bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			serve(c, handler.ImportExercise)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListeningHandler_SubmitAnswer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		sessionID  string
+		body       string
+		mockSetup  func(*MockListeningService)
+		wantStatus int
+	}{
+		{
+			name:      "answered",
+			sessionID: "2",
+			body:      `{"question_id": 4, "selected_option": 0}`,
+			mockSetup: func(m *MockListeningService) {
+				m.On("SubmitAnswer", int64(2), mock.MatchedBy(func(req *models.ListeningAnswerRequest) bool {
+					return req.QuestionID == 4 && *req.SelectedOption == 0
+				})).Return(&models.ListeningAnswerResponse{QuestionID: 4, CorrectOption: 1}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing option",
+			sessionID:  "2",
+			body:       `{"question_id": 4}`,
+			mockSetup:  func(m *MockListeningService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid session",
+			sessionID:  "abc",
+			body:       `{"question_id": 4, "selected_option": 0}`,
+			mockSetup:  func(m *MockListeningService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "question not found",
+			sessionID: "2",
+			body:      `{"question_id": 9, "selected_option": 0}`,
+			mockSetup: func(m *MockListeningService) {
+				m.On("SubmitAnswer", int64(2), mock.Anything).Return(nil, models.NotFoundError("listening question"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockListeningService)
+			tt.mockSetup(mockService)
+			handler := NewListeningHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: tt.sessionID}}
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/study_sessions/"+tt.sessionID+"/listening_answers", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			serve(c, handler.SubmitAnswer)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -12,14 +12,6 @@ import (
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
 )
 
-// ErrorResponse represents an error response
-// swagger:model
-type ErrorResponse struct {
-	// Error message
-	// required: true
-	Error string `json:"error" example:"Invalid request format"`
-}
-
 type LLMHandler struct {
 	service services.LLMServiceInterface
 }
@@ -29,34 +21,209 @@ func NewLLMHandler(service services.LLMServiceInterface) *LLMHandler {
 }
 
 // GenerateWords godoc
-// @Summary Generate Italian words for a thematic category
-// @Description Uses LLM to generate Italian words with translations and grammatical details for a given thematic category
+// @Summary Generate words for a thematic category
+// @Description Uses LLM to generate words in a course's target language with translations and grammatical details for a given thematic category. Words not written in the course's languages are dropped.
 // @Tags words
 // @Accept json
 // @Produce json
 // @Param request body models.GenerateWordsRequest true "Category for word generation"
+// @Param cache query string false "Completion cache option" Enums(bypass, refresh)
 // @Success 200 {object} models.GenerateWordsResponse
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 429 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
 // @Router /api/words/llm/generate-words [post]
 func (h *LLMHandler) GenerateWords(c *gin.Context) {
 	var req models.GenerateWordsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error().Err(err).Msg("Invalid request format")
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request format"})
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	cache, ok := cacheMode(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.GenerateWords(c.Request.Context(), &req, cache)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GenerateWordsStream godoc
+// @Summary Stream generated words for a thematic category
+// @Description Like generate-words, but streams each word as a Server-Sent "word" event as soon as the LLM has produced it, followed by a "summary" event with all words. Failures after the stream has started are sent as an "error" event.
+// @Tags words
+// @Accept json
+// @Produce text/event-stream
+// @Param request body models.GenerateWordsRequest true "Category for word generation"
+// @Param cache query string false "Completion cache option" Enums(bypass, refresh)
+// @Success 200 {object} models.GenerateWordsResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 429 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/words/llm/generate-words/stream [post]
+func (h *LLMHandler) GenerateWordsStream(c *gin.Context) {
+	var req models.GenerateWordsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	cache, ok := cacheMode(c)
+	if !ok {
+		return
+	}
+
+	stream := newSSEWriter(c)
+	response, err := h.service.StreamWords(c.Request.Context(), &req, cache, func(word models.WordResponse) error {
+		return stream.Send("word", word)
+	})
+	if err != nil {
+		if c.Request.Context().Err() != nil {
+			log.Info().Msg("Client disconnected from word generation stream")
+			return
+		}
+		stream.Fail(err)
+		return
+	}
+
+	stream.Send("summary", response)
+}
+
+// GenerateWordSentences godoc
+// @Summary Generate example sentences for a word
+// @Description Uses LLM to generate example sentences with translations for a word in its course's languages and stores them
+// @Tags words
+// @Accept json
+// @Produce json
+// @Param id path int true "Word ID"
+// @Param request body models.GenerateSentencesRequest false "Number of sentences to generate"
+// @Param cache query string false "Completion cache option" Enums(bypass, refresh)
+// @Success 200 {object} models.GenerateSentencesResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 429 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/words/{id}/sentences/generate [post]
+func (h *LLMHandler) GenerateWordSentences(c *gin.Context) {
+	wordID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid word ID")
+		invalid(c, "Invalid word ID")
+		return
+	}
+
+	var req models.GenerateSentencesRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			log.Error().Err(err).Msg("Invalid request format")
+			invalid(c, "Invalid request format")
+			return
+		}
+	}
+
+	cache, ok := cacheMode(c)
+	if !ok {
 		return
 	}
 
-	response, err := h.service.GenerateWords(req.Category)
+	response, err := h.service.GenerateSentences(c.Request.Context(), wordID, req.Count, cache)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to generate words")
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to generate words"})
+		fail(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// GetCacheStats godoc
+// @Summary Get LLM completion cache statistics
+// @Description Returns hit, miss and skip counters since the server started and the number of stored completions
+// @Tags llm
+// @Produce json
+// @Success 200 {object} models.LLMCacheStatsResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/llm/cache/stats [get]
+func (h *LLMHandler) GetCacheStats(c *gin.Context) {
+	stats, err := h.service.GetCacheStats(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetUsage godoc
+// @Summary Get LLM token usage and cost
+// @Description Summarises the LLM call ledger (calls, errors, tokens, estimated cost and latency) per day or per endpoint
+// @Tags llm
+// @Produce json
+// @Param group_by query string false "Grouping" Enums(day, endpoint) default(day)
+// @Param days query int false "How many days back to include, up to 366" default(30)
+// @Success 200 {object} models.LLMUsageSummaryResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/llm/usage [get]
+func (h *LLMHandler) GetUsage(c *gin.Context) {
+	groupBy, err := queryEnum(c, "group_by", "day", "day", "endpoint")
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
+
+	days, err := queryInt(c, "days", models.DefaultUsageDays, 1, models.MaxUsageDays)
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
+
+	usage, err := h.service.GetUsageSummary(c.Request.Context(), groupBy, days)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// GetBudget godoc
+// @Summary Get LLM budget status
+// @Description Returns this month's LLM spending and token usage against the configured monthly budgets
+// @Tags llm
+// @Produce json
+// @Success 200 {object} models.LLMBudgetResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/llm/budget [get]
+func (h *LLMHandler) GetBudget(c *gin.Context) {
+	budget, err := h.service.GetBudget(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+// cacheMode reads the "cache" query option, answering 400 when it is invalid
+func cacheMode(c *gin.Context) (models.CacheMode, bool) {
+	mode, ok := services.ParseCacheMode(c.Query("cache"))
+	if !ok {
+		invalid(c, "Invalid cache option, expected bypass or refresh")
+		return "", false
+	}
+	return mode, true
+}
+
 // CreateThematicGroup godoc
 // @Summary Add words to a group
 // @Description Adds new words to an existing group
@@ -66,43 +233,42 @@ func (h *LLMHandler) GenerateWords(c *gin.Context) {
 // @Param id path int true "Group ID"
 // @Param request body models.AddWordsToGroupRequest true "Words to add"
 // @Success 200 {object} models.AddWordsToGroupResponse
-// @Failure 400 {object} ErrorResponse
-// @Failure 404 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
 // @Router /api/groups/{id}/words [post]
 func (h *LLMHandler) CreateThematicGroup(c *gin.Context) {
 	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Error().Err(err).Msg("Invalid group ID")
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid group ID"})
+		invalid(c, "Invalid group ID")
 		return
 	}
 
 	var req models.AddWordsToGroupRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error().Err(err).Msg("Invalid request format")
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request format"})
+		invalid(c, "Invalid request format")
 		return
 	}
 
 	// Check if group exists
-	_, err = h.service.GetGroupByID(groupID)
+	_, err = h.service.GetGroupByID(c.Request.Context(), groupID)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to check group existence")
-		c.JSON(http.StatusNotFound, ErrorResponse{Error: "Group not found"})
+		fail(c, err)
 		return
 	}
 
 	// Add words to the group
 	wordsAdded := 0
 	for _, word := range req.Words {
-		wordID, err := h.service.CreateWord(&word)
+		wordID, err := h.service.FindOrCreateWord(c.Request.Context(), &word)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to create word")
 			continue
 		}
 
-		if err := h.service.AddWordToGroup(wordID, groupID); err != nil {
+		if err := h.service.AddWordToGroup(c.Request.Context(), wordID, groupID); err != nil {
 			log.Error().Err(err).Msg("Failed to add word to group")
 			continue
 		}
@@ -111,7 +277,7 @@ func (h *LLMHandler) CreateThematicGroup(c *gin.Context) {
 	}
 
 	// Update group words count
-	if err := h.service.UpdateGroupWordsCount(groupID); err != nil {
+	if err := h.service.UpdateGroupWordsCount(c.Request.Context(), groupID); err != nil {
 		log.Error().Err(err).Msg("Failed to update group words count")
 	}
 
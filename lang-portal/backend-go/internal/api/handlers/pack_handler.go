@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
+)
+
+// maxPackSize caps content pack uploads, which may carry audio for every word
+const maxPackSize = 100 << 20
+
+type PackHandler struct {
+	service services.PackServiceInterface
+}
+
+func NewPackHandler(service services.PackServiceInterface) *PackHandler {
+	return &PackHandler{service: service}
+}
+
+// InstallPack godoc
+// @Summary Install a content pack
+// @Description Installs a vocabulary pack from a zip holding pack.json (name, version, description, course_id) and optionally groups.json, words.json, words_groups.json, sentences.json and audio/<n>.wav|mp3|ogg|webm, the audio of the nth word. Installing a newer version updates the pack; the same version changes nothing but its audio, which is stored again.
+// @Tags packs
+// @Accept multipart/form-data
+// @Produce json
+// @Param pack formData file true "Content pack zip"
+// @Success 200 {object} models.InstallPackResponse "Already installed"
+// @Success 201 {object} models.InstallPackResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Failure 404 {object} handlers.ProblemResponse
+// @Failure 409 {object} handlers.ProblemResponse
+// @Failure 413 {object} handlers.ProblemResponse
+// @Router /api/packs/install [post]
+func (h *PackHandler) InstallPack(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxPackSize)
+	if err := c.Request.ParseMultipartForm(maxPackSize); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			fail(c, models.NewError(models.ErrTooLarge, "pack_too_large", "content pack upload is too large"))
+			return
+		}
+		invalid(c, "Invalid multipart form")
+		return
+	}
+
+	file, header, err := c.Request.FormFile("pack")
+	if err != nil {
+		invalid(c, "Pack file is required")
+		return
+	}
+	defer file.Close()
+
+	response, installed, err := h.service.InstallPack(c.Request.Context(), file, header.Size)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	status := http.StatusOK
+	if installed {
+		status = http.StatusCreated
+	}
+	c.JSON(status, response)
+}
+
+// GetPacks godoc
+// @Summary List installed content packs
+// @Tags packs
+// @Produce json
+// @Success 200 {object} models.ContentPackListResponse
+// @Router /api/packs [get]
+func (h *PackHandler) GetPacks(c *gin.Context) {
+	response, err := h.service.GetPacks(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// UninstallPack godoc
+// @Summary Uninstall a content pack
+// @Description Removes the pack and the words and groups it added. Words with review history are kept, as are groups with study sessions and anything another pack lists.
+// @Tags packs
+// @Produce json
+// @Param name path string true "Pack name"
+// @Success 200 {object} models.UninstallPackResponse
+// @Failure 404 {object} handlers.ProblemResponse
+// @Router /api/packs/{name} [delete]
+func (h *PackHandler) UninstallPack(c *gin.Context) {
+	response, err := h.service.UninstallPack(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type MockPackService struct {
+	mock.Mock
+}
+
+func (m *MockPackService) InstallPack(ctx context.Context, r io.ReaderAt, size int64) (*models.InstallPackResponse, bool, error) {
+	data, _ := io.ReadAll(io.NewSectionReader(r, 0, size))
+	args := m.Called(string(data))
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(*models.InstallPackResponse), args.Bool(1), args.Error(2)
+}
+
+func (m *MockPackService) GetPacks(ctx context.Context) (*models.ContentPackListResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ContentPackListResponse), args.Error(1)
+}
+
+func (m *MockPackService) UninstallPack(ctx context.Context, name string) (*models.UninstallPackResponse, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UninstallPackResponse), args.Error(1)
+}
+
+// packForm builds a multipart body with an optional pack file
+func packForm(t *testing.T, pack []byte) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if pack != nil {
+		file, err := form.CreateFormFile("pack", "a1-travel.zip")
+		require.NoError(t, err)
+		file.Write(pack)
+	}
+	require.NoError(t, form.Close())
+	return &body, form.FormDataContentType()
+}
+
+func TestPackHandler_InstallPack(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	installed := &models.InstallPackResponse{Pack: models.ContentPackResponse{Name: "a1-travel", Version: 1}}
+
+	tests := []struct {
+		name       string
+		pack       []byte
+		mockSetup  func(*MockPackService)
+		wantStatus int
+	}{
+		{
+			name: "installed",
+			pack: []byte("zip"),
+			mockSetup: func(m *MockPackService) {
+				m.On("InstallPack", "zip").Return(installed, true, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "already installed",
+			pack: []byte("zip"),
+			mockSetup: func(m *MockPackService) {
+				m.On("InstallPack", "zip").Return(installed, false, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing pack",
+			mockSetup:  func(m *MockPackService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "pack too large",
+			pack:       make([]byte, maxPackSize+1),
+			mockSetup:  func(m *MockPackService) {},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "invalid pack",
+			pack: []byte("not a zip"),
+			mockSetup: func(m *MockPackService) {
+				m.On("InstallPack", "not a zip").Return(nil, false, models.ValidationError("invalid_pack", "the pack is not a zip file"))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "newer version installed",
+			pack: []byte("zip"),
+			mockSetup: func(m *MockPackService) {
+				m.On("InstallPack", "zip").Return(nil, false, models.ConflictError("pack_version_older", "version 2 of pack a1-travel is installed, which is newer"))
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPackService)
+			tt.mockSetup(mockService)
+			handler := NewPackHandler(mockService)
+
+			router := gin.New()
+			router.Use(Problems())
+			router.POST("/api/packs/install", handler.InstallPack)
+
+			body, contentType := packForm(t, tt.pack)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/api/packs/install", body)
+			req.Header.Set("Content-Type", contentType)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPackHandler_UninstallPack(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockPackService)
+	mockService.On("UninstallPack", "a1-travel").Return(&models.UninstallPackResponse{RemovedWords: 110, KeptWords: 10, RemovedWordIDs: []int64{1}}, nil)
+	mockService.On("UninstallPack", "kitchen").Return(nil, models.NotFoundError("content pack"))
+	handler := NewPackHandler(mockService)
+
+	router := gin.New()
+	router.Use(Problems())
+	router.DELETE("/api/packs/:name", handler.UninstallPack)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/packs/a1-travel", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"removed_words": 110, "kept_words": 10, "removed_groups": 0}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodDelete, "/api/packs/kitchen", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
+)
+
+type PrivacyHandler struct {
+	service services.PrivacyServiceInterface
+}
+
+func NewPrivacyHandler(service services.PrivacyServiceInterface) *PrivacyHandler {
+	return &PrivacyHandler{service: service}
+}
+
+// ExportMyData godoc
+// @Summary Export the learner's data
+// @Description Streams a zip of JSON files with everything the portal stores about the learner: their profile, study sessions, reviews, progress per word, listening answers and tutor conversations. manifest.json describes the files and lists the kinds of data the portal does not keep. The portal has a single learner and no logins.
+// @Tags me
+// @Produce application/zip
+// @Success 200 {file} file
+// @Failure 500 {object} handlers.ProblemResponse
+// @Router /api/me/export [get]
+func (h *PrivacyHandler) ExportMyData(c *gin.Context) {
+	// The headers only go out with the first bytes of the zip, so an error
+	// reading the data is still answered with a problem document
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="lang-portal-export-%s.zip"`, time.Now().UTC().Format("2006-01-02")))
+	if err := h.service.Export(c.Request.Context(), c.Writer); err != nil {
+		if c.Writer.Written() {
+			// Too late for a problem document; the client gets a truncated zip
+			log.Error().Err(err).Msg("Data export failed while streaming")
+			c.Abort()
+			return
+		}
+		c.Writer.Header().Del("Content-Disposition")
+		fail(c, err)
+	}
+}
+
+// EraseMyData godoc
+// @Summary Erase the learner's data
+// @Description Deletes the learner's profile, study sessions, reviews, listening answers and tutor conversations in one transaction, and resets the review counts of words. Vocabulary, groups and content are shared and stay. Export the data first to keep a copy.
+// @Tags me
+// @Produce json
+// @Success 200 {object} models.EraseLearnerDataResponse
+// @Failure 500 {object} handlers.ProblemResponse
+// @Router /api/me [delete]
+func (h *PrivacyHandler) EraseMyData(c *gin.Context) {
+	erased, err := h.service.Erase(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, erased)
+}
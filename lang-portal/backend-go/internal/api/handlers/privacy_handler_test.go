@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type MockPrivacyService struct {
+	mock.Mock
+}
+
+func (m *MockPrivacyService) Export(ctx context.Context, w io.Writer) error {
+	args := m.Called(w)
+	return args.Error(0)
+}
+
+func (m *MockPrivacyService) Erase(ctx context.Context) (*models.EraseLearnerDataResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EraseLearnerDataResponse), args.Error(1)
+}
+
+func TestPrivacyHandler_ExportMyData(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name            string
+		mockSetup       func(*MockPrivacyService)
+		wantStatus      int
+		wantContentType string
+		wantAttachment  bool
+	}{
+		{
+			name: "zip",
+			mockSetup: func(m *MockPrivacyService) {
+				m.On("Export", mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(0).(io.Writer).Write([]byte("PK"))
+				}).Return(nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/zip",
+			wantAttachment:  true,
+		},
+		{
+			name: "error before streaming",
+			mockSetup: func(m *MockPrivacyService) {
+				m.On("Export", mock.Anything).Return(errors.New("database is locked"))
+			},
+			wantStatus:      http.StatusInternalServerError,
+			wantContentType: ProblemContentType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPrivacyService)
+			tt.mockSetup(mockService)
+			handler := NewPrivacyHandler(mockService)
+
+			router := gin.New()
+			router.Use(Problems())
+			router.GET("/api/me/export", handler.ExportMyData)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/api/me/export", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), tt.wantContentType)
+			assert.Equal(t, tt.wantAttachment, w.Header().Get("Content-Disposition") != "")
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPrivacyHandler_EraseMyData(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		mockSetup  func(*MockPrivacyService)
+		wantStatus int
+	}{
+		{
+			name: "erased",
+			mockSetup: func(m *MockPrivacyService) {
+				m.On("Erase").Return(&models.EraseLearnerDataResponse{StudySessions: 12, Reviews: 120}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "repository error",
+			mockSetup: func(m *MockPrivacyService) {
+				m.On("Erase").Return(nil, errors.New("database is locked"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPrivacyService)
+			tt.mockSetup(mockService)
+			handler := NewPrivacyHandler(mockService)
+
+			router := gin.New()
+			router.Use(Problems())
+			router.DELETE("/api/me", handler.EraseMyData)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/api/me", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
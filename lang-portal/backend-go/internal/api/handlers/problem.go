@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// ProblemContentType is the media type of error responses (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// ProblemResponse is the RFC 7807 problem document every failed request is
+// answered with
+// swagger:model
+type ProblemResponse struct {
+	// URI identifying the problem type, derived from code
+	Type string `json:"type" example:"urn:lang-portal:problem:group_not_found"`
+	// Short summary of the problem type
+	Title string `json:"title" example:"Not Found"`
+	// HTTP status code
+	Status int `json:"status" example:"404"`
+	// Explanation of this occurrence of the problem
+	Detail string `json:"detail,omitempty" example:"group not found"`
+	// Path of the request
+	Instance string `json:"instance,omitempty" example:"/api/groups/42"`
+	// Stable error code to branch on
+	Code string `json:"code" example:"group_not_found"`
+}
+
+// errorStatuses maps the kinds of domain errors to HTTP statuses
+var errorStatuses = []struct {
+	kind   error
+	status int
+}{
+	{models.ErrNotFound, http.StatusNotFound},
+	{models.ErrConflict, http.StatusConflict},
+	{models.ErrPrecondition, http.StatusPreconditionFailed},
+	{models.ErrValidation, http.StatusBadRequest},
+	{models.ErrLimitExceeded, http.StatusTooManyRequests},
+	{models.ErrTooLarge, http.StatusRequestEntityTooLarge},
+	{models.ErrUnavailable, http.StatusServiceUnavailable},
+	{models.ErrUpstream, http.StatusBadGateway},
+}
+
+// Problems writes the errors handlers report with fail as problem documents.
+// Unknown errors are logged and answered with a generic 500, so internal
+// details never reach clients.
+func Problems() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		writeProblem(c)
+	}
+}
+
+// fail reports err as the outcome of the request; the Problems middleware
+// writes the response
+func fail(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// invalid reports a malformed request, e.g. a bad parameter or body
+func invalid(c *gin.Context, message string) {
+	fail(c, models.ValidationError("invalid_request", message))
+}
+
+func writeProblem(c *gin.Context) {
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+	problem := newProblem(c, c.Errors.Last().Err)
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(problem.Status, problem)
+}
+
+// newProblem describes err as a problem document, logging errors that are
+// not the client's fault
+func newProblem(c *gin.Context, err error) ProblemResponse {
+	problem := ProblemResponse{
+		Status:   http.StatusInternalServerError,
+		Code:     "internal_error",
+		Detail:   "internal server error",
+		Instance: c.Request.URL.Path,
+	}
+
+	var domainErr *models.Error
+	if errors.As(err, &domainErr) {
+		for _, s := range errorStatuses {
+			if errors.Is(domainErr, s.kind) {
+				problem.Status = s.status
+				problem.Code = domainErr.Code
+				problem.Detail = domainErr.Message
+				break
+			}
+		}
+	} else {
+		// Work cut short by the request deadline or by shutdown surfaces as
+		// driver or transport errors, so the request context is checked too
+		switch ctx := c.Request.Context(); {
+		case errors.Is(err, context.DeadlineExceeded) || ctx.Err() == context.DeadlineExceeded:
+			problem.Status = http.StatusGatewayTimeout
+			problem.Code = "request_timeout"
+			problem.Detail = "the request did not complete in time"
+		case errors.Is(err, context.Canceled) || ctx.Err() == context.Canceled:
+			problem.Status = http.StatusServiceUnavailable
+			problem.Code = "request_cancelled"
+			problem.Detail = "the request was cancelled"
+		}
+	}
+
+	if problem.Status >= http.StatusInternalServerError {
+		log.Error().Err(err).Str("method", c.Request.Method).Str("path", c.Request.URL.Path).Msg("Request failed")
+	}
+
+	problem.Title = http.StatusText(problem.Status)
+	problem.Type = "urn:lang-portal:problem:" + problem.Code
+	return problem
+}
+
+// NoRoute answers requests for unknown paths
+func NoRoute(c *gin.Context) {
+	fail(c, models.NewError(models.ErrNotFound, "route_not_found", "no route for "+c.Request.Method+" "+c.Request.URL.Path))
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// serve runs a handler on a test context the way the router does, with the
+// Problems middleware writing any error it reports
+func serve(c *gin.Context, handler gin.HandlerFunc) {
+	if c.Request == nil {
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	}
+	handler(c)
+	writeProblem(c)
+}
+
+func TestProblems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{
+			name:       "not found",
+			err:        models.NotFoundError("group"),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "group_not_found",
+			wantDetail: "group not found",
+		},
+		{
+			name:       "wrapped conflict",
+			err:        fmt.Errorf("creating course: %w", models.ConflictError("course_exists", "course for en to it already exists")),
+			wantStatus: http.StatusConflict,
+			wantCode:   "course_exists",
+			wantDetail: "course for en to it already exists",
+		},
+		{
+			name:       "validation",
+			err:        models.ValidationError("invalid_request", "Invalid word ID"),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_request",
+			wantDetail: "Invalid word ID",
+		},
+		{
+			name:       "upstream failure hides the cause",
+			err:        models.WrapError(models.ErrUpstream, "tts_failed", "failed to synthesize audio", errors.New("api key sk-123 rejected")),
+			wantStatus: http.StatusBadGateway,
+			wantCode:   "tts_failed",
+			wantDetail: "failed to synthesize audio",
+		},
+		{
+			name:       "unknown error",
+			err:        errors.New("database is locked"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "internal_error",
+			wantDetail: "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/api/groups/42", nil)
+
+			serve(c, func(c *gin.Context) { fail(c, tt.err) })
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
+			var problem ProblemResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, ProblemResponse{
+				Type:     "urn:lang-portal:problem:" + tt.wantCode,
+				Title:    http.StatusText(tt.wantStatus),
+				Status:   tt.wantStatus,
+				Detail:   tt.wantDetail,
+				Instance: "/api/groups/42",
+				Code:     tt.wantCode,
+			}, problem)
+		})
+	}
+}
+
+func TestProblems_ResponseAlreadyWritten(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/api/words", nil)
+
+	serve(c, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"items": []string{}})
+		_ = c.Error(errors.New("logged after the response"))
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"items": []}`, w.Body.String())
+}
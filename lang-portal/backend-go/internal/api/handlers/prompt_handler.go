@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
+)
+
+type PromptHandler struct {
+	service services.PromptServiceInterface
+}
+
+func NewPromptHandler(service services.PromptServiceInterface) *PromptHandler {
+	return &PromptHandler{service: service}
+}
+
+// GetPrompts godoc
+// @Summary List prompt templates
+// @Description Returns every loaded prompt template version, where it was loaded from and whether it is active
+// @Tags admin
+// @Produce json
+// @Success 200 {object} models.PromptTemplateListResponse
+// @Router /api/admin/prompts [get]
+func (h *PromptHandler) GetPrompts(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.ListPrompts())
+}
+
+// PreviewPrompt godoc
+// @Summary Preview a prompt template
+// @Description Renders a prompt template version with the given variables without calling the LLM
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body models.PreviewPromptRequest true "Template and variables"
+// @Success 200 {object} models.PreviewPromptResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Router /api/admin/prompts/preview [post]
+func (h *PromptHandler) PreviewPrompt(c *gin.Context) {
+	var req models.PreviewPromptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	response, err := h.service.PreviewPrompt(&req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type MockPromptService struct {
+	mock.Mock
+}
+
+func (m *MockPromptService) ListPrompts() *models.PromptTemplateListResponse {
+	args := m.Called()
+	return args.Get(0).(*models.PromptTemplateListResponse)
+}
+
+func (m *MockPromptService) PreviewPrompt(req *models.PreviewPromptRequest) (*models.PreviewPromptResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PreviewPromptResponse), args.Error(1)
+}
+
+func TestPromptHandler_PreviewPrompt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockSetup  func(*MockPromptService)
+		wantStatus int
+	}{
+		{
+			name: "rendered",
+			body: `{"name": "generate_words", "category": "food", "count": 5}`,
+			mockSetup: func(m *MockPromptService) {
+				m.On("PreviewPrompt", &models.PreviewPromptRequest{Name: "generate_words", Category: "food", Count: 5}).
+					Return(&models.PreviewPromptResponse{PromptVersion: "generate_words@v2", Text: "Generate 5 Italian words"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing name",
+			body:       `{"category": "food"}`,
+			mockSetup:  func(m *MockPromptService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid level",
+			body:       `{"name": "generate_words", "level": "Z9"}`,
+			mockSetup:  func(m *MockPromptService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown version",
+			body: `{"name": "generate_words", "version": "v9"}`,
+			mockSetup: func(m *MockPromptService) {
+				m.On("PreviewPrompt", mock.Anything).Return(nil, models.NewError(models.ErrNotFound, "prompt_not_found", "prompt generate_words@v9 not found"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockPromptService)
+			tt.mockSetup(mockService)
+			handler := NewPromptHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/admin/prompts/preview", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			serve(c, handler.PreviewPrompt)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
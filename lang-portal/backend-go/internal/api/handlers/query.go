@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// listQuery describes the list parameters an endpoint accepts besides limit
+// and offset
+type listQuery struct {
+	// sortFields are the values the sort parameter may take; the list cannot
+	// be sorted when empty
+	sortFields []string
+	// cursor allows keyset pagination with the cursor parameter
+	cursor bool
+}
+
+// parseListParams reads and validates the paging parameters of a list
+// request: limit (1 to models.MaxPageLimit), then either offset, page
+// (counted from 1) or, where allowed, an opaque cursor, and where allowed
+// sort with order (asc or desc)
+func parseListParams(c *gin.Context, q listQuery) (models.ListParams, error) {
+	params := models.ListParams{Limit: models.DefaultPageLimit}
+
+	limit, err := queryInt(c, "limit", models.DefaultPageLimit, 1, models.MaxPageLimit)
+	if err != nil {
+		return params, err
+	}
+	params.Limit = limit
+
+	offset, hasOffset := c.GetQuery("offset")
+	page, hasPage := c.GetQuery("page")
+	cursor, hasCursor := c.GetQuery("cursor")
+	switch {
+	case hasOffset && hasPage, hasCursor && (hasOffset || hasPage):
+		return params, fmt.Errorf("use only one of offset, page and cursor")
+	case hasOffset:
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return params, fmt.Errorf("offset must be a number from 0")
+		}
+		params.Offset = value
+	case hasPage:
+		value, err := strconv.Atoi(page)
+		if err != nil || value < 1 || value > math.MaxInt32 {
+			return params, fmt.Errorf("page must be a number from 1")
+		}
+		params.Offset = (value - 1) * params.Limit
+	case hasCursor:
+		if !q.cursor {
+			return params, fmt.Errorf("this list does not support cursor pagination")
+		}
+		decoded, err := models.DecodeCursor(cursor)
+		if err != nil {
+			return params, err
+		}
+		params.Cursor = decoded
+	}
+
+	if sort, ok := c.GetQuery("sort"); ok {
+		if len(q.sortFields) == 0 {
+			return params, fmt.Errorf("this list cannot be sorted")
+		}
+		if !containsField(q.sortFields, sort) {
+			return params, fmt.Errorf("sort must be one of %s", strings.Join(q.sortFields, ", "))
+		}
+		params.Sort = sort
+	}
+	if order, ok := c.GetQuery("order"); ok {
+		if params.Sort == "" {
+			return params, fmt.Errorf("order requires sort")
+		}
+		switch order {
+		case "asc":
+		case "desc":
+			params.Desc = true
+		default:
+			return params, fmt.Errorf("order must be asc or desc")
+		}
+	}
+
+	return params, nil
+}
+
+// queryID reads an optional ID filter, 0 when it is absent
+func queryID(c *gin.Context, name string) (int64, error) {
+	value, ok := c.GetQuery(name)
+	if !ok {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("%s must be an ID", name)
+	}
+	return id, nil
+}
+
+// queryInt reads an optional number from min to max, def when it is absent
+func queryInt(c *gin.Context, name string, def, min, max int) (int, error) {
+	value, ok := c.GetQuery(name)
+	if !ok {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%s must be a number from %d to %d", name, min, max)
+	}
+	return n, nil
+}
+
+// queryEnum reads an optional parameter that takes one of a fixed set of
+// values, def when it is absent
+func queryEnum(c *gin.Context, name, def string, allowed ...string) (string, error) {
+	value, ok := c.GetQuery(name)
+	if !ok {
+		return def, nil
+	}
+	if !containsField(allowed, value) {
+		return "", fmt.Errorf("%s must be one of %s", name, strings.Join(allowed, ", "))
+	}
+	return value, nil
+}
+
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
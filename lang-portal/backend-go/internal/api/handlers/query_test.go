@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+func TestParseListParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cursor := models.Cursor{CreatedAt: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), ID: 42}
+	sortable := listQuery{sortFields: []string{"id", "name"}}
+
+	tests := []struct {
+		name    string
+		query   string
+		q       listQuery
+		want    models.ListParams
+		wantErr bool
+	}{
+		{name: "defaults", want: models.ListParams{Limit: models.DefaultPageLimit}},
+		{name: "limit and offset", query: "?limit=20&offset=40", want: models.ListParams{Limit: 20, Offset: 40}},
+		{name: "page", query: "?limit=20&page=3", want: models.ListParams{Limit: 20, Offset: 40}},
+		{name: "zero limit", query: "?limit=0", wantErr: true},
+		{name: "limit too large", query: "?limit=100000", wantErr: true},
+		{name: "limit not a number", query: "?limit=ten", wantErr: true},
+		{name: "negative offset", query: "?offset=-1", wantErr: true},
+		{name: "page zero", query: "?page=0", wantErr: true},
+		{name: "offset and page", query: "?offset=0&page=1", wantErr: true},
+		{
+			name:  "cursor",
+			query: "?cursor=" + cursor.Encode(),
+			q:     listQuery{cursor: true},
+			want:  models.ListParams{Limit: models.DefaultPageLimit, Cursor: &cursor},
+		},
+		{name: "cursor not supported", query: "?cursor=" + cursor.Encode(), wantErr: true},
+		{name: "cursor and offset", query: "?offset=0&cursor=" + cursor.Encode(), q: listQuery{cursor: true}, wantErr: true},
+		{name: "invalid cursor", query: "?cursor=abc", q: listQuery{cursor: true}, wantErr: true},
+		{
+			name:  "sort descending",
+			query: "?sort=name&order=desc",
+			q:     sortable,
+			want:  models.ListParams{Limit: models.DefaultPageLimit, Sort: "name", Desc: true},
+		},
+		{name: "unknown sort field", query: "?sort=password", q: sortable, wantErr: true},
+		{name: "sort not supported", query: "?sort=id", wantErr: true},
+		{name: "order without sort", query: "?order=desc", q: sortable, wantErr: true},
+		{name: "invalid order", query: "?sort=id&order=up", q: sortable, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest(http.MethodGet, "/api/items"+tt.query, nil)
+
+			params, err := parseListParams(c, tt.q)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, params)
+		})
+	}
+}
+
+func TestQueryInt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    int
+		wantErr string
+	}{
+		{name: "default", want: 30},
+		{name: "value", query: "?days=7", want: 7},
+		{name: "upper bound", query: "?days=366", want: 366},
+		{name: "below range", query: "?days=0", wantErr: "days must be a number from 1 to 366"},
+		{name: "above range", query: "?days=367", wantErr: "days must be a number from 1 to 366"},
+		{name: "not a number", query: "?days=week", wantErr: "days must be a number from 1 to 366"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest(http.MethodGet, "/api/llm/usage"+tt.query, nil)
+
+			days, err := queryInt(c, "days", 30, 1, 366)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, days)
+		})
+	}
+}
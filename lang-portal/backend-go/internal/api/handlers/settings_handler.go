@@ -25,11 +25,11 @@ func NewSettingsHandler(service services.SettingsServiceInterface) *SettingsHand
 // @Success 200 {object} map[string]string
 // @Router /api/reset_history [post]
 func (h *SettingsHandler) ResetHistory(c *gin.Context) {
-	if err := h.service.ResetHistory(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	if err := h.service.ResetHistory(c.Request.Context()); err != nil {
+		fail(c, err)
 		return
 	}
-	c.Status(http.StatusOK)
+	c.JSON(http.StatusOK, gin.H{"message": "History reset successfully"})
 }
 
 // FullReset godoc
@@ -41,9 +41,9 @@ func (h *SettingsHandler) ResetHistory(c *gin.Context) {
 // @Success 200 {object} map[string]string
 // @Router /api/full_reset [post]
 func (h *SettingsHandler) FullReset(c *gin.Context) {
-	if err := h.service.FullReset(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	if err := h.service.FullReset(c.Request.Context()); err != nil {
+		fail(c, err)
 		return
 	}
-	c.Status(http.StatusOK)
+	c.JSON(http.StatusOK, gin.H{"message": "Full reset completed successfully"})
 }
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -16,12 +17,12 @@ type MockSettingsService struct {
 	mock.Mock
 }
 
-func (m *MockSettingsService) ResetHistory() error {
+func (m *MockSettingsService) ResetHistory(_ context.Context) error {
 	args := m.Called()
 	return args.Error(0)
 }
 
-func (m *MockSettingsService) FullReset() error {
+func (m *MockSettingsService) FullReset(_ context.Context) error {
 	args := m.Called()
 	return args.Error(0)
 }
@@ -39,7 +40,7 @@ func TestSettingsHandler_ResetHistory(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/api/reset_history", nil)
 
-		handler.ResetHistory(c)
+		serve(c, handler.ResetHistory)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
@@ -60,14 +61,14 @@ func TestSettingsHandler_ResetHistory(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/api/reset_history", nil)
 
-		handler.ResetHistory(c)
+		serve(c, handler.ResetHistory)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 
 		var response gin.H
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "Internal server error", response["error"])
+		assert.Equal(t, "internal_error", response["code"])
 		mockService.AssertExpectations(t)
 	})
 }
@@ -85,7 +86,7 @@ func TestSettingsHandler_FullReset(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/api/full_reset", nil)
 
-		handler.FullReset(c)
+		serve(c, handler.FullReset)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
@@ -106,14 +107,14 @@ func TestSettingsHandler_FullReset(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 		c.Request, _ = http.NewRequest(http.MethodPost, "/api/full_reset", nil)
 
-		handler.FullReset(c)
+		serve(c, handler.FullReset)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 
 		var response gin.H
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "Internal server error", response["error"])
+		assert.Equal(t, "internal_error", response["code"])
 		mockService.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
+)
+
+// maxSpokenAnswerSize caps spoken answer uploads; a few seconds of speech is
+// far smaller
+const maxSpokenAnswerSize = 10 << 20
+
+type SpeakingHandler struct {
+	service services.SpeakingServiceInterface
+}
+
+func NewSpeakingHandler(service services.SpeakingServiceInterface) *SpeakingHandler {
+	return &SpeakingHandler{service: service}
+}
+
+// SubmitSpokenAnswer godoc
+// @Summary Answer a word by speaking it
+// @Description Transcribes a recorded answer with the configured speech recognizer, grades it against the word ignoring accents and records a review with mode speaking
+// @Tags study_sessions
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Study Session ID"
+// @Param word_id formData int true "Word ID"
+// @Param audio formData file true "Recorded answer (any format the recognizer accepts, e.g. wav, webm, mp3)"
+// @Success 200 {object} models.SpokenAnswerResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Failure 404 {object} handlers.ProblemResponse
+// @Failure 413 {object} handlers.ProblemResponse
+// @Failure 502 {object} handlers.ProblemResponse
+// @Failure 503 {object} handlers.ProblemResponse
+// @Router /api/study_sessions/{id}/spoken_answers [post]
+func (h *SpeakingHandler) SubmitSpokenAnswer(c *gin.Context) {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		invalid(c, "Invalid study session ID")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSpokenAnswerSize)
+	if err := c.Request.ParseMultipartForm(maxSpokenAnswerSize); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			fail(c, models.NewError(models.ErrTooLarge, "audio_too_large", "audio upload is too large"))
+			return
+		}
+		invalid(c, "Invalid multipart form")
+		return
+	}
+
+	wordID, err := strconv.ParseInt(c.Request.FormValue("word_id"), 10, 64)
+	if err != nil {
+		invalid(c, "Invalid word ID")
+		return
+	}
+
+	file, header, err := c.Request.FormFile("audio")
+	if err != nil {
+		invalid(c, "Audio file is required")
+		return
+	}
+	defer file.Close()
+
+	response, err := h.service.SubmitSpokenAnswer(c.Request.Context(), sessionID, wordID, file, header.Filename)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
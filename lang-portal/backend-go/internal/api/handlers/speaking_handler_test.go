@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type MockSpeakingService struct {
+	mock.Mock
+}
+
+func (m *MockSpeakingService) SubmitSpokenAnswer(ctx context.Context, sessionID, wordID int64, audio io.Reader, filename string) (*models.SpokenAnswerResponse, error) {
+	data, _ := io.ReadAll(audio)
+	args := m.Called(sessionID, wordID, string(data), filename)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SpokenAnswerResponse), args.Error(1)
+}
+
+// spokenAnswerForm builds a multipart body with an optional word_id and audio file
+func spokenAnswerForm(t *testing.T, wordID string, audio []byte) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if wordID != "" {
+		require.NoError(t, form.WriteField("word_id", wordID))
+	}
+	if audio != nil {
+		file, err := form.CreateFormFile("audio", "answer.webm")
+		require.NoError(t, err)
+		file.Write(audio)
+	}
+	require.NoError(t, form.Close())
+	return &body, form.FormDataContentType()
+}
+
+func TestSpeakingHandler_SubmitSpokenAnswer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		sessionID  string
+		wordID     string
+		audio      []byte
+		mockSetup  func(*MockSpeakingService)
+		wantStatus int
+	}{
+		{
+			name:      "graded",
+			sessionID: "1",
+			wordID:    "2",
+			audio:     []byte("sorella"),
+			mockSetup: func(m *MockSpeakingService) {
+				m.On("SubmitSpokenAnswer", int64(1), int64(2), "sorella", "answer.webm").
+					Return(&models.SpokenAnswerResponse{WordID: 2, Transcript: "sorella", Expected: "sorella", Similarity: 1, AccentsMatch: true, Correct: true}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid session ID",
+			sessionID:  "abc",
+			wordID:     "2",
+			audio:      []byte("sorella"),
+			mockSetup:  func(m *MockSpeakingService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing word ID",
+			sessionID:  "1",
+			audio:      []byte("sorella"),
+			mockSetup:  func(m *MockSpeakingService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing audio",
+			sessionID:  "1",
+			wordID:     "2",
+			mockSetup:  func(m *MockSpeakingService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "audio too large",
+			sessionID:  "1",
+			wordID:     "2",
+			audio:      make([]byte, maxSpokenAnswerSize+1),
+			mockSetup:  func(m *MockSpeakingService) {},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:      "word not found",
+			sessionID: "1",
+			wordID:    "99",
+			audio:     []byte("sorella"),
+			mockSetup: func(m *MockSpeakingService) {
+				m.On("SubmitSpokenAnswer", int64(1), int64(99), "sorella", "answer.webm").Return(nil, models.NotFoundError("word"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:      "recognizer not configured",
+			sessionID: "1",
+			wordID:    "2",
+			audio:     []byte("sorella"),
+			mockSetup: func(m *MockSpeakingService) {
+				m.On("SubmitSpokenAnswer", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, models.NewError(models.ErrUnavailable, "speech_recognition_not_configured", "speech recognition is not configured"))
+			},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:      "transcription failed",
+			sessionID: "1",
+			wordID:    "2",
+			audio:     []byte("sorella"),
+			mockSetup: func(m *MockSpeakingService) {
+				m.On("SubmitSpokenAnswer", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, models.WrapError(models.ErrUpstream, "transcription_failed", "failed to transcribe audio", errors.New("timeout")))
+			},
+			wantStatus: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSpeakingService)
+			tt.mockSetup(mockService)
+			handler := NewSpeakingHandler(mockService)
+
+			router := gin.New()
+			router.Use(Problems())
+			router.POST("/api/study_sessions/:id/spoken_answers", handler.SubmitSpokenAnswer)
+
+			body, contentType := spokenAnswerForm(t, tt.wordID, tt.audio)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/api/study_sessions/"+tt.sessionID+"/spoken_answers", body)
+			req.Header.Set("Content-Type", contentType)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
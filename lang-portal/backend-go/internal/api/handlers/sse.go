@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sseWriter writes Server-Sent Events to a client. The stream headers are only
+// sent with the first event, so handlers can still answer with a problem
+// response when a request fails before anything was streamed.
+type sseWriter struct {
+	c       *gin.Context
+	started bool
+}
+
+func newSSEWriter(c *gin.Context) *sseWriter {
+	return &sseWriter{c: c}
+}
+
+// Send writes an event and flushes it to the client. It returns an error once
+// the client has disconnected so producers can stop early.
+func (w *sseWriter) Send(event string, data interface{}) error {
+	if !w.started {
+		w.c.Header("Content-Type", "text/event-stream")
+		w.c.Header("Cache-Control", "no-cache")
+		w.c.Header("Connection", "keep-alive")
+		w.c.Header("X-Accel-Buffering", "no")
+		w.c.Status(http.StatusOK)
+		w.started = true
+	}
+
+	w.c.SSEvent(event, data)
+	w.c.Writer.Flush()
+
+	return w.c.Request.Context().Err()
+}
+
+// Fail reports an error either as an "error" event carrying a problem
+// document when the stream has already started, or as a regular problem
+// response
+func (w *sseWriter) Fail(err error) {
+	if w.started {
+		w.Send("error", newProblem(w.c, err))
+		return
+	}
+	fail(w.c, err)
+}
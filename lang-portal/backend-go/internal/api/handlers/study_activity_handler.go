@@ -27,28 +27,20 @@ func NewStudyActivityHandler(service services.StudyActivityServiceInterface) *St
 // @Produce json
 // @Param limit query int false "Number of items per page" default(100)
 // @Param offset query int false "Offset for pagination" default(0)
+// @Param page query int false "Page number counted from 1, instead of offset"
 // @Success 200 {object} models.StudyActivityListResponse
+// @Failure 400 {object} handlers.ProblemResponse
 // @Router /api/study_activities [get]
 func (h *StudyActivityHandler) GetStudyActivities(c *gin.Context) {
-	limit := 100 // Default limit as per spec
-	offset := 0  // Default offset
-
-	// Parse pagination parameters if provided
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
-			limit = parsedLimit
-		}
-	}
-	if offsetStr := c.Query("offset"); offsetStr != "" {
-		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
-			offset = parsedOffset
-		}
+	params, err := parseListParams(c, listQuery{})
+	if err != nil {
+		invalid(c, err.Error())
+		return
 	}
 
-	activities, err := h.service.GetStudyActivities(limit, offset)
+	activities, err := h.service.GetStudyActivities(c.Request.Context(), params.Limit, params.Offset)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get study activities")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		fail(c, err)
 		return
 	}
 
@@ -59,7 +51,7 @@ func (h *StudyActivityHandler) GetStudyActivities(c *gin.Context) {
 				CurrentPage:  1,
 				TotalPages:   1,
 				TotalItems:   0,
-				ItemsPerPage: limit,
+				ItemsPerPage: params.Limit,
 			},
 		})
 		return
@@ -81,19 +73,13 @@ func (h *StudyActivityHandler) GetStudyActivity(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Error().Err(err).Msg("Invalid study activity ID")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid study activity ID"})
+		invalid(c, "Invalid study activity ID")
 		return
 	}
 
-	activity, err := h.service.GetStudyActivity(id)
+	activity, err := h.service.GetStudyActivity(c.Request.Context(), id)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get study activity")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		return
-	}
-
-	if activity == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Study activity not found"})
+		fail(c, err)
 		return
 	}
 
@@ -123,22 +109,21 @@ func (h *StudyActivityHandler) LaunchStudyActivity(c *gin.Context) {
 	// Parse activity ID
 	activityID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid activity ID"})
+		invalid(c, "Invalid activity ID")
 		return
 	}
 
 	// Parse request body
 	var request models.LaunchStudyActivityRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		invalid(c, "Invalid request body")
 		return
 	}
 
 	// Launch study activity
-	response, err := h.service.LaunchStudyActivity(activityID, request.GroupID)
+	response, err := h.service.LaunchStudyActivity(c.Request.Context(), activityID, request.GroupID)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to launch study activity")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to launch study activity"})
+		fail(c, err)
 		return
 	}
 
@@ -149,14 +134,13 @@ func (h *StudyActivityHandler) GetStudyActivitySessions(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Error().Err(err).Msg("Invalid study activity ID")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid study activity ID"})
+		invalid(c, "Invalid study activity ID")
 		return
 	}
 
-	sessions, err := h.service.GetStudyActivitySessions(id)
+	sessions, err := h.service.GetStudyActivitySessions(c.Request.Context(), id)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get study activity sessions")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		fail(c, err)
 		return
 	}
 
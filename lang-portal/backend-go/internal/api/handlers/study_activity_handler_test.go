@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -24,7 +25,7 @@ type MockStudyActivityService struct {
 	mock.Mock
 }
 
-func (m *MockStudyActivityService) GetStudyActivities(limit, offset int) (*models.StudyActivityListResponse, error) {
+func (m *MockStudyActivityService) GetStudyActivities(_ context.Context, limit, offset int) (*models.StudyActivityListResponse, error) {
 	args := m.Called(limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -32,7 +33,7 @@ func (m *MockStudyActivityService) GetStudyActivities(limit, offset int) (*model
 	return args.Get(0).(*models.StudyActivityListResponse), args.Error(1)
 }
 
-func (m *MockStudyActivityService) GetStudyActivity(id int64) (*models.StudyActivityResponse, error) {
+func (m *MockStudyActivityService) GetStudyActivity(_ context.Context, id int64) (*models.StudyActivityResponse, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -40,7 +41,7 @@ func (m *MockStudyActivityService) GetStudyActivity(id int64) (*models.StudyActi
 	return args.Get(0).(*models.StudyActivityResponse), args.Error(1)
 }
 
-func (m *MockStudyActivityService) LaunchStudyActivity(activityID, groupID int64) (*models.LaunchStudyActivityResponse, error) {
+func (m *MockStudyActivityService) LaunchStudyActivity(_ context.Context, activityID, groupID int64) (*models.LaunchStudyActivityResponse, error) {
 	args := m.Called(activityID, groupID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -48,7 +49,7 @@ func (m *MockStudyActivityService) LaunchStudyActivity(activityID, groupID int64
 	return args.Get(0).(*models.LaunchStudyActivityResponse), args.Error(1)
 }
 
-func (m *MockStudyActivityService) GetStudyActivitySessions(activityID int64) (*models.StudySessionsListResponse, error) {
+func (m *MockStudyActivityService) GetStudyActivitySessions(_ context.Context, activityID int64) (*models.StudySessionsListResponse, error) {
 	args := m.Called(activityID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -93,7 +94,7 @@ func TestStudyActivityHandler_GetStudyActivities(t *testing.T) {
 		c.Request, _ = http.NewRequest(http.MethodGet, "/api/study_activities", nil)
 
 		// Act
-		handler.GetStudyActivities(c)
+		serve(c, handler.GetStudyActivities)
 
 		// Assert
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -129,7 +130,7 @@ func TestStudyActivityHandler_GetStudyActivities(t *testing.T) {
 		c.Request, _ = http.NewRequest(http.MethodGet, "/api/study_activities", nil)
 
 		// Act
-		handler.GetStudyActivities(c)
+		serve(c, handler.GetStudyActivities)
 
 		// Assert
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -165,7 +166,7 @@ func TestStudyActivityHandler_GetStudyActivity(t *testing.T) {
 		c.Params = []gin.Param{{Key: "id", Value: "1"}}
 
 		// Act
-		handler.GetStudyActivity(c)
+		serve(c, handler.GetStudyActivity)
 
 		// Assert
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -184,14 +185,14 @@ func TestStudyActivityHandler_GetStudyActivity(t *testing.T) {
 		mockService := new(MockStudyActivityService)
 		handler := NewStudyActivityHandler(mockService)
 		// Arrange
-		mockService.On("GetStudyActivity", int64(999)).Return(nil, nil).Once()
+		mockService.On("GetStudyActivity", int64(999)).Return(nil, models.NotFoundError("study activity")).Once()
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Params = []gin.Param{{Key: "id", Value: "999"}}
 
 		// Act
-		handler.GetStudyActivity(c)
+		serve(c, handler.GetStudyActivity)
 
 		// Assert
 		assert.Equal(t, http.StatusNotFound, w.Code)
@@ -209,7 +210,7 @@ func TestStudyActivityHandler_GetStudyActivity(t *testing.T) {
 		c.Params = []gin.Param{{Key: "id", Value: "invalid"}}
 
 		// Act
-		handler.GetStudyActivity(c)
+		serve(c, handler.GetStudyActivity)
 
 		// Assert
 		assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -247,7 +248,7 @@ func TestStudyActivityHandler_LaunchStudyActivity(t *testing.T) {
 		c.AddParam("id", "1")
 
 		// Act
-		handler.LaunchStudyActivity(c)
+		serve(c, handler.LaunchStudyActivity)
 
 		// Assert
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -272,7 +273,7 @@ func TestStudyActivityHandler_LaunchStudyActivity(t *testing.T) {
 		c.AddParam("id", "invalid")
 
 		// Act
-		handler.LaunchStudyActivity(c)
+		serve(c, handler.LaunchStudyActivity)
 
 		// Assert
 		assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -291,7 +292,7 @@ func TestStudyActivityHandler_LaunchStudyActivity(t *testing.T) {
 		c.AddParam("id", "1")
 
 		// Act
-		handler.LaunchStudyActivity(c)
+		serve(c, handler.LaunchStudyActivity)
 
 		// Assert
 		assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -328,7 +329,7 @@ func TestStudyActivityHandler_GetStudyActivitySessions(t *testing.T) {
 		c.Params = []gin.Param{{Key: "id", Value: "1"}}
 
 		// Act
-		handler.GetStudyActivitySessions(c)
+		serve(c, handler.GetStudyActivitySessions)
 
 		// Assert
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -352,7 +353,7 @@ func TestStudyActivityHandler_GetStudyActivitySessions(t *testing.T) {
 		c.Params = []gin.Param{{Key: "id", Value: "invalid"}}
 
 		// Act
-		handler.GetStudyActivitySessions(c)
+		serve(c, handler.GetStudyActivitySessions)
 
 		// Assert
 		assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -371,7 +372,7 @@ func TestStudyActivityHandler_GetStudyActivitySessions(t *testing.T) {
 		c.Params = []gin.Param{{Key: "id", Value: "1"}}
 
 		// Act
-		handler.GetStudyActivitySessions(c)
+		serve(c, handler.GetStudyActivitySessions)
 
 		// Assert
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
 )
 
@@ -22,25 +23,25 @@ type StudySessionHandler struct {
 // @Param id path int true "Study Session ID"
 // @Param limit query int false "Number of items per page" default(100)
 // @Param offset query int false "Offset for pagination" default(0)
+// @Param page query int false "Page number counted from 1, instead of offset"
 // @Success 200 {object} models.StudySessionWordsResponse
 // @Router /api/study_sessions/{id}/words [get]
 func (h *StudySessionHandler) GetStudySessionWords(c *gin.Context) {
 	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid study session ID"})
+		invalid(c, "Invalid study session ID")
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-
-	words, err := h.service.GetStudySessionWords(sessionID, limit, offset)
+	params, err := parseListParams(c, listQuery{})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		invalid(c, err.Error())
 		return
 	}
-	if words == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No words found for this session"})
+
+	words, err := h.service.GetStudySessionWords(c.Request.Context(), sessionID, params.Limit, params.Offset)
+	if err != nil {
+		fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, words)
@@ -52,21 +53,33 @@ func NewStudySessionHandler(service services.StudySessionServiceInterface) *Stud
 
 // GetAllStudySessions godoc
 // @Summary Get all study sessions
-// @Description Returns a paginated list of all study sessions with activity name, group name, and review items
+// @Description Returns a paginated list of all study sessions with activity name, group name and review stats, and with include=reviews their review items
 // @Tags study_sessions
 // @Accept json
 // @Produce json
 // @Param limit query int false "Number of items per page" default(100)
 // @Param offset query int false "Offset for pagination" default(0)
+// @Param page query int false "Page number counted from 1, instead of offset"
+// @Param cursor query string false "Cursor of the page to fetch, from pagination.next_cursor of the previous page; faster than offsets on long histories"
+// @Param include query string false "reviews to list the review items of each session" Enums(reviews)
 // @Success 200 {object} models.StudySessionListResponse
+// @Failure 400 {object} handlers.ProblemResponse
 // @Router /api/study_sessions [get]
 func (h *StudySessionHandler) GetAllStudySessions(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	params, err := parseListParams(c, listQuery{cursor: true})
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
+	include, err := queryEnum(c, "include", "", "reviews")
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
 
-	sessions, err := h.service.GetAllStudySessions(limit, offset)
+	sessions, err := h.service.GetAllStudySessions(c.Request.Context(), params, include == "reviews")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, sessions)
@@ -74,7 +87,7 @@ func (h *StudySessionHandler) GetAllStudySessions(c *gin.Context) {
 
 // ReviewWord godoc
 // @Summary Review a word in a study session
-// @Description Records whether a word was correctly or incorrectly reviewed in a study session
+// @Description Records whether a word was correctly or incorrectly reviewed in a study session, optionally tagged with a review mode (recognition or recall)
 // @Tags study_sessions
 // @Accept json
 // @Produce json
@@ -84,29 +97,102 @@ func (h *StudySessionHandler) GetAllStudySessions(c *gin.Context) {
 // @Success 200 {object} models.WordReviewResponse
 // @Router /api/study_sessions/{id}/words/{word_id}/review [post]
 func (h *StudySessionHandler) ReviewWord(c *gin.Context) {
-	sessionID, err := strconv.ParseInt(c.Param("sessionID"), 10, 64)
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid study session ID"})
+		invalid(c, "Invalid study session ID")
 		return
 	}
 
-	wordID, err := strconv.ParseInt(c.Param("wordID"), 10, 64)
+	wordID, err := strconv.ParseInt(c.Param("word_id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid word ID"})
+		invalid(c, "Invalid word ID")
+		return
+	}
+
+	var review models.WordReviewRequest
+	if err := c.ShouldBindJSON(&review); err != nil {
+		invalid(c, "Invalid request payload")
 		return
 	}
 
-	var review struct {
-		IsCorrect bool `json:"isCorrect"`
+	response, err := h.service.ReviewWord(c.Request.Context(), sessionID, wordID, review.Correct, review.Mode)
+	if err != nil {
+		fail(c, err)
+		return
 	}
-	if err := c.BindJSON(&review); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+	c.JSON(http.StatusOK, response)
+}
+
+// GetClozeCard godoc
+// @Summary Get a cloze card for a word
+// @Description Returns one of the word's example sentences with the word (or its inflected form) blanked out
+// @Tags study_sessions
+// @Accept json
+// @Produce json
+// @Param id path int true "Study Session ID"
+// @Param word_id path int true "Word ID"
+// @Success 200 {object} models.ClozeCardResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Failure 404 {object} handlers.ProblemResponse
+// @Failure 500 {object} handlers.ProblemResponse
+// @Router /api/study_sessions/{id}/words/{word_id}/cloze [get]
+func (h *StudySessionHandler) GetClozeCard(c *gin.Context) {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		invalid(c, "Invalid study session ID")
+		return
+	}
+
+	wordID, err := strconv.ParseInt(c.Param("word_id"), 10, 64)
+	if err != nil {
+		invalid(c, "Invalid word ID")
+		return
+	}
+
+	card, err := h.service.GetClozeCard(c.Request.Context(), sessionID, wordID)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, card)
+}
+
+// SubmitClozeAnswer godoc
+// @Summary Answer a cloze card
+// @Description Grades the missing word for a cloze sentence and records a review with mode cloze
+// @Tags study_sessions
+// @Accept json
+// @Produce json
+// @Param id path int true "Study Session ID"
+// @Param word_id path int true "Word ID"
+// @Param request body models.ClozeAnswerRequest true "Cloze answer"
+// @Success 200 {object} models.ClozeAnswerResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Failure 404 {object} handlers.ProblemResponse
+// @Failure 500 {object} handlers.ProblemResponse
+// @Router /api/study_sessions/{id}/words/{word_id}/cloze [post]
+func (h *StudySessionHandler) SubmitClozeAnswer(c *gin.Context) {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		invalid(c, "Invalid study session ID")
+		return
+	}
+
+	wordID, err := strconv.ParseInt(c.Param("word_id"), 10, 64)
+	if err != nil {
+		invalid(c, "Invalid word ID")
+		return
+	}
+
+	var req models.ClozeAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalid(c, "Invalid request payload")
 		return
 	}
 
-	response, err := h.service.ReviewWord(sessionID, wordID, review.IsCorrect)
+	response, err := h.service.SubmitClozeAnswer(c.Request.Context(), sessionID, wordID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, response)
@@ -1,25 +1,30 @@
 package handlers
 
 import (
+	"context"
 	"bytes"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
 )
 
 type MockStudySessionService struct {
 	mock.Mock
 }
 
-func (m *MockStudySessionService) GetStudySessionWords(sessionID int64, limit, offset int) (*models.StudySessionWordsResponse, error) {
+func (m *MockStudySessionService) GetStudySessionWords(_ context.Context, sessionID int64, limit, offset int) (*models.StudySessionWordsResponse, error) {
 	args := m.Called(sessionID, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -27,22 +32,38 @@ func (m *MockStudySessionService) GetStudySessionWords(sessionID int64, limit, o
 	return args.Get(0).(*models.StudySessionWordsResponse), args.Error(1)
 }
 
-func (m *MockStudySessionService) GetAllStudySessions(limit, offset int) (*models.StudySessionListResponse, error) {
-	args := m.Called(limit, offset)
+func (m *MockStudySessionService) GetAllStudySessions(_ context.Context, params models.ListParams, includeReviews bool) (*models.StudySessionListResponse, error) {
+	args := m.Called(params, includeReviews)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.StudySessionListResponse), args.Error(1)
 }
 
-func (m *MockStudySessionService) ReviewWord(sessionID, wordID int64, correct bool) (*models.WordReviewResponse, error) {
-	args := m.Called(sessionID, wordID, correct)
+func (m *MockStudySessionService) ReviewWord(_ context.Context, sessionID, wordID int64, correct bool, mode string) (*models.WordReviewResponse, error) {
+	args := m.Called(sessionID, wordID, correct, mode)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.WordReviewResponse), args.Error(1)
 }
 
+func (m *MockStudySessionService) GetClozeCard(_ context.Context, sessionID, wordID int64) (*models.ClozeCardResponse, error) {
+	args := m.Called(sessionID, wordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClozeCardResponse), args.Error(1)
+}
+
+func (m *MockStudySessionService) SubmitClozeAnswer(_ context.Context, sessionID, wordID int64, req *models.ClozeAnswerRequest) (*models.ClozeAnswerResponse, error) {
+	args := m.Called(sessionID, wordID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClozeAnswerResponse), args.Error(1)
+}
+
 func TestStudySessionHandler_GetStudySessionWords(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -54,10 +75,10 @@ func TestStudySessionHandler_GetStudySessionWords(t *testing.T) {
 		expectedResponse := &models.StudySessionWordsResponse{
 			Items: []*models.WordResponse{
 				{
-					ID:      1,
-					Italian: "ciao",
-					English: "hello",
-					Parts:   map[string]interface{}{"part": "greeting"},
+					ID:          1,
+					Term:        "ciao",
+					Translation: "hello",
+					Parts:       map[string]interface{}{"part": "greeting"},
 				},
 			},
 			Pagination: models.PaginationResponse{
@@ -76,7 +97,7 @@ func TestStudySessionHandler_GetStudySessionWords(t *testing.T) {
 		c.Request, _ = http.NewRequest(http.MethodGet, "/api/study_sessions/1/words", nil)
 
 		// Act
-		handler.GetStudySessionWords(c)
+		serve(c, handler.GetStudySessionWords)
 
 		// Assert
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -98,7 +119,7 @@ func TestStudySessionHandler_GetStudySessionWords(t *testing.T) {
 		c.Params = []gin.Param{{Key: "id", Value: "invalid"}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/api/study_sessions/invalid/words", nil)
 
-		handler.GetStudySessionWords(c)
+		serve(c, handler.GetStudySessionWords)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		mockService.AssertExpectations(t)
@@ -115,13 +136,13 @@ func TestStudySessionHandler_GetStudySessionWords(t *testing.T) {
 		c.Params = []gin.Param{{Key: "id", Value: "1"}}
 		c.Request, _ = http.NewRequest(http.MethodGet, "/api/study_sessions/1/words", nil)
 
-		handler.GetStudySessionWords(c)
+		serve(c, handler.GetStudySessionWords)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		var response gin.H
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "Internal server error", response["error"])
+		assert.Equal(t, "internal_error", response["code"])
 		mockService.AssertExpectations(t)
 	})
 }
@@ -153,13 +174,13 @@ func TestStudySessionHandler_GetAllStudySessions(t *testing.T) {
 			},
 		}
 
-		mockService.On("GetAllStudySessions", 100, 0).Return(expectedResponse, nil)
+		mockService.On("GetAllStudySessions", models.ListParams{Limit: 100}, false).Return(expectedResponse, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request, _ = http.NewRequest(http.MethodGet, "/api/study_sessions", nil)
 
-		handler.GetAllStudySessions(c)
+		serve(c, handler.GetAllStudySessions)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
@@ -170,22 +191,51 @@ func TestStudySessionHandler_GetAllStudySessions(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("include reviews", func(t *testing.T) {
+		mockService := new(MockStudySessionService)
+		handler := NewStudySessionHandler(mockService)
+		mockService.On("GetAllStudySessions", models.ListParams{Limit: 100}, true).Return(&models.StudySessionListResponse{}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/api/study_sessions?include=reviews", nil)
+
+		serve(c, handler.GetAllStudySessions)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unknown include", func(t *testing.T) {
+		mockService := new(MockStudySessionService)
+		handler := NewStudySessionHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, "/api/study_sessions?include=words", nil)
+
+		serve(c, handler.GetAllStudySessions)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "GetAllStudySessions")
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		mockService := new(MockStudySessionService)
 		handler := NewStudySessionHandler(mockService)
-		mockService.On("GetAllStudySessions", 100, 0).Return(nil, errors.New("service error")).Once()
+		mockService.On("GetAllStudySessions", models.ListParams{Limit: 100}, false).Return(nil, errors.New("service error")).Once()
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Request, _ = http.NewRequest(http.MethodGet, "/api/study_sessions", nil)
 
-		handler.GetAllStudySessions(c)
+		serve(c, handler.GetAllStudySessions)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		var response gin.H
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "Internal server error", response["error"])
+		assert.Equal(t, "internal_error", response["code"])
 		mockService.AssertExpectations(t)
 	})
 }
@@ -202,7 +252,7 @@ func TestStudySessionHandler_ReviewWord(t *testing.T) {
 			WordID:  1,
 		}
 
-		mockService.On("ReviewWord", int64(1), int64(1), true).Return(expectedResponse, nil)
+		mockService.On("ReviewWord", int64(1), int64(1), true, "").Return(expectedResponse, nil)
 
 		reqBody := models.WordReviewRequest{Correct: true}
 		reqBytes, _ := json.Marshal(reqBody)
@@ -216,7 +266,7 @@ func TestStudySessionHandler_ReviewWord(t *testing.T) {
 		c.Request, _ = http.NewRequest(http.MethodPost, "/api/study_sessions/1/words/1/review", bytes.NewBuffer(reqBytes))
 		c.Request.Header.Set("Content-Type", "application/json")
 
-		handler.ReviewWord(c)
+		serve(c, handler.ReviewWord)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
@@ -240,7 +290,7 @@ func TestStudySessionHandler_ReviewWord(t *testing.T) {
 		}
 		c.Request, _ = http.NewRequest(http.MethodPost, "/api/study_sessions/invalid/words/1/review", nil)
 
-		handler.ReviewWord(c)
+		serve(c, handler.ReviewWord)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		mockService.AssertExpectations(t)
@@ -258,7 +308,7 @@ func TestStudySessionHandler_ReviewWord(t *testing.T) {
 		}
 		c.Request, _ = http.NewRequest(http.MethodPost, "/api/study_sessions/1/words/invalid/review", nil)
 
-		handler.ReviewWord(c)
+		serve(c, handler.ReviewWord)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		mockService.AssertExpectations(t)
@@ -277,7 +327,7 @@ func TestStudySessionHandler_ReviewWord(t *testing.T) {
 		c.Request, _ = http.NewRequest(http.MethodPost, "/api/study_sessions/1/words/1/review", bytes.NewBufferString("invalid json"))
 		c.Request.Header.Set("Content-Type", "application/json")
 
-		handler.ReviewWord(c)
+		serve(c, handler.ReviewWord)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		mockService.AssertExpectations(t)
@@ -287,7 +337,7 @@ func TestStudySessionHandler_ReviewWord(t *testing.T) {
 		// Setup
 		mockService := new(MockStudySessionService)
 		handler := NewStudySessionHandler(mockService)
-		mockService.On("ReviewWord", int64(1), int64(1), true).Return(nil, errors.New("service error")).Once()
+		mockService.On("ReviewWord", int64(1), int64(1), true, "").Return(nil, errors.New("service error")).Once()
 
 		reqBody := models.WordReviewRequest{Correct: true}
 		reqBytes, _ := json.Marshal(reqBody)
@@ -301,13 +351,143 @@ func TestStudySessionHandler_ReviewWord(t *testing.T) {
 		c.Request, _ = http.NewRequest(http.MethodPost, "/api/study_sessions/1/words/1/review", bytes.NewBuffer(reqBytes))
 		c.Request.Header.Set("Content-Type", "application/json")
 
-		handler.ReviewWord(c)
+		serve(c, handler.ReviewWord)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		var response gin.H
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "Internal server error", response["error"])
+		assert.Equal(t, "internal_error", response["code"])
+		mockService.AssertExpectations(t)
+	})
+}
+
+// With foreign keys enforced, reviews of a missing session or word must still
+// be answered with a problem document, not a database error
+func TestStudySessionHandler_ReviewWord_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctx := context.Background()
+	db, err := repository.NewDB(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, db.CreateTables(ctx))
+
+	groupID, err := db.CreateGroup(ctx, "Family")
+	require.NoError(t, err)
+	wordID, err := db.CreateWord(ctx, &models.WordResponse{Term: "madre", Translation: "mother", CourseID: models.DefaultCourseID})
+	require.NoError(t, err)
+	var activityID, sessionID int64
+	require.NoError(t, db.DB().QueryRow("INSERT INTO study_activities (name) VALUES ('Flashcards') RETURNING id").Scan(&activityID))
+	require.NoError(t, db.DB().QueryRow("INSERT INTO study_sessions (group_id, study_activity_id) VALUES (?, ?) RETURNING id", groupID, activityID).Scan(&sessionID))
+
+	handler := NewStudySessionHandler(services.NewStudySessionService(db))
+	tests := []struct {
+		name      string
+		sessionID int64
+		wordID    int64
+		wantCode  string
+	}{
+		{name: "missing session", sessionID: sessionID + 1, wordID: wordID, wantCode: "study_session_not_found"},
+		{name: "missing word", sessionID: sessionID, wordID: wordID + 1, wantCode: "word_not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = []gin.Param{
+				{Key: "id", Value: strconv.FormatInt(tt.sessionID, 10)},
+				{Key: "word_id", Value: strconv.FormatInt(tt.wordID, 10)},
+			}
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/study_sessions/1/words/1/review", bytes.NewBufferString(`{"correct": true}`))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			serve(c, handler.ReviewWord)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+			var problem gin.H
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, tt.wantCode, problem["code"])
+		})
+	}
+}
+
+func TestStudySessionHandler_SubmitClozeAnswer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("successful answer", func(t *testing.T) {
+		// Setup
+		mockService := new(MockStudySessionService)
+		handler := NewStudySessionHandler(mockService)
+		expectedResponse := &models.ClozeAnswerResponse{
+			WordID:   1,
+			Correct:  true,
+			Expected: "sorelle",
+			Sentence: "Ho due sorelle.",
+		}
+
+		mockService.On("SubmitClozeAnswer", int64(1), int64(1), &models.ClozeAnswerRequest{SentenceID: 2, Answer: "sorelle"}).Return(expectedResponse, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{
+			{Key: "id", Value: "1"},
+			{Key: "word_id", Value: "1"},
+		}
+		c.Request, _ = http.NewRequest(http.MethodPost, "/api/study_sessions/1/words/1/cloze", bytes.NewBufferString(`{"sentence_id": 2, "answer": "sorelle"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		serve(c, handler.SubmitClozeAnswer)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.ClozeAnswerResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, *expectedResponse, response)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("missing answer", func(t *testing.T) {
+		// Setup
+		mockService := new(MockStudySessionService)
+		handler := NewStudySessionHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{
+			{Key: "id", Value: "1"},
+			{Key: "word_id", Value: "1"},
+		}
+		c.Request, _ = http.NewRequest(http.MethodPost, "/api/study_sessions/1/words/1/cloze", bytes.NewBufferString(`{"sentence_id": 2}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		serve(c, handler.SubmitClozeAnswer)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("sentence not found", func(t *testing.T) {
+		// Setup
+		mockService := new(MockStudySessionService)
+		handler := NewStudySessionHandler(mockService)
+		mockService.On("SubmitClozeAnswer", int64(1), int64(1), &models.ClozeAnswerRequest{SentenceID: 99, Answer: "sorelle"}).Return(nil, models.NotFoundError("sentence"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = []gin.Param{
+			{Key: "id", Value: "1"},
+			{Key: "word_id", Value: "1"},
+		}
+		c.Request, _ = http.NewRequest(http.MethodPost, "/api/study_sessions/1/words/1/cloze", bytes.NewBufferString(`{"sentence_id": 99, "answer": "sorelle"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		serve(c, handler.SubmitClozeAnswer)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
 		mockService.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
+)
+
+type SyncHandler struct {
+	service services.SyncServiceInterface
+}
+
+func NewSyncHandler(service services.SyncServiceInterface) *SyncHandler {
+	return &SyncHandler{service: service}
+}
+
+// Pull godoc
+// @Summary Pull changes since the last sync
+// @Description Returns the changes to words, groups, group memberships, study sessions and reviews after the sync token, oldest first, with the current state of each changed entity and only its latest change. Without a token the changes start from the beginning. Store the returned token once the changes are applied and pull with it again while has_more is set. When reset is set the token was from another database, e.g. before a backup was restored: drop the synced data and apply the changes, which start over.
+// @Tags sync
+// @Produce json
+// @Param since query string false "Token of the previous pull"
+// @Param limit query int false "Maximum number of changes" default(500)
+// @Success 200 {object} models.SyncPullResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Router /api/sync [get]
+func (h *SyncHandler) Pull(c *gin.Context) {
+	limit, err := queryInt(c, "limit", models.DefaultSyncLimit, 1, models.MaxSyncLimit)
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
+
+	response, err := h.service.Pull(c.Request.Context(), c.Query("since"), limit)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// Push godoc
+// @Summary Push sessions and reviews recorded offline
+// @Description Records study sessions and reviews made offline, each identified by a UUID the client generated, in one transaction. Items are applied oldest first. An item pushed before is reported as a duplicate with its server ID and left as first recorded, so pushes can be retried safely. Items referring to a group, activity, word or session that was deleted are rejected with the reason. A review belongs either to a server session (study_session_id) or to a session pushed by the client (session_client_id).
+// @Tags sync
+// @Accept json
+// @Produce json
+// @Param request body models.SyncPushRequest true "Sessions and reviews"
+// @Success 200 {object} models.SyncPushResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Router /api/sync [post]
+func (h *SyncHandler) Push(c *gin.Context) {
+	var req models.SyncPushRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	response, err := h.service.Push(c.Request.Context(), &req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type MockSyncService struct {
+	mock.Mock
+}
+
+func (m *MockSyncService) Pull(ctx context.Context, token string, limit int) (*models.SyncPullResponse, error) {
+	args := m.Called(token, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SyncPullResponse), args.Error(1)
+}
+
+func (m *MockSyncService) Push(ctx context.Context, req *models.SyncPushRequest) (*models.SyncPushResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SyncPushResponse), args.Error(1)
+}
+
+func TestSyncHandler_Pull(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		query      string
+		mockSetup  func(*MockSyncService)
+		wantStatus int
+	}{
+		{
+			name:  "first sync",
+			query: "",
+			mockSetup: func(m *MockSyncService) {
+				m.On("Pull", "", models.DefaultSyncLimit).Return(&models.SyncPullResponse{Changes: []models.SyncChange{}, Token: "t1"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "since a token",
+			query: "?since=t1&limit=50",
+			mockSetup: func(m *MockSyncService) {
+				m.On("Pull", "t1", 50).Return(&models.SyncPullResponse{Changes: []models.SyncChange{}, Token: "t2"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "limit out of range",
+			query:      "?limit=0",
+			mockSetup:  func(m *MockSyncService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid token",
+			query: "?since=garbage",
+			mockSetup: func(m *MockSyncService) {
+				m.On("Pull", "garbage", models.DefaultSyncLimit).Return(nil, models.ValidationError("invalid_sync_token", "invalid sync token"))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSyncService)
+			tt.mockSetup(mockService)
+			handler := NewSyncHandler(mockService)
+
+			router := gin.New()
+			router.Use(Problems())
+			router.GET("/api/sync", handler.Pull)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/api/sync"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSyncHandler_Push(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockSetup  func(*MockSyncService)
+		wantStatus int
+	}{
+		{
+			name: "reviews",
+			body: `{"reviews": [{"client_id": "5e0a1d9c-7b55-4a8f-b1d4-2c7e9f03a6b2", "study_session_id": 41, "word_id": 17, "correct": true, "reviewed_at": "2024-03-01T08:01:00Z"}]}`,
+			mockSetup: func(m *MockSyncService) {
+				m.On("Push", mock.MatchedBy(func(req *models.SyncPushRequest) bool {
+					return len(req.Reviews) == 1 && req.Reviews[0].WordID == 17 && req.Reviews[0].Correct
+				})).Return(&models.SyncPushResponse{
+					Sessions: []models.SyncPushResult{},
+					Reviews:  []models.SyncPushResult{{ClientID: "5e0a1d9c-7b55-4a8f-b1d4-2c7e9f03a6b2", Status: models.SyncStatusCreated, ID: 905}},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "review without time",
+			body:       `{"reviews": [{"client_id": "5e0a1d9c-7b55-4a8f-b1d4-2c7e9f03a6b2", "study_session_id": 41, "word_id": 17}]}`,
+			mockSetup:  func(m *MockSyncService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown review mode",
+			body:       `{"reviews": [{"client_id": "5e0a1d9c-7b55-4a8f-b1d4-2c7e9f03a6b2", "study_session_id": 41, "word_id": 17, "review_mode": "dictation", "reviewed_at": "2024-03-01T08:01:00Z"}]}`,
+			mockSetup:  func(m *MockSyncService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid client ID",
+			body: `{"reviews": [{"client_id": "42", "study_session_id": 41, "word_id": 17, "reviewed_at": "2024-03-01T08:01:00Z"}]}`,
+			mockSetup: func(m *MockSyncService) {
+				m.On("Push", mock.Anything).Return(nil, models.ValidationError("invalid_client_id", `client_id "42" is not a UUID`))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSyncService)
+			tt.mockSetup(mockService)
+			handler := NewSyncHandler(mockService)
+
+			router := gin.New()
+			router.Use(Problems())
+			router.POST("/api/sync", handler.Push)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/api/sync", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
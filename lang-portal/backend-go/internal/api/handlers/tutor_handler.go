@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
+)
+
+type TutorHandler struct {
+	service services.TutorServiceInterface
+}
+
+func NewTutorHandler(service services.TutorServiceInterface) *TutorHandler {
+	return &TutorHandler{service: service}
+}
+
+// CreateConversation godoc
+// @Summary Start a sentence constructor tutoring conversation
+// @Description Creates a conversation practising a course with a tutor persona that prefers the course's words from the chosen group
+// @Tags tutor
+// @Accept json
+// @Produce json
+// @Param request body models.CreateTutorConversationRequest false "Conversation options"
+// @Success 201 {object} models.TutorConversationResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/tutor/conversations [post]
+func (h *TutorHandler) CreateConversation(c *gin.Context) {
+	var req models.CreateTutorConversationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			log.Error().Err(err).Msg("Invalid request format")
+			invalid(c, "Invalid request format")
+			return
+		}
+	}
+
+	conversation, err := h.service.CreateConversation(c.Request.Context(), &req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, conversation)
+}
+
+// GetConversation godoc
+// @Summary Get a tutoring conversation
+// @Description Returns the conversation history and the vocabulary table built so far
+// @Tags tutor
+// @Accept json
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Success 200 {object} models.TutorConversationResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/tutor/conversations/{id} [get]
+func (h *TutorHandler) GetConversation(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid conversation ID")
+		invalid(c, "Invalid conversation ID")
+		return
+	}
+
+	conversation, err := h.service.GetConversation(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, conversation)
+}
+
+// PostTurn godoc
+// @Summary Post a turn to a tutoring conversation
+// @Description Sends a sentence in the course's source language, or an attempt in its target language, and returns guided hints instead of the answer
+// @Tags tutor
+// @Accept json
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param request body models.TutorTurnRequest true "Learner turn"
+// @Success 200 {object} models.TutorTurnResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 429 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/tutor/conversations/{id}/turns [post]
+func (h *TutorHandler) PostTurn(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid conversation ID")
+		invalid(c, "Invalid conversation ID")
+		return
+	}
+
+	var req models.TutorTurnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	response, err := h.service.PostTurn(c.Request.Context(), id, &req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// PostTurnStream godoc
+// @Summary Post a turn to a tutoring conversation and stream the reply
+// @Description Like posting a turn, but streams the tutor's guidance as Server-Sent "token" events while it is generated, followed by a "summary" event with the full turn response. The answer is only ever included in the "summary" event.
+// @Tags tutor
+// @Accept json
+// @Produce text/event-stream
+// @Param id path int true "Conversation ID"
+// @Param request body models.TutorTurnRequest true "Learner turn"
+// @Success 200 {object} models.TutorTurnResponse
+// @Failure 400 {object} ProblemResponse
+// @Failure 404 {object} ProblemResponse
+// @Failure 429 {object} ProblemResponse
+// @Failure 500 {object} ProblemResponse
+// @Router /api/tutor/conversations/{id}/turns/stream [post]
+func (h *TutorHandler) PostTurnStream(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid conversation ID")
+		invalid(c, "Invalid conversation ID")
+		return
+	}
+
+	var req models.TutorTurnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format")
+		return
+	}
+
+	stream := newSSEWriter(c)
+	response, err := h.service.PostTurnStream(c.Request.Context(), id, &req, func(delta string) error {
+		return stream.Send("token", gin.H{"content": delta})
+	})
+	if err != nil {
+		if c.Request.Context().Err() != nil {
+			log.Info().Msg("Client disconnected from tutor stream")
+			return
+		}
+		stream.Fail(err)
+		return
+	}
+
+	stream.Send("summary", response)
+}
@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type MockTutorService struct {
+	mock.Mock
+}
+
+func (m *MockTutorService) CreateConversation(_ context.Context, req *models.CreateTutorConversationRequest) (*models.TutorConversationResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TutorConversationResponse), args.Error(1)
+}
+
+func (m *MockTutorService) GetConversation(_ context.Context, id int64) (*models.TutorConversationResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TutorConversationResponse), args.Error(1)
+}
+
+func (m *MockTutorService) PostTurn(_ context.Context, conversationID int64, req *models.TutorTurnRequest) (*models.TutorTurnResponse, error) {
+	args := m.Called(conversationID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TutorTurnResponse), args.Error(1)
+}
+
+// PostTurnStream emits the mocked deltas before returning the mocked response
+func (m *MockTutorService) PostTurnStream(ctx context.Context, conversationID int64, req *models.TutorTurnRequest, onDelta func(string) error) (*models.TutorTurnResponse, error) {
+	args := m.Called(conversationID, req)
+	deltas, _ := args.Get(1).([]string)
+	for _, delta := range deltas {
+		if err := onDelta(delta); err != nil {
+			return nil, err
+		}
+	}
+	if args.Get(0) == nil {
+		return nil, args.Error(2)
+	}
+	return args.Get(0).(*models.TutorTurnResponse), args.Error(2)
+}
+
+func TestTutorHandler_CreateConversation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("defaults without body", func(t *testing.T) {
+		mockService := new(MockTutorService)
+		handler := NewTutorHandler(mockService)
+		mockService.On("CreateConversation", &models.CreateTutorConversationRequest{}).Return(&models.TutorConversationResponse{
+			TutorConversation: models.TutorConversation{ID: 1, Persona: "luca"},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/api/tutor/conversations", nil)
+
+		serve(c, handler.CreateConversation)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unknown persona", func(t *testing.T) {
+		mockService := new(MockTutorService)
+		handler := NewTutorHandler(mockService)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/api/tutor/conversations", bytes.NewBufferString(`{"persona": "dante"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		serve(c, handler.CreateConversation)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestTutorHandler_PostTurn(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		mockSetup  func(*MockTutorService)
+		wantStatus int
+	}{
+		{
+			name: "successful turn",
+			id:   "1",
+			body: `{"content": "I eat an apple"}`,
+			mockSetup: func(m *MockTutorService) {
+				m.On("PostTurn", int64(1), &models.TutorTurnRequest{Content: "I eat an apple"}).Return(&models.TutorTurnResponse{
+					ConversationID: 1,
+					Message:        "Think about the verb mangiare.",
+					Hints:          []string{},
+					Vocabulary:     []models.TutorVocabularyEntry{},
+					Attempt:        1,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing content",
+			id:         "1",
+			body:       `{}`,
+			mockSetup:  func(m *MockTutorService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "conversation not found",
+			id:   "9",
+			body: `{"content": "I eat an apple"}`,
+			mockSetup: func(m *MockTutorService) {
+				m.On("PostTurn", int64(9), &models.TutorTurnRequest{Content: "I eat an apple"}).Return(nil, models.NotFoundError("conversation"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTutorService)
+			tt.mockSetup(mockService)
+			handler := NewTutorHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = []gin.Param{{Key: "id", Value: tt.id}}
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api/tutor/conversations/"+tt.id+"/turns", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			serve(c, handler.PostTurn)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				var response models.TutorTurnResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Nil(t, response.Answer)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTutorHandler_PostTurnStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		mockSetup  func(*MockTutorService)
+		wantStatus int
+		wantEvents []string
+	}{
+		{
+			name: "streams tokens then the turn",
+			id:   "1",
+			body: `{"content": "I eat an apple"}`,
+			mockSetup: func(m *MockTutorService) {
+				m.On("PostTurnStream", int64(1), &models.TutorTurnRequest{Content: "I eat an apple"}).Return(&models.TutorTurnResponse{
+					ConversationID: 1,
+					Message:        "Think about mangiare.",
+					Hints:          []string{},
+					Vocabulary:     []models.TutorVocabularyEntry{},
+					Attempt:        1,
+				}, []string{"Think about ", "mangiare."}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantEvents: []string{"event:token", "event:token", "event:summary"},
+		},
+		{
+			name:       "missing content",
+			id:         "1",
+			body:       `{}`,
+			mockSetup:  func(m *MockTutorService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "conversation not found before streaming",
+			id:   "9",
+			body: `{"content": "I eat an apple"}`,
+			mockSetup: func(m *MockTutorService) {
+				m.On("PostTurnStream", int64(9), &models.TutorTurnRequest{Content: "I eat an apple"}).Return(nil, nil, models.NotFoundError("conversation"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "llm failure after streaming started",
+			id:   "1",
+			body: `{"content": "I eat an apple"}`,
+			mockSetup: func(m *MockTutorService) {
+				m.On("PostTurnStream", int64(1), &models.TutorTurnRequest{Content: "I eat an apple"}).Return(nil, []string{"Think"}, errors.New("failed to read stream"))
+			},
+			wantStatus: http.StatusOK,
+			wantEvents: []string{"event:token", "event:error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTutorService)
+			tt.mockSetup(mockService)
+			handler := NewTutorHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = []gin.Param{{Key: "id", Value: tt.id}}
+			c.Request, _ = http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/tutor/conversations/"+tt.id+"/turns/stream", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			serve(c, handler.PostTurnStream)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantEvents != nil {
+				assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+				var events []string
+				for _, line := range strings.Split(w.Body.String(), "\n") {
+					if strings.HasPrefix(line, "event:") {
+						events = append(events, line)
+					}
+				}
+				assert.Equal(t, tt.wantEvents, events)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -3,7 +3,6 @@ package handlers
 import (
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
@@ -22,22 +21,35 @@ func NewWordHandler(service services.WordServiceInterface) *WordHandler {
 
 // GetWords godoc
 // @Summary Get all words
-// @Description Returns a paginated list of words
+// @Description Returns a paginated list of words, optionally only those of one course
 // @Tags words
 // @Accept json
 // @Produce json
+// @Param course_id query int false "Only words of this course"
 // @Param limit query int false "Number of items per page" default(100)
 // @Param offset query int false "Offset for pagination" default(0)
+// @Param page query int false "Page number counted from 1, instead of offset"
+// @Param sort query string false "Field to sort by" Enums(id, term, translation, correct_count, wrong_count)
+// @Param order query string false "Sort order" Enums(asc, desc)
 // @Success 200 {object} models.WordListResponse
+// @Failure 400 {object} handlers.ProblemResponse
 // @Router /api/words [get]
 func (h *WordHandler) GetWords(c *gin.Context) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	params, err := parseListParams(c, listQuery{sortFields: models.WordSortFields})
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
 
-	words, err := h.service.GetWords(limit, offset)
+	courseID, err := queryID(c, "course_id")
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get words")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		invalid(c, err.Error())
+		return
+	}
+
+	words, err := h.service.GetWords(c.Request.Context(), courseID, params)
+	if err != nil {
+		fail(c, err)
 		return
 	}
 
@@ -57,19 +69,13 @@ func (h *WordHandler) GetWordByID(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		log.Error().Err(err).Msg("Invalid word ID")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid word ID"})
+		invalid(c, "Invalid word ID")
 		return
 	}
 
-	word, err := h.service.GetWordByID(id)
+	word, err := h.service.GetWordByID(c.Request.Context(), id)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get word")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
-		return
-	}
-
-	if word == nil {
-		c.JSON(http.StatusOK, models.WordResponse{})
+		fail(c, err)
 		return
 	}
 
@@ -78,34 +84,133 @@ func (h *WordHandler) GetWordByID(c *gin.Context) {
 
 // ImportWords godoc
 // @Summary Import words into a group
-// @Description Imports a list of structured words (with translations and grammatical details) and associates them with a specified group
+// @Description Imports a list of structured words (with translations and grammatical details) into a course and associates them with a specified group. Words not written in the course's languages are skipped.
 // @Tags words
 // @Accept json
 // @Produce json
 // @Param request body models.ImportWordsRequest true "Words import request"
 // @Success 200 {object} models.ImportWordsResponse
-// @Failure 400 {object} handlers.ErrorResponse "Invalid request format"
-// @Failure 404 {object} handlers.ErrorResponse "Group not found"
-// @Failure 500 {object} handlers.ErrorResponse "Internal server error"
+// @Failure 400 {object} handlers.ProblemResponse "Invalid request format"
+// @Failure 404 {object} handlers.ProblemResponse "Group or course not found"
+// @Failure 500 {object} handlers.ProblemResponse "Internal server error"
 // @Router /api/words/import [post]
 func (h *WordHandler) ImportWords(c *gin.Context) {
 	var req models.ImportWordsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Error().Err(err).Msg("Invalid request format")
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request format: " + err.Error()})
+		invalid(c, "Invalid request format: "+err.Error())
 		return
 	}
 
-	result, err := h.service.ImportWords(req.GroupID, req.Words)
+	result, err := h.service.ImportWords(c.Request.Context(), &req)
 	if err != nil {
-		if strings.Contains(err.Error(), "group not found") {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
-			return
-		}
-		log.Error().Err(err).Msg("Failed to import words")
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to import words"})
+		fail(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetWordSentences godoc
+// @Summary Get example sentences for a word
+// @Description Returns a paginated list of example sentences linked to a word
+// @Tags words
+// @Accept json
+// @Produce json
+// @Param id path int true "Word ID"
+// @Param limit query int false "Number of items per page" default(100)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param page query int false "Page number counted from 1, instead of offset"
+// @Success 200 {object} models.WordSentencesResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Failure 404 {object} handlers.ProblemResponse
+// @Failure 500 {object} handlers.ProblemResponse
+// @Router /api/words/{id}/sentences [get]
+func (h *WordHandler) GetWordSentences(c *gin.Context) {
+	wordID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid word ID")
+		invalid(c, "Invalid word ID")
+		return
+	}
+
+	params, err := parseListParams(c, listQuery{})
+	if err != nil {
+		invalid(c, err.Error())
+		return
+	}
+
+	sentences, err := h.service.GetWordSentences(c.Request.Context(), wordID, params.Limit, params.Offset)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sentences)
+}
+
+// AddWordSentence godoc
+// @Summary Add an example sentence to a word
+// @Description Stores an example sentence in the word's course language with its translation and links it to the word
+// @Tags words
+// @Accept json
+// @Produce json
+// @Param id path int true "Word ID"
+// @Param request body models.CreateSentenceRequest true "Sentence to add"
+// @Success 201 {object} models.SentenceResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Failure 404 {object} handlers.ProblemResponse
+// @Failure 500 {object} handlers.ProblemResponse
+// @Router /api/words/{id}/sentences [post]
+func (h *WordHandler) AddWordSentence(c *gin.Context) {
+	wordID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid word ID")
+		invalid(c, "Invalid word ID")
+		return
+	}
+
+	var req models.CreateSentenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Error().Err(err).Msg("Invalid request format")
+		invalid(c, "Invalid request format: "+err.Error())
+		return
+	}
+
+	sentence, err := h.service.AddWordSentence(c.Request.Context(), wordID, &req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, sentence)
+}
+
+// GetWordReviewStats godoc
+// @Summary Get review accuracy for a word by review mode
+// @Description Returns correct/wrong counts and success rate per review mode (recognition, recall, cloze)
+// @Tags words
+// @Accept json
+// @Produce json
+// @Param id path int true "Word ID"
+// @Success 200 {object} models.WordReviewStatsResponse
+// @Failure 400 {object} handlers.ProblemResponse
+// @Failure 404 {object} handlers.ProblemResponse
+// @Failure 500 {object} handlers.ProblemResponse
+// @Router /api/words/{id}/review_stats [get]
+func (h *WordHandler) GetWordReviewStats(c *gin.Context) {
+	wordID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid word ID")
+		invalid(c, "Invalid word ID")
+		return
+	}
+
+	stats, err := h.service.GetWordReviewStats(c.Request.Context(), wordID)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
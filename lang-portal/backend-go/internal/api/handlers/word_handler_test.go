@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -18,15 +20,15 @@ type MockWordService struct {
 	mock.Mock
 }
 
-func (m *MockWordService) GetWords(limit, offset int) (*models.WordListResponse, error) {
-	args := m.Called(limit, offset)
+func (m *MockWordService) GetWords(_ context.Context, courseID int64, params models.ListParams) (*models.WordListResponse, error) {
+	args := m.Called(courseID, params)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.WordListResponse), args.Error(1)
 }
 
-func (m *MockWordService) GetWordByID(id int64) (*models.WordResponse, error) {
+func (m *MockWordService) GetWordByID(_ context.Context, id int64) (*models.WordResponse, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -34,33 +36,63 @@ func (m *MockWordService) GetWordByID(id int64) (*models.WordResponse, error) {
 	return args.Get(0).(*models.WordResponse), args.Error(1)
 }
 
-func (m *MockWordService) ImportWords(groupID int64, words []string) (*models.ImportWordsResponse, error) {
-	args := m.Called(groupID, words)
+func (m *MockWordService) ImportWords(_ context.Context, req *models.ImportWordsRequest) (*models.ImportWordsResponse, error) {
+	args := m.Called(req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.ImportWordsResponse), args.Error(1)
 }
 
+func (m *MockWordService) ImportWordsWithProgress(ctx context.Context, req *models.ImportWordsRequest, progress func(done, total int)) (*models.ImportWordsResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ImportWordsResponse), args.Error(1)
+}
+
+func (m *MockWordService) GetWordSentences(_ context.Context, wordID int64, limit, offset int) (*models.WordSentencesResponse, error) {
+	args := m.Called(wordID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WordSentencesResponse), args.Error(1)
+}
+
+func (m *MockWordService) AddWordSentence(_ context.Context, wordID int64, req *models.CreateSentenceRequest) (*models.SentenceResponse, error) {
+	args := m.Called(wordID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SentenceResponse), args.Error(1)
+}
+
+func (m *MockWordService) GetWordReviewStats(_ context.Context, wordID int64) (*models.WordReviewStatsResponse, error) {
+	args := m.Called(wordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WordReviewStatsResponse), args.Error(1)
+}
+
 func TestWordHandler_GetWords(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	tests := []struct {
 		name       string
-		limit      string
-		offset     string
+		query      string
 		mockSetup  func(*MockWordService)
 		wantStatus int
 		wantBody   *models.WordListResponse
 	}{
 		{
-			name:   "successful retrieval",
-			limit:  "10",
-			offset: "0",
+			name:  "successful retrieval",
+			query: "limit=10&offset=0",
 			mockSetup: func(m *MockWordService) {
-				m.On("GetWords", 10, 0).Return(&models.WordListResponse{
+				m.On("GetWords", int64(0), models.ListParams{Limit: 10}).Return(&models.WordListResponse{
 					Items: []models.WordResponse{
-						{ID: 1, Italian: "ciao", English: "hello"},
+						{ID: 1, Term: "ciao", Translation: "hello"},
 					},
 					Pagination: models.PaginationResponse{
 						CurrentPage:  1,
@@ -73,7 +105,7 @@ func TestWordHandler_GetWords(t *testing.T) {
 			wantStatus: http.StatusOK,
 			wantBody: &models.WordListResponse{
 				Items: []models.WordResponse{
-					{ID: 1, Italian: "ciao", English: "hello"},
+					{ID: 1, Term: "ciao", Translation: "hello"},
 				},
 				Pagination: models.PaginationResponse{
 					CurrentPage:  1,
@@ -84,11 +116,24 @@ func TestWordHandler_GetWords(t *testing.T) {
 			},
 		},
 		{
-			name:   "service error",
-			limit:  "10",
-			offset: "0",
+			name:  "filtered by course",
+			query: "course_id=2&limit=10&offset=0",
+			mockSetup: func(m *MockWordService) {
+				m.On("GetWords", int64(2), models.ListParams{Limit: 10}).Return(&models.WordListResponse{Items: []models.WordResponse{}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid course",
+			query:      "course_id=spanish",
+			mockSetup:  func(m *MockWordService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "service error",
+			query: "limit=10&offset=0",
 			mockSetup: func(m *MockWordService) {
-				m.On("GetWords", 10, 0).Return(nil, errors.New("service error"))
+				m.On("GetWords", int64(0), models.ListParams{Limit: 10}).Return(nil, errors.New("service error"))
 			},
 			wantStatus: http.StatusInternalServerError,
 		},
@@ -102,9 +147,9 @@ func TestWordHandler_GetWords(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
-			c.Request = httptest.NewRequest("GET", "/?limit="+tt.limit+"&offset="+tt.offset, nil)
+			c.Request = httptest.NewRequest("GET", "/?"+tt.query, nil)
 
-			handler.GetWords(c)
+			serve(c, handler.GetWords)
 
 			assert.Equal(t, tt.wantStatus, w.Code)
 			if tt.wantBody != nil {
@@ -133,26 +178,25 @@ func TestWordHandler_GetWordByID(t *testing.T) {
 			wordID: "1",
 			mockSetup: func(m *MockWordService) {
 				m.On("GetWordByID", int64(1)).Return(&models.WordResponse{
-					ID:      1,
-					Italian: "ciao",
-					English: "hello",
+					ID:          1,
+					Term:        "ciao",
+					Translation: "hello",
 				}, nil)
 			},
 			wantStatus: http.StatusOK,
 			wantBody: &models.WordResponse{
-				ID:      1,
-				Italian: "ciao",
-				English: "hello",
+				ID:          1,
+				Term:        "ciao",
+				Translation: "hello",
 			},
 		},
 		{
 			name:   "word not found",
 			wordID: "999",
 			mockSetup: func(m *MockWordService) {
-				m.On("GetWordByID", int64(999)).Return(nil, nil)
+				m.On("GetWordByID", int64(999)).Return(nil, models.NotFoundError("word"))
 			},
-			wantStatus: http.StatusOK,
-			wantBody:   &models.WordResponse{},
+			wantStatus: http.StatusNotFound,
 		},
 		{
 			name:   "invalid id",
@@ -175,7 +219,7 @@ func TestWordHandler_GetWordByID(t *testing.T) {
 			c.Params = []gin.Param{{Key: "id", Value: tt.wordID}}
 			c.Request = httptest.NewRequest("GET", "/words/"+tt.wordID, nil)
 
-			handler.GetWordByID(c)
+			serve(c, handler.GetWordByID)
 
 			assert.Equal(t, tt.wantStatus, w.Code)
 			if tt.wantBody != nil {
@@ -188,3 +232,155 @@ func TestWordHandler_GetWordByID(t *testing.T) {
 		})
 	}
 }
+
+func TestWordHandler_GetWordSentences(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		wordID     string
+		mockSetup  func(*MockWordService)
+		wantStatus int
+		wantBody   *models.WordSentencesResponse
+	}{
+		{
+			name:   "successful retrieval",
+			wordID: "1",
+			mockSetup: func(m *MockWordService) {
+				m.On("GetWordSentences", int64(1), 100, 0).Return(&models.WordSentencesResponse{
+					Items: []models.SentenceResponse{
+						{ID: 1, Text: "Ciao, come stai?", Translation: "Hello, how are you?", Source: "manual"},
+					},
+					Pagination: models.PaginationResponse{
+						CurrentPage:  1,
+						TotalPages:   1,
+						TotalItems:   1,
+						ItemsPerPage: 100,
+					},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: &models.WordSentencesResponse{
+				Items: []models.SentenceResponse{
+					{ID: 1, Text: "Ciao, come stai?", Translation: "Hello, how are you?", Source: "manual"},
+				},
+				Pagination: models.PaginationResponse{
+					CurrentPage:  1,
+					TotalPages:   1,
+					TotalItems:   1,
+					ItemsPerPage: 100,
+				},
+			},
+		},
+		{
+			name:   "word not found",
+			wordID: "999",
+			mockSetup: func(m *MockWordService) {
+				m.On("GetWordSentences", int64(999), 100, 0).Return(nil, models.NotFoundError("word"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "invalid id",
+			wordID: "invalid",
+			mockSetup: func(m *MockWordService) {
+				// No mock setup needed as it won't reach the service
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWordService)
+			tt.mockSetup(mockService)
+			handler := NewWordHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = []gin.Param{{Key: "id", Value: tt.wordID}}
+			c.Request = httptest.NewRequest("GET", "/words/"+tt.wordID+"/sentences", nil)
+
+			serve(c, handler.GetWordSentences)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != nil {
+				var got models.WordSentencesResponse
+				err := json.Unmarshal(w.Body.Bytes(), &got)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantBody, &got)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWordHandler_AddWordSentence(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		wordID     string
+		body       string
+		mockSetup  func(*MockWordService)
+		wantStatus int
+	}{
+		{
+			name:   "successful creation",
+			wordID: "1",
+			body:   `{"text": "Ciao, come stai?", "translation": "Hello, how are you?"}`,
+			mockSetup: func(m *MockWordService) {
+				m.On("AddWordSentence", int64(1), &models.CreateSentenceRequest{
+					Text:        "Ciao, come stai?",
+					Translation: "Hello, how are you?",
+				}).Return(&models.SentenceResponse{
+					ID:          1,
+					Text:        "Ciao, come stai?",
+					Translation: "Hello, how are you?",
+					Source:      "manual",
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:   "invalid source",
+			wordID: "1",
+			body:   `{"text": "Ciao", "translation": "Hello", "source": "scraped"}`,
+			mockSetup: func(m *MockWordService) {
+				// No mock setup needed as it won't reach the service
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "word not found",
+			wordID: "999",
+			body:   `{"text": "Ciao", "translation": "Hello"}`,
+			mockSetup: func(m *MockWordService) {
+				m.On("AddWordSentence", int64(999), &models.CreateSentenceRequest{
+					Text:        "Ciao",
+					Translation: "Hello",
+				}).Return(nil, models.NotFoundError("word"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockWordService)
+			tt.mockSetup(mockService)
+			handler := NewWordHandler(mockService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = []gin.Param{{Key: "id", Value: tt.wordID}}
+			c.Request = httptest.NewRequest("POST", "/words/"+tt.wordID+"/sentences", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			serve(c, handler.AddWordSentence)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -1,35 +1,43 @@
 package router
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/jeevanions/lang-portal/backend-go/internal/db/seeder"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	"github.com/jeevanions/lang-portal/backend-go/internal/api/handlers"
+	"github.com/jeevanions/lang-portal/backend-go/internal/config"
 	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/services"
 )
 
-// Setup initializes the router with all routes and middleware
-func Setup(db *repository.SQLiteRepository, seeder *seeder.Seeder) *gin.Engine {
+// Setup initializes the router with all routes and middleware. Background job
+// workers run until ctx is cancelled.
+func Setup(ctx context.Context, db *repository.SQLRepository, seeder *seeder.Seeder, timeouts config.Timeouts) *gin.Engine {
 	r := gin.Default()
 
 	// Configure CORS
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With", "Access-Control-Allow-Origin", "Access-Control-Allow-Headers"},
-		ExposeHeaders:    []string{"Content-Length", "Content-Type"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With", "Access-Control-Allow-Origin", "Access-Control-Allow-Headers", "If-Match", "If-None-Match"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Type", "ETag"},
 		AllowCredentials: true,
 		AllowWildcard:    true,
 		MaxAge:           12 * 3600,
 	}))
 
+	// Failed requests are answered with RFC 7807 problem documents
+	r.Use(handlers.Problems())
+	r.NoRoute(handlers.NoRoute)
+
 	// Health check
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -44,23 +52,66 @@ func Setup(db *repository.SQLiteRepository, seeder *seeder.Seeder) *gin.Engine {
 	settingsHandler := handlers.NewSettingsHandler(settingsService)
 	studySessionHandler := handlers.NewStudySessionHandler(studySessionService)
 
+	speakingService := services.NewSpeakingServiceFromEnv(db)
+	speakingHandler := handlers.NewSpeakingHandler(speakingService)
+
 	studyActivityService := services.NewStudyActivityService(db)
 	studyActivityHandler := handlers.NewStudyActivityHandler(studyActivityService)
 
+	courseService := services.NewCourseService(db)
+	courseHandler := handlers.NewCourseHandler(courseService)
+
 	wordService := services.NewWordService(db)
 	wordHandler := handlers.NewWordHandler(wordService)
 
+	analyzerService := services.NewAnalyzerService(db, wordService)
+	analyzerHandler := handlers.NewAnalyzerHandler(analyzerService)
+
+	listeningService := services.NewListeningService(db, analyzerService)
+	listeningHandler := handlers.NewListeningHandler(listeningService)
+
 	llmService := services.NewLLMService(db)
 	llmHandler := handlers.NewLLMHandler(llmService)
 
 	groupService := services.NewGroupService(db)
 	groupHandler := handlers.NewGroupHandler(groupService)
 
+	tutorService := services.NewTutorService(db, llmService)
+	tutorHandler := handlers.NewTutorHandler(tutorService)
+
+	audioService := services.NewAudioServiceFromEnv(db)
+	audioHandler := handlers.NewAudioHandler(audioService)
+
+	jobService := services.NewJobService(db, services.NewJobRunners(llmService, wordService, audioService))
+	if err := jobService.Start(ctx, 0); err != nil {
+		log.Error().Err(err).Msg("Failed to start job workers")
+	}
+	jobHandler := handlers.NewJobHandler(jobService)
+
+	packService := services.NewPackService(db, seeder, audioService)
+	packHandler := handlers.NewPackHandler(packService)
+
+	syncService := services.NewSyncService(db)
+	syncHandler := handlers.NewSyncHandler(syncService)
+
+	privacyService := services.NewPrivacyService(db)
+	privacyHandler := handlers.NewPrivacyHandler(privacyService)
+
+	promptService := services.NewPromptService(llmService.Prompts())
+	promptHandler := handlers.NewPromptHandler(promptService)
+
+	// Routes waiting on an LLM, TTS or speech provider, and streamed
+	// responses, get longer deadlines than the rest of the API
+	providerDeadline := handlers.Deadline(timeouts.Provider)
+	streamDeadline := handlers.Deadline(timeouts.Stream)
+
 	// API routes
-	api := r.Group("/api")
+	api := r.Group("/api", handlers.Deadline(timeouts.Request))
 	{
-		// Dashboard routes
-		dashboard := api.Group("/dashboard")
+		// Dashboard routes. Read-heavy routes are tagged with the versions
+		// of the data they read, so clients can revalidate them cheaply.
+		dashboard := api.Group("/dashboard", handlers.ETags(handlers.DailyTablesETag(db,
+			"words", "groups", "study_activities", "study_sessions", "word_review_items", "listening_answers")))
 		{
 			dashboard.GET("/last_study_session", dashboardHandler.GetLastStudySession)
 			dashboard.GET("/study_progress", dashboardHandler.GetStudyProgress)
@@ -76,20 +127,103 @@ func Setup(db *repository.SQLiteRepository, seeder *seeder.Seeder) *gin.Engine {
 			studyActivities.POST("/:id/launch", studyActivityHandler.LaunchStudyActivity)
 		}
 
+		// Language and course routes
+		api.GET("/languages", courseHandler.GetLanguages)
+		api.POST("/languages", courseHandler.CreateLanguage)
+		courses := api.Group("/courses")
+		{
+			courses.GET("", courseHandler.GetCourses)
+			courses.POST("", courseHandler.CreateCourse)
+			courses.GET("/:id", courseHandler.GetCourse)
+		}
+
 		// Word routes
 		words := api.Group("/words")
 		{
 			words.GET("", wordHandler.GetWords)
 			words.GET("/:id", wordHandler.GetWordByID)
 			words.POST("/import", wordHandler.ImportWords)
-			
+			words.GET("/:id/sentences", wordHandler.GetWordSentences)
+			words.POST("/:id/sentences", wordHandler.AddWordSentence)
+			words.POST("/:id/sentences/generate", providerDeadline, llmHandler.GenerateWordSentences)
+			words.GET("/:id/review_stats", wordHandler.GetWordReviewStats)
+			words.GET("/:id/audio", providerDeadline, audioHandler.GetWordAudio)
+			words.HEAD("/:id/audio", providerDeadline, audioHandler.GetWordAudio)
+
 			// LLM routes under words
 			llm := words.Group("/llm")
 			{
-				llm.POST("/generate-words", llmHandler.GenerateWords)
+				llm.POST("/generate-words", providerDeadline, llmHandler.GenerateWords)
+				llm.POST("/generate-words/stream", streamDeadline, llmHandler.GenerateWordsStream)
 			}
 		}
 
+		// Text analysis routes
+		analyze := api.Group("/analyze")
+		{
+			analyze.POST("/text", analyzerHandler.AnalyzeText)
+			analyze.POST("/unknown_words", analyzerHandler.AddUnknownWords)
+		}
+
+		// Listening comprehension routes
+		listening := api.Group("/listening")
+		{
+			listening.GET("/exercises", listeningHandler.GetExercises)
+			listening.POST("/exercises/import", listeningHandler.ImportExercise)
+			listening.GET("/exercises/:id", listeningHandler.GetExercise)
+		}
+
+		// LLM routes
+		llm := api.Group("/llm")
+		{
+			llm.GET("/cache/stats", llmHandler.GetCacheStats)
+			llm.GET("/usage", llmHandler.GetUsage)
+			llm.GET("/budget", llmHandler.GetBudget)
+		}
+
+		// Job routes
+		jobs := api.Group("/jobs")
+		{
+			jobs.POST("", jobHandler.CreateJob)
+			jobs.GET("", jobHandler.GetJobs)
+			jobs.GET("/:id", jobHandler.GetJob)
+			jobs.POST("/:id/cancel", jobHandler.CancelJob)
+		}
+
+		// Admin routes
+		admin := api.Group("/admin")
+		{
+			admin.GET("/prompts", promptHandler.GetPrompts)
+			admin.POST("/prompts/preview", promptHandler.PreviewPrompt)
+		}
+
+		// Tutor routes
+		tutor := api.Group("/tutor")
+		{
+			tutor.POST("/conversations", tutorHandler.CreateConversation)
+			tutor.GET("/conversations/:id", tutorHandler.GetConversation)
+			tutor.POST("/conversations/:id/turns", providerDeadline, tutorHandler.PostTurn)
+			tutor.POST("/conversations/:id/turns/stream", streamDeadline, tutorHandler.PostTurnStream)
+		}
+
+		// Content pack routes. Uploads carry audio, so installing gets the
+		// longer deadline.
+		packs := api.Group("/packs")
+		{
+			packs.GET("", packHandler.GetPacks)
+			packs.POST("/install", providerDeadline, packHandler.InstallPack)
+			packs.DELETE("/:name", packHandler.UninstallPack)
+		}
+
+		// Offline sync routes
+		api.GET("/sync", syncHandler.Pull)
+		api.POST("/sync", syncHandler.Push)
+
+		// Learner data routes. The export is streamed, so it gets the
+		// streaming deadline.
+		api.GET("/me/export", streamDeadline, privacyHandler.ExportMyData)
+		api.DELETE("/me", privacyHandler.EraseMyData)
+
 		// Settings routes
 		api.POST("/reset_history", settingsHandler.ResetHistory)
 		api.POST("/full_reset", settingsHandler.FullReset)
@@ -100,18 +234,23 @@ func Setup(db *repository.SQLiteRepository, seeder *seeder.Seeder) *gin.Engine {
 			studySessions.GET("", studySessionHandler.GetAllStudySessions)
 			studySessions.GET("/:id/words", studySessionHandler.GetStudySessionWords)
 			studySessions.POST("/:id/words/:word_id/review", studySessionHandler.ReviewWord)
+			studySessions.GET("/:id/words/:word_id/cloze", studySessionHandler.GetClozeCard)
+			studySessions.POST("/:id/words/:word_id/cloze", studySessionHandler.SubmitClozeAnswer)
+			studySessions.POST("/:id/spoken_answers", providerDeadline, speakingHandler.SubmitSpokenAnswer)
+			studySessions.POST("/:id/listening_answers", listeningHandler.SubmitAnswer)
 		}
 
 		// Group routes
 		groups := api.Group("/groups")
 		{
 			groups.POST("", groupHandler.CreateGroup)
-			groups.POST("/:id/words", llmHandler.CreateThematicGroup)
+			groups.POST("/:id/words", providerDeadline, llmHandler.CreateThematicGroup)
 
-			groups.GET("", groupHandler.GetGroups)
-			groups.GET("/:id", groupHandler.GetGroupByID)
-			groups.GET("/:id/words", groupHandler.GetGroupWords)
-			groups.GET("/:id/study_sessions", groupHandler.GetGroupStudySessions)
+			groups.GET("", handlers.ETags(handlers.TablesETag(db, "groups", "words_groups")), groupHandler.GetGroups)
+			groups.GET("/:id", handlers.ETags(handlers.GroupETag(db)), groupHandler.GetGroupByID)
+			groups.PUT("/:id", groupHandler.UpdateGroup)
+			groups.GET("/:id/words", handlers.ETags(handlers.TablesETag(db, "words", "words_groups", "word_review_items")), groupHandler.GetGroupWords)
+			groups.GET("/:id/study_sessions", handlers.ETags(handlers.TablesETag(db, "groups", "study_activities", "study_sessions", "word_review_items")), groupHandler.GetGroupStudySessions)
 		}
 	}
 
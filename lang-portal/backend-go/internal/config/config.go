@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog/log"
@@ -10,9 +11,29 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	Port    int
-	DBPath  string
-	EnvMode string
+	Port int
+	// DBDriver selects the database: "sqlite" or "postgres"
+	DBDriver string
+	// DBPath is the SQLite database file
+	DBPath string
+	// DatabaseURL is the PostgreSQL connection URL
+	DatabaseURL string
+	EnvMode     string
+	// Timeouts bound how long requests may run
+	Timeouts Timeouts
+}
+
+// Timeouts are the deadlines of API requests by kind of route, and the grace
+// period in-flight requests get on shutdown
+type Timeouts struct {
+	// Request bounds regular API requests
+	Request time.Duration
+	// Provider bounds requests that wait on an LLM, TTS or speech provider
+	Provider time.Duration
+	// Stream bounds streamed responses
+	Stream time.Duration
+	// Shutdown is how long in-flight requests may finish before they are cancelled
+	Shutdown time.Duration
 }
 
 // Load returns a Config struct populated with values from environment variables
@@ -24,10 +45,27 @@ func Load() *Config {
 	port, _ := strconv.Atoi(getEnvOrDefault("PORT", "8080"))
 	
 	return &Config{
-		Port:    port,
-		DBPath:  getEnvOrDefault("DB_PATH", "words.db"),
-		EnvMode: getEnvOrDefault("ENV_MODE", "development"),
+		Port:        port,
+		DBDriver:    getEnvOrDefault("DB_DRIVER", "sqlite"),
+		DBPath:      getEnvOrDefault("DB_PATH", "words.db"),
+		DatabaseURL: os.Getenv("DATABASE_URL"),
+		EnvMode:     getEnvOrDefault("ENV_MODE", "development"),
+		Timeouts: Timeouts{
+			Request:  getDurationOrDefault("REQUEST_TIMEOUT", 15*time.Second),
+			Provider: getDurationOrDefault("PROVIDER_REQUEST_TIMEOUT", 2*time.Minute),
+			Stream:   getDurationOrDefault("STREAM_REQUEST_TIMEOUT", 10*time.Minute),
+			Shutdown: getDurationOrDefault("SHUTDOWN_TIMEOUT", 5*time.Second),
+		},
+	}
+}
+
+// DatabaseDSN returns what identifies the database of the configured driver:
+// the file path for SQLite, the connection URL for PostgreSQL
+func (c *Config) DatabaseDSN() string {
+	if c.DBDriver == "postgres" {
+		return c.DatabaseURL
 	}
+	return c.DBPath
 }
 
 func getEnvOrDefault(key, defaultValue string) string {
@@ -36,3 +74,19 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getDurationOrDefault reads a Go duration such as "30s", falling back to the
+// default when unset or invalid
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Warn().Str(key, value).Msg("Invalid duration, using default")
+		return defaultValue
+	}
+	return d
+}
@@ -0,0 +1,10 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE word_review_items ADD COLUMN review_mode TEXT NOT NULL DEFAULT 'recognition';
+
+CREATE INDEX idx_word_review_items_word_id_review_mode ON word_review_items(word_id, review_mode);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX IF EXISTS idx_word_review_items_word_id_review_mode;
+ALTER TABLE word_review_items DROP COLUMN review_mode;
@@ -0,0 +1,43 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+CREATE TABLE tutor_conversations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    group_id INTEGER,
+    persona TEXT NOT NULL DEFAULT 'luca',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE SET NULL
+);
+
+CREATE TABLE tutor_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    conversation_id INTEGER NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('system', 'user', 'assistant')),
+    content TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (conversation_id) REFERENCES tutor_conversations(id) ON DELETE CASCADE
+);
+
+CREATE TABLE tutor_vocabulary (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    conversation_id INTEGER NOT NULL,
+    word_id INTEGER,
+    italian TEXT NOT NULL,
+    english TEXT NOT NULL,
+    part_of_speech TEXT,
+    pronunciation TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (conversation_id) REFERENCES tutor_conversations(id) ON DELETE CASCADE,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE SET NULL,
+    UNIQUE (conversation_id, italian)
+);
+
+CREATE INDEX idx_tutor_messages_conversation_id ON tutor_messages(conversation_id);
+CREATE INDEX idx_tutor_vocabulary_conversation_id ON tutor_vocabulary(conversation_id);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS tutor_vocabulary;
+DROP TABLE IF EXISTS tutor_messages;
+DROP TABLE IF EXISTS tutor_conversations;
@@ -0,0 +1,61 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- script decides how terms and sentences in the language are validated
+CREATE TABLE languages (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    script TEXT NOT NULL CHECK (script IN ('latin', 'cyrillic', 'greek', 'arabic', 'han', 'hangul', 'japanese'))
+);
+
+INSERT INTO languages (code, name, script) VALUES
+    ('en', 'English', 'latin'),
+    ('it', 'Italian', 'latin'),
+    ('es', 'Spanish', 'latin'),
+    ('ja', 'Japanese', 'japanese');
+
+-- A course teaches the target language to speakers of the source language
+CREATE TABLE courses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    source_language TEXT NOT NULL,
+    target_language TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (source_language) REFERENCES languages(code),
+    FOREIGN KEY (target_language) REFERENCES languages(code),
+    UNIQUE (source_language, target_language),
+    CHECK (source_language <> target_language)
+);
+
+-- Existing words and sentences belong to the Italian course
+INSERT INTO courses (id, name, source_language, target_language) VALUES (1, 'Italian for English speakers', 'en', 'it');
+
+ALTER TABLE words RENAME COLUMN italian TO term;
+ALTER TABLE words RENAME COLUMN english TO translation;
+ALTER TABLE words ADD COLUMN course_id INTEGER NOT NULL DEFAULT 1;
+CREATE INDEX idx_words_course_id ON words(course_id);
+
+ALTER TABLE sentences RENAME COLUMN italian TO text;
+ALTER TABLE sentences RENAME COLUMN english TO translation;
+
+-- Read-only view with the old column names for tools that still query Italian words
+CREATE VIEW italian_words AS
+SELECT w.id, w.term AS italian, w.translation AS english, w.parts, w.prompt_version, w.created_at
+FROM words w
+JOIN courses c ON c.id = w.course_id
+WHERE c.source_language = 'en' AND c.target_language = 'it';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP VIEW IF EXISTS italian_words;
+
+ALTER TABLE sentences RENAME COLUMN text TO italian;
+ALTER TABLE sentences RENAME COLUMN translation TO english;
+
+DROP INDEX IF EXISTS idx_words_course_id;
+ALTER TABLE words DROP COLUMN course_id;
+ALTER TABLE words RENAME COLUMN term TO italian;
+ALTER TABLE words RENAME COLUMN translation TO english;
+
+DROP TABLE IF EXISTS courses;
+DROP TABLE IF EXISTS languages;
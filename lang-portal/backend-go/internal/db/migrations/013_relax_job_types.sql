@@ -0,0 +1,46 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Job types are validated by the job runners, so the table no longer lists
+-- them; SQLite cannot drop a CHECK constraint in place, so the table is rebuilt
+CREATE TABLE jobs_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled')),
+    payload TEXT NOT NULL,
+    progress INTEGER NOT NULL DEFAULT 0,
+    result TEXT,
+    error TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    started_at DATETIME,
+    finished_at DATETIME
+);
+
+INSERT INTO jobs_new SELECT id, type, status, payload, progress, result, error, created_at, started_at, finished_at FROM jobs;
+DROP TABLE jobs;
+ALTER TABLE jobs_new RENAME TO jobs;
+
+CREATE INDEX idx_jobs_status ON jobs(status, id);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+CREATE TABLE jobs_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL CHECK (type IN ('generate_words', 'generate_sentences', 'import_words')),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled')),
+    payload TEXT NOT NULL,
+    progress INTEGER NOT NULL DEFAULT 0,
+    result TEXT,
+    error TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    started_at DATETIME,
+    finished_at DATETIME
+);
+
+INSERT INTO jobs_old
+SELECT id, type, status, payload, progress, result, error, created_at, started_at, finished_at FROM jobs
+WHERE type IN ('generate_words', 'generate_sentences', 'import_words');
+DROP TABLE jobs;
+ALTER TABLE jobs_old RENAME TO jobs;
+
+CREATE INDEX idx_jobs_status ON jobs(status, id);
@@ -0,0 +1,70 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- A listening exercise is built from a video by the listening-comp pipeline.
+-- content_hash identifies the imported pipeline output so re-importing a file
+-- does not duplicate the exercise.
+CREATE TABLE listening_exercises (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    course_id INTEGER NOT NULL DEFAULT 1,
+    title TEXT NOT NULL,
+    level TEXT,
+    topic TEXT,
+    context TEXT,
+    source_url TEXT,
+    start_ms INTEGER,
+    end_ms INTEGER,
+    content_hash TEXT NOT NULL UNIQUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (course_id) REFERENCES courses(id)
+);
+
+-- Transcript lines, in the order they are spoken
+CREATE TABLE listening_segments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    exercise_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    speaker TEXT,
+    text TEXT NOT NULL,
+    translation TEXT,
+    start_ms INTEGER,
+    key_phrases TEXT NOT NULL DEFAULT '[]',
+    FOREIGN KEY (exercise_id) REFERENCES listening_exercises(id) ON DELETE CASCADE,
+    UNIQUE (exercise_id, position)
+);
+
+-- Multiple-choice questions; options is a JSON array and correct_option an
+-- index into it
+CREATE TABLE listening_questions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    exercise_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    question TEXT NOT NULL,
+    options TEXT NOT NULL,
+    correct_option INTEGER NOT NULL,
+    start_ms INTEGER,
+    FOREIGN KEY (exercise_id) REFERENCES listening_exercises(id) ON DELETE CASCADE,
+    UNIQUE (exercise_id, position)
+);
+
+CREATE TABLE listening_answers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    question_id INTEGER NOT NULL,
+    study_session_id INTEGER NOT NULL,
+    selected_option INTEGER NOT NULL,
+    correct BOOLEAN NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (question_id) REFERENCES listening_questions(id) ON DELETE CASCADE,
+    FOREIGN KEY (study_session_id) REFERENCES study_sessions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_listening_exercises_course_id ON listening_exercises(course_id);
+CREATE INDEX idx_listening_answers_question_id ON listening_answers(question_id);
+CREATE INDEX idx_listening_answers_study_session_id ON listening_answers(study_session_id);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS listening_answers;
+DROP TABLE IF EXISTS listening_questions;
+DROP TABLE IF EXISTS listening_segments;
+DROP TABLE IF EXISTS listening_exercises;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Study sessions are listed newest first and paged by (created_at, id)
+CREATE INDEX idx_study_sessions_created_at ON study_sessions(created_at, id);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX IF EXISTS idx_study_sessions_created_at;
@@ -0,0 +1,183 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- The data version changes with every write to the data the API serves with
+-- ETags; see handlers.ETags
+CREATE TABLE data_version (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    version INTEGER NOT NULL
+);
+
+INSERT INTO data_version (id, version) VALUES (1, 1);
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_courses_insert AFTER INSERT ON courses
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_courses_update AFTER UPDATE ON courses
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_courses_delete AFTER DELETE ON courses
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_words_insert AFTER INSERT ON words
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_words_update AFTER UPDATE ON words
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_words_delete AFTER DELETE ON words
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_groups_insert AFTER INSERT ON groups
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_groups_update AFTER UPDATE ON groups
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_groups_delete AFTER DELETE ON groups
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_words_groups_insert AFTER INSERT ON words_groups
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_words_groups_update AFTER UPDATE ON words_groups
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_words_groups_delete AFTER DELETE ON words_groups
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_study_activities_insert AFTER INSERT ON study_activities
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_study_activities_update AFTER UPDATE ON study_activities
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_study_activities_delete AFTER DELETE ON study_activities
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_study_sessions_insert AFTER INSERT ON study_sessions
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_study_sessions_update AFTER UPDATE ON study_sessions
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_study_sessions_delete AFTER DELETE ON study_sessions
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_word_review_items_insert AFTER INSERT ON word_review_items
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_word_review_items_update AFTER UPDATE ON word_review_items
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_word_review_items_delete AFTER DELETE ON word_review_items
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TRIGGER IF EXISTS bump_data_version_courses_insert;
+DROP TRIGGER IF EXISTS bump_data_version_courses_update;
+DROP TRIGGER IF EXISTS bump_data_version_courses_delete;
+DROP TRIGGER IF EXISTS bump_data_version_words_insert;
+DROP TRIGGER IF EXISTS bump_data_version_words_update;
+DROP TRIGGER IF EXISTS bump_data_version_words_delete;
+DROP TRIGGER IF EXISTS bump_data_version_groups_insert;
+DROP TRIGGER IF EXISTS bump_data_version_groups_update;
+DROP TRIGGER IF EXISTS bump_data_version_groups_delete;
+DROP TRIGGER IF EXISTS bump_data_version_words_groups_insert;
+DROP TRIGGER IF EXISTS bump_data_version_words_groups_update;
+DROP TRIGGER IF EXISTS bump_data_version_words_groups_delete;
+DROP TRIGGER IF EXISTS bump_data_version_study_activities_insert;
+DROP TRIGGER IF EXISTS bump_data_version_study_activities_update;
+DROP TRIGGER IF EXISTS bump_data_version_study_activities_delete;
+DROP TRIGGER IF EXISTS bump_data_version_study_sessions_insert;
+DROP TRIGGER IF EXISTS bump_data_version_study_sessions_update;
+DROP TRIGGER IF EXISTS bump_data_version_study_sessions_delete;
+DROP TRIGGER IF EXISTS bump_data_version_word_review_items_insert;
+DROP TRIGGER IF EXISTS bump_data_version_word_review_items_update;
+DROP TRIGGER IF EXISTS bump_data_version_word_review_items_delete;
+DROP TABLE IF EXISTS data_version;
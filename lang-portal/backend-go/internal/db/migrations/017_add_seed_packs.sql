@@ -0,0 +1,14 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Seed packs applied to the database, so seeding again only applies new packs
+-- and newer versions of applied ones
+CREATE TABLE seed_packs (
+    name TEXT PRIMARY KEY,
+    version INTEGER NOT NULL,
+    applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS seed_packs;
@@ -0,0 +1,41 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Content packs installed through the API. Unlike seed packs they can be
+-- uninstalled, so the words and groups each pack lists are recorded, with
+-- created set on those the pack added.
+CREATE TABLE content_packs (
+    name TEXT PRIMARY KEY,
+    version INTEGER NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    course_id INTEGER NOT NULL DEFAULT 1,
+    installed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (course_id) REFERENCES courses(id)
+);
+
+CREATE TABLE content_pack_words (
+    pack_name TEXT NOT NULL,
+    word_id INTEGER NOT NULL,
+    created BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (pack_name, word_id),
+    FOREIGN KEY (pack_name) REFERENCES content_packs(name) ON DELETE CASCADE,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE
+);
+
+CREATE TABLE content_pack_groups (
+    pack_name TEXT NOT NULL,
+    group_id INTEGER NOT NULL,
+    created BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (pack_name, group_id),
+    FOREIGN KEY (pack_name) REFERENCES content_packs(name) ON DELETE CASCADE,
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_content_pack_words_word_id ON content_pack_words(word_id);
+CREATE INDEX idx_content_pack_groups_group_id ON content_pack_groups(group_id);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS content_pack_groups;
+DROP TABLE IF EXISTS content_pack_words;
+DROP TABLE IF EXISTS content_packs;
@@ -0,0 +1,168 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Every insert, update and delete of words, groups, group memberships, study
+-- sessions and reviews is logged under an increasing sequence number, which
+-- offline clients sync from; see GET /api/sync. AUTOINCREMENT keeps numbers
+-- from being reused after the latest changes are deleted.
+CREATE TABLE change_log (
+    seq INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity TEXT NOT NULL CHECK (entity IN ('word', 'group', 'group_word', 'study_session', 'review')),
+    entity_id INTEGER NOT NULL,
+    op TEXT NOT NULL CHECK (op IN ('insert', 'update', 'delete')),
+    changed_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_change_log_entity ON change_log(entity, entity_id, seq);
+
+-- The epoch names the change log a sync token belongs to. A new database or a
+-- restored backup gets a new one, which sends clients back to a full sync.
+CREATE TABLE sync_state (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    epoch TEXT NOT NULL
+);
+
+INSERT INTO sync_state (id, epoch) VALUES (1, lower(hex(randomblob(8))));
+
+-- Sessions and reviews recorded offline carry the UUID the client gave them,
+-- so pushing them again does not record them twice
+ALTER TABLE study_sessions ADD COLUMN client_id TEXT;
+ALTER TABLE word_review_items ADD COLUMN client_id TEXT;
+CREATE UNIQUE INDEX idx_study_sessions_client_id ON study_sessions(client_id);
+CREATE UNIQUE INDEX idx_word_review_items_client_id ON word_review_items(client_id);
+
+-- Rows from before the log are logged as inserts, so a first sync gets them
+INSERT INTO change_log (entity, entity_id, op) SELECT 'word', id, 'insert' FROM words ORDER BY id;
+INSERT INTO change_log (entity, entity_id, op) SELECT 'group', id, 'insert' FROM groups ORDER BY id;
+INSERT INTO change_log (entity, entity_id, op) SELECT 'group_word', id, 'insert' FROM words_groups ORDER BY id;
+INSERT INTO change_log (entity, entity_id, op) SELECT 'study_session', id, 'insert' FROM study_sessions ORDER BY id;
+INSERT INTO change_log (entity, entity_id, op) SELECT 'review', id, 'insert' FROM word_review_items ORDER BY id;
+
+-- +goose StatementBegin
+CREATE TRIGGER log_words_insert AFTER INSERT ON words
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('word', NEW.id, 'insert');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_words_update AFTER UPDATE ON words
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('word', NEW.id, 'update');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_words_delete AFTER DELETE ON words
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('word', OLD.id, 'delete');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_groups_insert AFTER INSERT ON groups
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('group', NEW.id, 'insert');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_groups_update AFTER UPDATE ON groups
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('group', NEW.id, 'update');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_groups_delete AFTER DELETE ON groups
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('group', OLD.id, 'delete');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_words_groups_insert AFTER INSERT ON words_groups
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('group_word', NEW.id, 'insert');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_words_groups_update AFTER UPDATE ON words_groups
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('group_word', NEW.id, 'update');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_words_groups_delete AFTER DELETE ON words_groups
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('group_word', OLD.id, 'delete');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_study_sessions_insert AFTER INSERT ON study_sessions
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('study_session', NEW.id, 'insert');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_study_sessions_update AFTER UPDATE ON study_sessions
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('study_session', NEW.id, 'update');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_study_sessions_delete AFTER DELETE ON study_sessions
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('study_session', OLD.id, 'delete');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_word_review_items_insert AFTER INSERT ON word_review_items
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('review', NEW.id, 'insert');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_word_review_items_update AFTER UPDATE ON word_review_items
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('review', NEW.id, 'update');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER log_word_review_items_delete AFTER DELETE ON word_review_items
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('review', OLD.id, 'delete');
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TRIGGER IF EXISTS log_words_insert;
+DROP TRIGGER IF EXISTS log_words_update;
+DROP TRIGGER IF EXISTS log_words_delete;
+DROP TRIGGER IF EXISTS log_groups_insert;
+DROP TRIGGER IF EXISTS log_groups_update;
+DROP TRIGGER IF EXISTS log_groups_delete;
+DROP TRIGGER IF EXISTS log_words_groups_insert;
+DROP TRIGGER IF EXISTS log_words_groups_update;
+DROP TRIGGER IF EXISTS log_words_groups_delete;
+DROP TRIGGER IF EXISTS log_study_sessions_insert;
+DROP TRIGGER IF EXISTS log_study_sessions_update;
+DROP TRIGGER IF EXISTS log_study_sessions_delete;
+DROP TRIGGER IF EXISTS log_word_review_items_insert;
+DROP TRIGGER IF EXISTS log_word_review_items_update;
+DROP TRIGGER IF EXISTS log_word_review_items_delete;
+DROP INDEX IF EXISTS idx_word_review_items_client_id;
+DROP INDEX IF EXISTS idx_study_sessions_client_id;
+ALTER TABLE word_review_items DROP COLUMN client_id;
+ALTER TABLE study_sessions DROP COLUMN client_id;
+DROP TABLE IF EXISTS sync_state;
+DROP TABLE IF EXISTS change_log;
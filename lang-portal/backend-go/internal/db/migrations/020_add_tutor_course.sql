@@ -0,0 +1,21 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Tutor conversations practise a course, and the vocabulary the tutor lists
+-- is kept under the generic column names words got in 012. Existing
+-- conversations were all held in the Italian course.
+ALTER TABLE tutor_conversations ADD COLUMN course_id INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE tutor_vocabulary RENAME COLUMN italian TO term;
+ALTER TABLE tutor_vocabulary RENAME COLUMN english TO translation;
+ALTER TABLE tutor_vocabulary ADD COLUMN course_id INTEGER NOT NULL DEFAULT 1;
+CREATE INDEX idx_tutor_vocabulary_course_term ON tutor_vocabulary(course_id, term);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX IF EXISTS idx_tutor_vocabulary_course_term;
+ALTER TABLE tutor_vocabulary DROP COLUMN course_id;
+ALTER TABLE tutor_vocabulary RENAME COLUMN term TO italian;
+ALTER TABLE tutor_vocabulary RENAME COLUMN translation TO english;
+
+ALTER TABLE tutor_conversations DROP COLUMN course_id;
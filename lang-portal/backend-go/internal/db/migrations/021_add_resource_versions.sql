@@ -0,0 +1,439 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Every write bumped the single data version row, so writers contended for
+-- it. Table and group versions replace it; the row stays only as the lock
+-- sync pushes take.
+DROP TRIGGER IF EXISTS bump_data_version_courses_insert;
+DROP TRIGGER IF EXISTS bump_data_version_courses_update;
+DROP TRIGGER IF EXISTS bump_data_version_courses_delete;
+DROP TRIGGER IF EXISTS bump_data_version_words_insert;
+DROP TRIGGER IF EXISTS bump_data_version_words_update;
+DROP TRIGGER IF EXISTS bump_data_version_words_delete;
+DROP TRIGGER IF EXISTS bump_data_version_groups_insert;
+DROP TRIGGER IF EXISTS bump_data_version_groups_update;
+DROP TRIGGER IF EXISTS bump_data_version_groups_delete;
+DROP TRIGGER IF EXISTS bump_data_version_words_groups_insert;
+DROP TRIGGER IF EXISTS bump_data_version_words_groups_update;
+DROP TRIGGER IF EXISTS bump_data_version_words_groups_delete;
+DROP TRIGGER IF EXISTS bump_data_version_study_activities_insert;
+DROP TRIGGER IF EXISTS bump_data_version_study_activities_update;
+DROP TRIGGER IF EXISTS bump_data_version_study_activities_delete;
+DROP TRIGGER IF EXISTS bump_data_version_study_sessions_insert;
+DROP TRIGGER IF EXISTS bump_data_version_study_sessions_update;
+DROP TRIGGER IF EXISTS bump_data_version_study_sessions_delete;
+DROP TRIGGER IF EXISTS bump_data_version_word_review_items_insert;
+DROP TRIGGER IF EXISTS bump_data_version_word_review_items_update;
+DROP TRIGGER IF EXISTS bump_data_version_word_review_items_delete;
+
+-- ETags are derived from the versions of the tables a response is read from,
+-- or of the group it describes, so that writes elsewhere don't make them
+-- stale; see handlers.ETags
+CREATE TABLE table_versions (
+    name TEXT PRIMARY KEY,
+    version INTEGER NOT NULL
+);
+
+INSERT INTO table_versions (name, version) VALUES
+    ('courses', 1),
+    ('words', 1),
+    ('groups', 1),
+    ('words_groups', 1),
+    ('study_activities', 1),
+    ('study_sessions', 1),
+    ('word_review_items', 1),
+    ('listening_answers', 1);
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_courses_insert AFTER INSERT ON courses
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'courses';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_courses_update AFTER UPDATE ON courses
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'courses';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_courses_delete AFTER DELETE ON courses
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'courses';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_words_insert AFTER INSERT ON words
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'words';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_words_update AFTER UPDATE ON words
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'words';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_words_delete AFTER DELETE ON words
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'words';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_groups_insert AFTER INSERT ON groups
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'groups';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_groups_update AFTER UPDATE ON groups
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'groups';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_groups_delete AFTER DELETE ON groups
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'groups';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_words_groups_insert AFTER INSERT ON words_groups
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'words_groups';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_words_groups_update AFTER UPDATE ON words_groups
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'words_groups';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_words_groups_delete AFTER DELETE ON words_groups
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'words_groups';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_study_activities_insert AFTER INSERT ON study_activities
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'study_activities';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_study_activities_update AFTER UPDATE ON study_activities
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'study_activities';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_study_activities_delete AFTER DELETE ON study_activities
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'study_activities';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_study_sessions_insert AFTER INSERT ON study_sessions
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'study_sessions';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_study_sessions_update AFTER UPDATE ON study_sessions
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'study_sessions';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_study_sessions_delete AFTER DELETE ON study_sessions
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'study_sessions';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_word_review_items_insert AFTER INSERT ON word_review_items
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'word_review_items';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_word_review_items_update AFTER UPDATE ON word_review_items
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'word_review_items';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_word_review_items_delete AFTER DELETE ON word_review_items
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'word_review_items';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_listening_answers_insert AFTER INSERT ON listening_answers
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'listening_answers';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_listening_answers_update AFTER UPDATE ON listening_answers
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'listening_answers';
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_table_version_listening_answers_delete AFTER DELETE ON listening_answers
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'listening_answers';
+END;
+-- +goose StatementEnd
+
+-- A group's version moves when it is renamed or its words change
+CREATE TABLE group_versions (
+    group_id INTEGER PRIMARY KEY,
+    version INTEGER NOT NULL DEFAULT 1,
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
+);
+
+INSERT INTO group_versions (group_id) SELECT id FROM groups;
+
+-- +goose StatementBegin
+CREATE TRIGGER add_group_version AFTER INSERT ON groups
+BEGIN
+    INSERT INTO group_versions (group_id) VALUES (NEW.id);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_group_version_rename AFTER UPDATE OF name ON groups
+WHEN NEW.name IS NOT OLD.name
+BEGIN
+    UPDATE group_versions SET version = version + 1 WHERE group_id = NEW.id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_group_version_words_insert AFTER INSERT ON words_groups
+BEGIN
+    UPDATE group_versions SET version = version + 1 WHERE group_id = NEW.group_id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_group_version_words_update AFTER UPDATE ON words_groups
+BEGIN
+    UPDATE group_versions SET version = version + 1 WHERE group_id IN (OLD.group_id, NEW.group_id);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_group_version_words_delete AFTER DELETE ON words_groups
+BEGIN
+    UPDATE group_versions SET version = version + 1 WHERE group_id = OLD.group_id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TRIGGER IF EXISTS add_group_version;
+DROP TRIGGER IF EXISTS bump_group_version_rename;
+DROP TRIGGER IF EXISTS bump_group_version_words_insert;
+DROP TRIGGER IF EXISTS bump_group_version_words_update;
+DROP TRIGGER IF EXISTS bump_group_version_words_delete;
+DROP TABLE IF EXISTS group_versions;
+DROP TRIGGER IF EXISTS bump_table_version_courses_insert;
+DROP TRIGGER IF EXISTS bump_table_version_courses_update;
+DROP TRIGGER IF EXISTS bump_table_version_courses_delete;
+DROP TRIGGER IF EXISTS bump_table_version_words_insert;
+DROP TRIGGER IF EXISTS bump_table_version_words_update;
+DROP TRIGGER IF EXISTS bump_table_version_words_delete;
+DROP TRIGGER IF EXISTS bump_table_version_groups_insert;
+DROP TRIGGER IF EXISTS bump_table_version_groups_update;
+DROP TRIGGER IF EXISTS bump_table_version_groups_delete;
+DROP TRIGGER IF EXISTS bump_table_version_words_groups_insert;
+DROP TRIGGER IF EXISTS bump_table_version_words_groups_update;
+DROP TRIGGER IF EXISTS bump_table_version_words_groups_delete;
+DROP TRIGGER IF EXISTS bump_table_version_study_activities_insert;
+DROP TRIGGER IF EXISTS bump_table_version_study_activities_update;
+DROP TRIGGER IF EXISTS bump_table_version_study_activities_delete;
+DROP TRIGGER IF EXISTS bump_table_version_study_sessions_insert;
+DROP TRIGGER IF EXISTS bump_table_version_study_sessions_update;
+DROP TRIGGER IF EXISTS bump_table_version_study_sessions_delete;
+DROP TRIGGER IF EXISTS bump_table_version_word_review_items_insert;
+DROP TRIGGER IF EXISTS bump_table_version_word_review_items_update;
+DROP TRIGGER IF EXISTS bump_table_version_word_review_items_delete;
+DROP TRIGGER IF EXISTS bump_table_version_listening_answers_insert;
+DROP TRIGGER IF EXISTS bump_table_version_listening_answers_update;
+DROP TRIGGER IF EXISTS bump_table_version_listening_answers_delete;
+DROP TABLE IF EXISTS table_versions;
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_courses_insert AFTER INSERT ON courses
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_courses_update AFTER UPDATE ON courses
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_courses_delete AFTER DELETE ON courses
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_words_insert AFTER INSERT ON words
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_words_update AFTER UPDATE ON words
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_words_delete AFTER DELETE ON words
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_groups_insert AFTER INSERT ON groups
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_groups_update AFTER UPDATE ON groups
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_groups_delete AFTER DELETE ON groups
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_words_groups_insert AFTER INSERT ON words_groups
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_words_groups_update AFTER UPDATE ON words_groups
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_words_groups_delete AFTER DELETE ON words_groups
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_study_activities_insert AFTER INSERT ON study_activities
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_study_activities_update AFTER UPDATE ON study_activities
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_study_activities_delete AFTER DELETE ON study_activities
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_study_sessions_insert AFTER INSERT ON study_sessions
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_study_sessions_update AFTER UPDATE ON study_sessions
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_study_sessions_delete AFTER DELETE ON study_sessions
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_word_review_items_insert AFTER INSERT ON word_review_items
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_word_review_items_update AFTER UPDATE ON word_review_items
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER bump_data_version_word_review_items_delete AFTER DELETE ON word_review_items
+BEGIN
+    UPDATE data_version SET version = version + 1;
+END;
+-- +goose StatementEnd
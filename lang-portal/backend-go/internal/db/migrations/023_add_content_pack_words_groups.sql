@@ -0,0 +1,22 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- The word group mappings each content pack lists, with created set on those
+-- the pack added, so uninstalling it takes the words it put in groups out of
+-- them. Packs installed before record none until they are updated.
+CREATE TABLE content_pack_words_groups (
+    pack_name TEXT NOT NULL,
+    word_id INTEGER NOT NULL,
+    group_id INTEGER NOT NULL,
+    created BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (pack_name, word_id, group_id),
+    FOREIGN KEY (pack_name) REFERENCES content_packs(name) ON DELETE CASCADE,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_content_pack_words_groups_word_id ON content_pack_words_groups(word_id, group_id);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS content_pack_words_groups;
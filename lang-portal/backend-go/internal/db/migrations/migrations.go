@@ -0,0 +1,10 @@
+// Package migrations holds the goose migrations of the SQLite schema. The
+// PostgreSQL migrations are in the postgres subdirectory.
+package migrations
+
+import "embed"
+
+// FS holds the migrations, for applying them without the goose CLI
+//
+//go:embed *.sql
+var FS embed.FS
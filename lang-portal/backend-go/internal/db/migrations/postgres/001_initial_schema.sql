@@ -0,0 +1,275 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- The PostgreSQL schema starts out at the state the SQLite migrations reached
+-- with 015_add_study_sessions_created_at_index. Later schema changes need a
+-- migration in both directories.
+
+-- script decides how terms and sentences in the language are validated
+CREATE TABLE IF NOT EXISTS languages (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    script TEXT NOT NULL CHECK (script IN ('latin', 'cyrillic', 'greek', 'arabic', 'han', 'hangul', 'japanese'))
+);
+
+INSERT INTO languages (code, name, script) VALUES
+    ('en', 'English', 'latin'),
+    ('it', 'Italian', 'latin'),
+    ('es', 'Spanish', 'latin'),
+    ('ja', 'Japanese', 'japanese')
+ON CONFLICT DO NOTHING;
+
+-- A course teaches the target language to speakers of the source language
+CREATE TABLE IF NOT EXISTS courses (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL,
+    source_language TEXT NOT NULL REFERENCES languages(code),
+    target_language TEXT NOT NULL REFERENCES languages(code),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (source_language, target_language),
+    CHECK (source_language <> target_language)
+);
+
+-- The Italian course is the first one, so that it gets the ID 1 words and
+-- exercises default to
+INSERT INTO courses (name, source_language, target_language)
+VALUES ('Italian for English speakers', 'en', 'it')
+ON CONFLICT DO NOTHING;
+
+-- parts is JSON stored as text, as with SQLite, so words can be compared and
+-- selected DISTINCT
+CREATE TABLE IF NOT EXISTS words (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    term TEXT NOT NULL,
+    translation TEXT NOT NULL,
+    parts TEXT,
+    prompt_version TEXT,
+    course_id BIGINT NOT NULL DEFAULT 1 REFERENCES courses(id),
+    correct_count INTEGER DEFAULT 0,
+    wrong_count INTEGER DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Read-only view with the old column names for tools that still query Italian words
+CREATE OR REPLACE VIEW italian_words AS
+SELECT w.id, w.term AS italian, w.translation AS english, w.parts, w.prompt_version, w.created_at
+FROM words w
+JOIN courses c ON c.id = w.course_id
+WHERE c.source_language = 'en' AND c.target_language = 'it';
+
+CREATE TABLE IF NOT EXISTS groups (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL,
+    words_count INTEGER DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS words_groups (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    word_id BIGINT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    group_id BIGINT NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS study_activities (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL,
+    thumbnail_url TEXT,
+    description TEXT,
+    launch_url TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS study_sessions (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    group_id BIGINT NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    study_activity_id BIGINT NOT NULL REFERENCES study_activities(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS word_review_items (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    word_id BIGINT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    study_session_id BIGINT NOT NULL REFERENCES study_sessions(id) ON DELETE CASCADE,
+    correct BOOLEAN NOT NULL,
+    review_mode TEXT NOT NULL DEFAULT 'recognition',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS sentences (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    text TEXT NOT NULL,
+    translation TEXT NOT NULL,
+    source TEXT NOT NULL DEFAULT 'manual' CHECK (source IN ('manual', 'llm', 'import')),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS words_sentences (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    word_id BIGINT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    sentence_id BIGINT NOT NULL REFERENCES sentences(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (word_id, sentence_id)
+);
+
+CREATE TABLE IF NOT EXISTS tutor_conversations (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    group_id BIGINT REFERENCES groups(id) ON DELETE SET NULL,
+    persona TEXT NOT NULL DEFAULT 'luca',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS tutor_messages (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    conversation_id BIGINT NOT NULL REFERENCES tutor_conversations(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('system', 'user', 'assistant')),
+    content TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS tutor_vocabulary (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    conversation_id BIGINT NOT NULL REFERENCES tutor_conversations(id) ON DELETE CASCADE,
+    word_id BIGINT REFERENCES words(id) ON DELETE SET NULL,
+    italian TEXT NOT NULL,
+    english TEXT NOT NULL,
+    part_of_speech TEXT,
+    pronunciation TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (conversation_id, italian)
+);
+
+CREATE TABLE IF NOT EXISTS llm_cache (
+    cache_key TEXT PRIMARY KEY,
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL,
+    response TEXT NOT NULL,
+    hits INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    type TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled')),
+    payload TEXT NOT NULL,
+    progress INTEGER NOT NULL DEFAULT 0,
+    result TEXT,
+    error TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS llm_usage (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL,
+    endpoint TEXT NOT NULL,
+    prompt_tokens INTEGER NOT NULL DEFAULT 0,
+    completion_tokens INTEGER NOT NULL DEFAULT 0,
+    total_tokens INTEGER NOT NULL DEFAULT 0,
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL CHECK (status IN ('success', 'error', 'cancelled')),
+    error TEXT,
+    cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A listening exercise is built from a video by the listening-comp pipeline.
+-- content_hash identifies the imported pipeline output so re-importing a file
+-- does not duplicate the exercise.
+CREATE TABLE IF NOT EXISTS listening_exercises (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    course_id BIGINT NOT NULL DEFAULT 1 REFERENCES courses(id),
+    title TEXT NOT NULL,
+    level TEXT,
+    topic TEXT,
+    context TEXT,
+    source_url TEXT,
+    start_ms BIGINT,
+    end_ms BIGINT,
+    content_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Transcript lines, in the order they are spoken
+CREATE TABLE IF NOT EXISTS listening_segments (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    exercise_id BIGINT NOT NULL REFERENCES listening_exercises(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    speaker TEXT,
+    text TEXT NOT NULL,
+    translation TEXT,
+    start_ms BIGINT,
+    key_phrases TEXT NOT NULL DEFAULT '[]',
+    UNIQUE (exercise_id, position)
+);
+
+-- Multiple-choice questions; options is a JSON array and correct_option an
+-- index into it
+CREATE TABLE IF NOT EXISTS listening_questions (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    exercise_id BIGINT NOT NULL REFERENCES listening_exercises(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    question TEXT NOT NULL,
+    options TEXT NOT NULL,
+    correct_option INTEGER NOT NULL,
+    start_ms BIGINT,
+    UNIQUE (exercise_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS listening_answers (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    question_id BIGINT NOT NULL REFERENCES listening_questions(id) ON DELETE CASCADE,
+    study_session_id BIGINT NOT NULL REFERENCES study_sessions(id) ON DELETE CASCADE,
+    selected_option INTEGER NOT NULL,
+    correct BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_words_course_id ON words(course_id);
+CREATE INDEX IF NOT EXISTS idx_words_groups_word_id ON words_groups(word_id);
+CREATE INDEX IF NOT EXISTS idx_words_groups_group_id ON words_groups(group_id);
+CREATE INDEX IF NOT EXISTS idx_study_sessions_group_id ON study_sessions(group_id);
+CREATE INDEX IF NOT EXISTS idx_study_sessions_created_at ON study_sessions(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_word_review_items_word_id ON word_review_items(word_id);
+CREATE INDEX IF NOT EXISTS idx_word_review_items_study_session_id ON word_review_items(study_session_id);
+CREATE INDEX IF NOT EXISTS idx_word_review_items_word_id_review_mode ON word_review_items(word_id, review_mode);
+CREATE INDEX IF NOT EXISTS idx_words_sentences_word_id ON words_sentences(word_id);
+CREATE INDEX IF NOT EXISTS idx_words_sentences_sentence_id ON words_sentences(sentence_id);
+CREATE INDEX IF NOT EXISTS idx_tutor_messages_conversation_id ON tutor_messages(conversation_id);
+CREATE INDEX IF NOT EXISTS idx_tutor_vocabulary_conversation_id ON tutor_vocabulary(conversation_id);
+CREATE INDEX IF NOT EXISTS idx_llm_cache_expires_at ON llm_cache(expires_at);
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status, id);
+CREATE INDEX IF NOT EXISTS idx_llm_usage_created_at ON llm_usage(created_at);
+CREATE INDEX IF NOT EXISTS idx_listening_exercises_course_id ON listening_exercises(course_id);
+CREATE INDEX IF NOT EXISTS idx_listening_answers_question_id ON listening_answers(question_id);
+CREATE INDEX IF NOT EXISTS idx_listening_answers_study_session_id ON listening_answers(study_session_id);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP VIEW IF EXISTS italian_words;
+DROP TABLE IF EXISTS listening_answers;
+DROP TABLE IF EXISTS listening_questions;
+DROP TABLE IF EXISTS listening_segments;
+DROP TABLE IF EXISTS listening_exercises;
+DROP TABLE IF EXISTS llm_usage;
+DROP TABLE IF EXISTS jobs;
+DROP TABLE IF EXISTS llm_cache;
+DROP TABLE IF EXISTS tutor_vocabulary;
+DROP TABLE IF EXISTS tutor_messages;
+DROP TABLE IF EXISTS tutor_conversations;
+DROP TABLE IF EXISTS words_sentences;
+DROP TABLE IF EXISTS sentences;
+DROP TABLE IF EXISTS word_review_items;
+DROP TABLE IF EXISTS study_sessions;
+DROP TABLE IF EXISTS study_activities;
+DROP TABLE IF EXISTS words_groups;
+DROP TABLE IF EXISTS groups;
+DROP TABLE IF EXISTS words;
+DROP TABLE IF EXISTS courses;
+DROP TABLE IF EXISTS languages;
@@ -0,0 +1,54 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- The data version changes with every write to the data the API serves with
+-- ETags; see handlers.ETags
+CREATE TABLE IF NOT EXISTS data_version (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    version BIGINT NOT NULL
+);
+
+INSERT INTO data_version (id, version) VALUES (1, 1) ON CONFLICT (id) DO NOTHING;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION bump_data_version() RETURNS trigger AS $$
+BEGIN
+    UPDATE data_version SET version = version + 1;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS bump_data_version ON courses;
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON courses
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+DROP TRIGGER IF EXISTS bump_data_version ON words;
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON words
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+DROP TRIGGER IF EXISTS bump_data_version ON groups;
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON groups
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+DROP TRIGGER IF EXISTS bump_data_version ON words_groups;
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON words_groups
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+DROP TRIGGER IF EXISTS bump_data_version ON study_activities;
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON study_activities
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+DROP TRIGGER IF EXISTS bump_data_version ON study_sessions;
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON study_sessions
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+DROP TRIGGER IF EXISTS bump_data_version ON word_review_items;
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON word_review_items
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TRIGGER IF EXISTS bump_data_version ON courses;
+DROP TRIGGER IF EXISTS bump_data_version ON words;
+DROP TRIGGER IF EXISTS bump_data_version ON groups;
+DROP TRIGGER IF EXISTS bump_data_version ON words_groups;
+DROP TRIGGER IF EXISTS bump_data_version ON study_activities;
+DROP TRIGGER IF EXISTS bump_data_version ON study_sessions;
+DROP TRIGGER IF EXISTS bump_data_version ON word_review_items;
+DROP FUNCTION IF EXISTS bump_data_version();
+DROP TABLE IF EXISTS data_version;
@@ -0,0 +1,36 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Content packs installed through the API. Unlike seed packs they can be
+-- uninstalled, so the words and groups each pack lists are recorded, with
+-- created set on those the pack added.
+CREATE TABLE IF NOT EXISTS content_packs (
+    name TEXT PRIMARY KEY,
+    version INTEGER NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    course_id BIGINT NOT NULL DEFAULT 1 REFERENCES courses(id),
+    installed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS content_pack_words (
+    pack_name TEXT NOT NULL REFERENCES content_packs(name) ON DELETE CASCADE,
+    word_id BIGINT NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    created BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (pack_name, word_id)
+);
+
+CREATE TABLE IF NOT EXISTS content_pack_groups (
+    pack_name TEXT NOT NULL REFERENCES content_packs(name) ON DELETE CASCADE,
+    group_id BIGINT NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    created BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (pack_name, group_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_pack_words_word_id ON content_pack_words(word_id);
+CREATE INDEX IF NOT EXISTS idx_content_pack_groups_group_id ON content_pack_groups(group_id);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS content_pack_groups;
+DROP TABLE IF EXISTS content_pack_words;
+DROP TABLE IF EXISTS content_packs;
@@ -0,0 +1,92 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Every insert, update and delete of words, groups, group memberships, study
+-- sessions and reviews is logged under an increasing sequence number, which
+-- offline clients sync from; see GET /api/sync
+CREATE TABLE IF NOT EXISTS change_log (
+    seq BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    entity TEXT NOT NULL CHECK (entity IN ('word', 'group', 'group_word', 'study_session', 'review')),
+    entity_id BIGINT NOT NULL,
+    op TEXT NOT NULL CHECK (op IN ('insert', 'update', 'delete')),
+    changed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_change_log_entity ON change_log(entity, entity_id, seq);
+
+-- The epoch names the change log a sync token belongs to. A new database gets
+-- a new one, which sends clients back to a full sync.
+CREATE TABLE IF NOT EXISTS sync_state (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    epoch TEXT NOT NULL
+);
+
+INSERT INTO sync_state (id, epoch) VALUES (1, substr(md5(random()::text || clock_timestamp()::text), 1, 16))
+ON CONFLICT (id) DO NOTHING;
+
+-- Sessions and reviews recorded offline carry the UUID the client gave them,
+-- so pushing them again does not record them twice
+ALTER TABLE study_sessions ADD COLUMN IF NOT EXISTS client_id TEXT;
+ALTER TABLE word_review_items ADD COLUMN IF NOT EXISTS client_id TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_study_sessions_client_id ON study_sessions(client_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_word_review_items_client_id ON word_review_items(client_id);
+
+-- Rows from before the log are logged as inserts, so a first sync gets them
+INSERT INTO change_log (entity, entity_id, op)
+SELECT entity, id, 'insert' FROM (
+    SELECT 'word' AS entity, id, 1 AS n FROM words
+    UNION ALL SELECT 'group', id, 2 FROM groups
+    UNION ALL SELECT 'group_word', id, 3 FROM words_groups
+    UNION ALL SELECT 'study_session', id, 4 FROM study_sessions
+    UNION ALL SELECT 'review', id, 5 FROM word_review_items
+) existing
+WHERE NOT EXISTS (SELECT 1 FROM change_log)
+ORDER BY n, id;
+
+-- Writers queue on the data version row before they take a sequence number,
+-- so changes commit in sequence order and a pull cannot pass over a change
+-- that commits after a later one
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION log_change() RETURNS trigger AS $$
+BEGIN
+    PERFORM 1 FROM data_version WHERE id = 1 FOR UPDATE;
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO change_log (entity, entity_id, op) VALUES (TG_ARGV[0], OLD.id, 'delete');
+    ELSE
+        INSERT INTO change_log (entity, entity_id, op) VALUES (TG_ARGV[0], NEW.id, lower(TG_OP));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS log_change ON words;
+CREATE TRIGGER log_change AFTER INSERT OR UPDATE OR DELETE ON words
+    FOR EACH ROW EXECUTE FUNCTION log_change('word');
+DROP TRIGGER IF EXISTS log_change ON groups;
+CREATE TRIGGER log_change AFTER INSERT OR UPDATE OR DELETE ON groups
+    FOR EACH ROW EXECUTE FUNCTION log_change('group');
+DROP TRIGGER IF EXISTS log_change ON words_groups;
+CREATE TRIGGER log_change AFTER INSERT OR UPDATE OR DELETE ON words_groups
+    FOR EACH ROW EXECUTE FUNCTION log_change('group_word');
+DROP TRIGGER IF EXISTS log_change ON study_sessions;
+CREATE TRIGGER log_change AFTER INSERT OR UPDATE OR DELETE ON study_sessions
+    FOR EACH ROW EXECUTE FUNCTION log_change('study_session');
+DROP TRIGGER IF EXISTS log_change ON word_review_items;
+CREATE TRIGGER log_change AFTER INSERT OR UPDATE OR DELETE ON word_review_items
+    FOR EACH ROW EXECUTE FUNCTION log_change('review');
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TRIGGER IF EXISTS log_change ON words;
+DROP TRIGGER IF EXISTS log_change ON groups;
+DROP TRIGGER IF EXISTS log_change ON words_groups;
+DROP TRIGGER IF EXISTS log_change ON study_sessions;
+DROP TRIGGER IF EXISTS log_change ON word_review_items;
+DROP FUNCTION IF EXISTS log_change();
+DROP INDEX IF EXISTS idx_word_review_items_client_id;
+DROP INDEX IF EXISTS idx_study_sessions_client_id;
+ALTER TABLE word_review_items DROP COLUMN IF EXISTS client_id;
+ALTER TABLE study_sessions DROP COLUMN IF EXISTS client_id;
+DROP TABLE IF EXISTS sync_state;
+DROP TABLE IF EXISTS change_log;
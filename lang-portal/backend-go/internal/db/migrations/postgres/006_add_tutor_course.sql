@@ -0,0 +1,32 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Tutor conversations practise a course, and the vocabulary the tutor lists
+-- is kept under the same generic column names as words. Existing
+-- conversations were all held in the Italian course.
+ALTER TABLE tutor_conversations ADD COLUMN IF NOT EXISTS course_id BIGINT NOT NULL DEFAULT 1 REFERENCES courses(id);
+ALTER TABLE tutor_vocabulary ADD COLUMN IF NOT EXISTS course_id BIGINT NOT NULL DEFAULT 1 REFERENCES courses(id);
+
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'tutor_vocabulary' AND column_name = 'italian'
+    ) THEN
+        ALTER TABLE tutor_vocabulary RENAME COLUMN italian TO term;
+        ALTER TABLE tutor_vocabulary RENAME COLUMN english TO translation;
+    END IF;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS idx_tutor_vocabulary_course_term ON tutor_vocabulary(course_id, term);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX IF EXISTS idx_tutor_vocabulary_course_term;
+ALTER TABLE tutor_vocabulary DROP COLUMN IF EXISTS course_id;
+ALTER TABLE tutor_vocabulary RENAME COLUMN term TO italian;
+ALTER TABLE tutor_vocabulary RENAME COLUMN translation TO english;
+ALTER TABLE tutor_conversations DROP COLUMN IF EXISTS course_id;
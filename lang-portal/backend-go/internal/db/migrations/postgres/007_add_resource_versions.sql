@@ -0,0 +1,155 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+
+-- Every write bumped the single data version row, so writers contended for
+-- it. Table and group versions replace it; the row stays only as the lock
+-- sync pushes take.
+DROP TRIGGER IF EXISTS bump_data_version ON courses;
+DROP TRIGGER IF EXISTS bump_data_version ON words;
+DROP TRIGGER IF EXISTS bump_data_version ON groups;
+DROP TRIGGER IF EXISTS bump_data_version ON words_groups;
+DROP TRIGGER IF EXISTS bump_data_version ON study_activities;
+DROP TRIGGER IF EXISTS bump_data_version ON study_sessions;
+DROP TRIGGER IF EXISTS bump_data_version ON word_review_items;
+DROP FUNCTION IF EXISTS bump_data_version();
+
+-- ETags are derived from the versions of the tables a response is read from,
+-- or of the group it describes, so that writes elsewhere don't make them
+-- stale; see handlers.ETags
+CREATE TABLE IF NOT EXISTS table_versions (
+    name TEXT PRIMARY KEY,
+    version BIGINT NOT NULL
+);
+
+INSERT INTO table_versions (name, version) VALUES
+    ('courses', 1),
+    ('words', 1),
+    ('groups', 1),
+    ('words_groups', 1),
+    ('study_activities', 1),
+    ('study_sessions', 1),
+    ('word_review_items', 1),
+    ('listening_answers', 1)
+ON CONFLICT (name) DO NOTHING;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION bump_table_version() RETURNS trigger AS $$
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = TG_TABLE_NAME;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS bump_table_version ON courses;
+CREATE TRIGGER bump_table_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON courses
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_table_version();
+DROP TRIGGER IF EXISTS bump_table_version ON words;
+CREATE TRIGGER bump_table_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON words
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_table_version();
+DROP TRIGGER IF EXISTS bump_table_version ON groups;
+CREATE TRIGGER bump_table_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON groups
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_table_version();
+DROP TRIGGER IF EXISTS bump_table_version ON words_groups;
+CREATE TRIGGER bump_table_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON words_groups
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_table_version();
+DROP TRIGGER IF EXISTS bump_table_version ON study_activities;
+CREATE TRIGGER bump_table_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON study_activities
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_table_version();
+DROP TRIGGER IF EXISTS bump_table_version ON study_sessions;
+CREATE TRIGGER bump_table_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON study_sessions
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_table_version();
+DROP TRIGGER IF EXISTS bump_table_version ON word_review_items;
+CREATE TRIGGER bump_table_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON word_review_items
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_table_version();
+DROP TRIGGER IF EXISTS bump_table_version ON listening_answers;
+CREATE TRIGGER bump_table_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON listening_answers
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_table_version();
+
+-- A group's version moves when it is renamed or its words change
+CREATE TABLE IF NOT EXISTS group_versions (
+    group_id BIGINT PRIMARY KEY REFERENCES groups(id) ON DELETE CASCADE,
+    version BIGINT NOT NULL DEFAULT 1
+);
+
+INSERT INTO group_versions (group_id) SELECT id FROM groups ON CONFLICT (group_id) DO NOTHING;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION add_group_version() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO group_versions (group_id) VALUES (NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION bump_group_version() RETURNS trigger AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'groups' THEN
+        UPDATE group_versions SET version = version + 1 WHERE group_id = NEW.id;
+        RETURN NULL;
+    END IF;
+    IF TG_OP <> 'INSERT' THEN
+        UPDATE group_versions SET version = version + 1 WHERE group_id = OLD.group_id;
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        UPDATE group_versions SET version = version + 1 WHERE group_id = NEW.group_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS add_group_version ON groups;
+CREATE TRIGGER add_group_version AFTER INSERT ON groups
+    FOR EACH ROW EXECUTE FUNCTION add_group_version();
+DROP TRIGGER IF EXISTS bump_group_version ON groups;
+CREATE TRIGGER bump_group_version AFTER UPDATE OF name ON groups
+    FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name) EXECUTE FUNCTION bump_group_version();
+DROP TRIGGER IF EXISTS bump_group_version ON words_groups;
+CREATE TRIGGER bump_group_version AFTER INSERT OR UPDATE OR DELETE ON words_groups
+    FOR EACH ROW EXECUTE FUNCTION bump_group_version();
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TRIGGER IF EXISTS add_group_version ON groups;
+DROP TRIGGER IF EXISTS bump_group_version ON groups;
+DROP TRIGGER IF EXISTS bump_group_version ON words_groups;
+DROP FUNCTION IF EXISTS bump_group_version();
+DROP FUNCTION IF EXISTS add_group_version();
+DROP TABLE IF EXISTS group_versions;
+DROP TRIGGER IF EXISTS bump_table_version ON courses;
+DROP TRIGGER IF EXISTS bump_table_version ON words;
+DROP TRIGGER IF EXISTS bump_table_version ON groups;
+DROP TRIGGER IF EXISTS bump_table_version ON words_groups;
+DROP TRIGGER IF EXISTS bump_table_version ON study_activities;
+DROP TRIGGER IF EXISTS bump_table_version ON study_sessions;
+DROP TRIGGER IF EXISTS bump_table_version ON word_review_items;
+DROP TRIGGER IF EXISTS bump_table_version ON listening_answers;
+DROP FUNCTION IF EXISTS bump_table_version();
+DROP TABLE IF EXISTS table_versions;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION bump_data_version() RETURNS trigger AS $$
+BEGIN
+    UPDATE data_version SET version = version + 1;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON courses
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON words
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON groups
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON words_groups
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON study_activities
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON study_sessions
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+CREATE TRIGGER bump_data_version AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON word_review_items
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
@@ -0,0 +1,37 @@
+// Package postgres holds the goose migrations of the PostgreSQL schema.
+// The SQLite migrations are in the parent directory.
+package postgres
+
+import (
+	"embed"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// FS holds the migrations, for applying them without the goose CLI
+//
+//go:embed *.sql
+var FS embed.FS
+
+// Schema returns the statements creating the current schema: the Up sections
+// of all migrations, in order
+func Schema() (string, error) {
+	names, err := fs.Glob(FS, "*.sql")
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(names)
+
+	var schema strings.Builder
+	for _, name := range names {
+		data, err := FS.ReadFile(name)
+		if err != nil {
+			return "", err
+		}
+		up, _, _ := strings.Cut(string(data), "-- +goose Down")
+		schema.WriteString(up)
+		schema.WriteString("\n")
+	}
+	return schema.String(), nil
+}
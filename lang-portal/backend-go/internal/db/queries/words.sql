@@ -8,7 +8,7 @@ ORDER BY id;
 
 -- name: CreateWord :one
 INSERT INTO words (
-  italian, english, parts
+  term, translation, parts
 ) VALUES (
   ?, ?, ?
 )
@@ -16,7 +16,7 @@ RETURNING *;
 
 -- name: UpdateWord :one
 UPDATE words
-SET italian = ?, english = ?, parts = ?
+SET term = ?, translation = ?, parts = ?
 WHERE id = ?
 RETURNING *;
 
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// Backup writes a consistent copy of the SQLite database to path, which must
+// not exist yet, while the database stays in use. PostgreSQL databases are
+// backed up with pg_dump instead.
+func (r *SQLRepository) Backup(ctx context.Context, path string) error {
+	if r.db.dialect == postgresDialect {
+		return models.NewError(models.ErrUnavailable, "backup_unsupported", "back up PostgreSQL databases with pg_dump")
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("backup file %s already exists", path)
+	}
+	_, err := r.db.ExecContext(ctx, "VACUUM INTO ?", path)
+	return err
+}
+
+// Restore replaces the SQLite database at dbPath with the backup at
+// backupPath, after checking that the backup is an intact lang-portal
+// database. Nothing may have the database open while it is restored. The
+// restored change log gets a new epoch, so sync clients start over instead
+// of pulling from a position in the history the restore undid.
+func Restore(ctx context.Context, backupPath, dbPath string) error {
+	if err := checkBackup(ctx, backupPath); err != nil {
+		return err
+	}
+
+	src, err := os.Open(backupPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// Copy next to the database and rename over it, so a failed copy never
+	// leaves a partial database behind
+	tmp, err := os.CreateTemp(filepath.Dir(dbPath), filepath.Base(dbPath)+"-restore-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := renewSyncEpoch(ctx, tmp.Name()); err != nil {
+		return err
+	}
+
+	// The write-ahead log of the old database must not be replayed onto the
+	// restored one
+	for _, file := range []string{dbPath + "-wal", dbPath + "-shm"} {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), dbPath)
+}
+
+// checkBackup opens a backup read-only and checks its integrity and that it
+// holds the lang-portal schema
+func checkBackup(ctx context.Context, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	db, err := sql.Open("sqlite", sqliteDSN(path, true))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var result string
+	if err := db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&result); err != nil {
+		return fmt.Errorf("%s is not a SQLite database: %w", path, err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("backup %s is corrupt: %s", path, result)
+	}
+	var tables int
+	err = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('words', 'groups', 'study_sessions')").Scan(&tables)
+	if err != nil {
+		return err
+	}
+	if tables != 3 {
+		return fmt.Errorf("backup %s is not a lang-portal database", path)
+	}
+	return nil
+}
+
+// renewSyncEpoch gives the change log of the database at path a new epoch.
+// Backups from before the change log have none; they get one when migrated.
+func renewSyncEpoch(ctx context.Context, path string) error {
+	db, err := sql.Open("sqlite", sqliteDSN(path, false))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var tables int
+	err = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'sync_state'").Scan(&tables)
+	if err != nil || tables == 0 {
+		return err
+	}
+	_, err = db.ExecContext(ctx, "UPDATE sync_state SET epoch = lower(hex(randomblob(8)))")
+	return err
+}
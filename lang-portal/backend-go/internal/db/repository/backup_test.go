@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath, backupPath := filepath.Join(dir, "words.db"), filepath.Join(dir, "backup.db")
+
+	repo, err := NewDB(dbPath)
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateTables(ctx))
+	_, err = repo.CreateGroup(ctx, "Backed up")
+	require.NoError(t, err)
+
+	epoch, _, err := repo.GetChangeLogState(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Backup(ctx, backupPath))
+	assert.Error(t, repo.Backup(ctx, backupPath), "backups are not overwritten")
+
+	_, err = repo.CreateGroup(ctx, "After the backup")
+	require.NoError(t, err)
+	require.NoError(t, repo.Close())
+
+	require.NoError(t, Restore(ctx, backupPath, dbPath))
+
+	repo, err = NewDB(dbPath)
+	require.NoError(t, err)
+	defer repo.Close()
+	groups, err := repo.GetGroups(ctx, models.ListParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, groups.Items, 1)
+	assert.Equal(t, "Backed up", groups.Items[0].Name)
+
+	// Sync clients start over from the restored database
+	restoredEpoch, _, err := repo.GetChangeLogState(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, epoch, restoredEpoch)
+}
+
+func TestRestore_RejectsInvalidBackups(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "words.db")
+	require.NoError(t, os.WriteFile(dbPath, []byte("current"), 0o644))
+
+	notSQLite := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(notSQLite, []byte("not a database"), 0o644))
+	assert.Error(t, Restore(ctx, notSQLite, dbPath))
+
+	other, err := NewDB(filepath.Join(dir, "other.db"))
+	require.NoError(t, err)
+	_, err = other.DB().Exec("CREATE TABLE notes (id INTEGER)")
+	require.NoError(t, err)
+	require.NoError(t, other.Close())
+	assert.Error(t, Restore(ctx, filepath.Join(dir, "other.db"), dbPath))
+
+	assert.Error(t, Restore(ctx, filepath.Join(dir, "missing.db"), dbPath))
+
+	data, err := os.ReadFile(dbPath)
+	require.NoError(t, err)
+	assert.Equal(t, "current", string(data))
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// BenchmarkDashboardDuringReviews reads the dashboard while reviews are
+// recorded in batches, as when a study session is synced or words are
+// imported. Each operation is one dashboard read; reviews/s shows that the
+// writer keeps its pace. "single connection" is how SQLite was opened before
+// WAL and the read pool, with reads queued behind every open transaction.
+//
+//	go test -run '^$' -bench DashboardDuringReviews ./internal/db/repository
+func BenchmarkDashboardDuringReviews(b *testing.B) {
+	b.Run("single connection", func(b *testing.B) {
+		db, err := sql.Open(sqliteDialect.driver, filepath.Join(b.TempDir(), "bench.db"))
+		if err != nil {
+			b.Fatal(err)
+		}
+		db.SetMaxOpenConns(1)
+		repo := &SQLRepository{db: &sqlDB{DB: db, dialect: sqliteDialect}}
+		defer repo.Close()
+
+		benchmarkDashboardDuringReviews(b, repo)
+	})
+
+	b.Run("read pool", func(b *testing.B) {
+		repo, err := NewDB(filepath.Join(b.TempDir(), "bench.db"))
+		if err != nil {
+			b.Fatal(err)
+		}
+		defer repo.Close()
+
+		benchmarkDashboardDuringReviews(b, repo)
+	})
+}
+
+func benchmarkDashboardDuringReviews(b *testing.B, repo *SQLRepository) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := repo.CreateTables(ctx); err != nil {
+		b.Fatal(err)
+	}
+	sessionID, wordIDs := seedReviews(b, repo, 200, 20000)
+
+	var reviews atomic.Int64
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for ctx.Err() == nil {
+			tx, err := repo.BeginTx(ctx)
+			if err != nil {
+				return
+			}
+			for i := 0; i < 100; i++ {
+				_, err = tx.ExecContext(ctx, "INSERT INTO word_review_items (word_id, study_session_id, correct) VALUES (?, ?, ?)", wordIDs[i%len(wordIDs)], sessionID, i%3 != 0)
+				if err != nil {
+					break
+				}
+			}
+			if err != nil || tx.Commit() != nil {
+				tx.Rollback()
+				return
+			}
+			reviews.Add(100)
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := repo.GetQuickStats(ctx); err != nil {
+				b.Error(err)
+			}
+			if _, err := repo.GetStudyProgress(ctx); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+	b.StopTimer()
+
+	cancel()
+	<-done
+	b.ReportMetric(float64(reviews.Load())/b.Elapsed().Seconds(), "reviews/s")
+}
+
+// seedReviews stores a group of words studied in one session with the given
+// number of reviews
+func seedReviews(tb testing.TB, repo *SQLRepository, words, reviews int) (sessionID int64, wordIDs []int64) {
+	ctx := context.Background()
+	tx, err := repo.BeginTx(ctx)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	var groupID, activityID int64
+	if err := tx.QueryRowContext(ctx, "INSERT INTO groups (name) VALUES (?) RETURNING id", "Benchmark").Scan(&groupID); err != nil {
+		tb.Fatal(err)
+	}
+	if err := tx.QueryRowContext(ctx, "INSERT INTO study_activities (name) VALUES (?) RETURNING id", "Flashcards").Scan(&activityID); err != nil {
+		tb.Fatal(err)
+	}
+	if err := tx.QueryRowContext(ctx, "INSERT INTO study_sessions (group_id, study_activity_id) VALUES (?, ?) RETURNING id", groupID, activityID).Scan(&sessionID); err != nil {
+		tb.Fatal(err)
+	}
+	for i := 0; i < words; i++ {
+		var id int64
+		err := tx.QueryRowContext(ctx, "INSERT INTO words (term, translation, parts, course_id) VALUES (?, ?, '{}', ?) RETURNING id", "parola", "word", models.DefaultCourseID).Scan(&id)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		wordIDs = append(wordIDs, id)
+	}
+	for i := 0; i < reviews; i++ {
+		_, err := tx.ExecContext(ctx, "INSERT INTO word_review_items (word_id, study_session_id, correct) VALUES (?, ?, ?)", wordIDs[i%words], sessionID, i%3 != 0)
+		if err != nil {
+			tb.Fatal(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		tb.Fatal(err)
+	}
+	return sessionID, wordIDs
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// GetContentPacks returns the installed content packs by name
+func (r *SQLRepository) GetContentPacks(ctx context.Context) ([]models.ContentPackResponse, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT name, version, description, course_id, installed_at,
+			(SELECT COUNT(*) FROM content_pack_words WHERE pack_name = p.name),
+			(SELECT COUNT(*) FROM content_pack_groups WHERE pack_name = p.name)
+		FROM content_packs p
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	packs := []models.ContentPackResponse{}
+	for rows.Next() {
+		var pack models.ContentPackResponse
+		err := rows.Scan(&pack.Name, &pack.Version, &pack.Description, &pack.CourseID, &pack.InstalledAt, &pack.WordCount, &pack.GroupCount)
+		if err != nil {
+			return nil, err
+		}
+		packs = append(packs, pack)
+	}
+	return packs, rows.Err()
+}
+
+// DeleteContentPack uninstalls a content pack. The words the pack created
+// are removed with their example sentences, unless another pack lists them
+// or they have been reviewed, so no learner loses review history. The words
+// the pack put in groups are taken out of them, and groups the pack created
+// are removed once they hold no words and no study sessions.
+func (r *SQLRepository) DeleteContentPack(ctx context.Context, name string) (*models.UninstallPackResponse, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM content_packs WHERE name = ?)", name).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, models.NotFoundError("content pack")
+	}
+
+	result := &models.UninstallPackResponse{RemovedWordIDs: []int64{}}
+	rows, err := tx.QueryContext(ctx, `
+		SELECT cpw.word_id, EXISTS (SELECT 1 FROM word_review_items wri WHERE wri.word_id = cpw.word_id)
+		FROM content_pack_words cpw
+		WHERE cpw.pack_name = ? AND cpw.created
+			AND NOT EXISTS (SELECT 1 FROM content_pack_words other WHERE other.word_id = cpw.word_id AND other.pack_name <> cpw.pack_name)
+		ORDER BY cpw.word_id
+	`, name)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var wordID int64
+		var reviewed bool
+		if err := rows.Scan(&wordID, &reviewed); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if reviewed {
+			result.KeptWords++
+		} else {
+			result.RemovedWordIDs = append(result.RemovedWordIDs, wordID)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(result.RemovedWordIDs) > 0 {
+		args := make([]interface{}, len(result.RemovedWordIDs))
+		for i, id := range result.RemovedWordIDs {
+			args[i] = id
+		}
+		in := "(?" + strings.Repeat(", ?", len(args)-1) + ")"
+
+		// Imported sentences are removed with the last word they illustrate
+		var sentenceIDs []interface{}
+		rows, err := tx.QueryContext(ctx, "SELECT DISTINCT sentence_id FROM words_sentences WHERE word_id IN "+in, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			sentenceIDs = append(sentenceIDs, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM words WHERE id IN "+in, args...); err != nil {
+			return nil, err
+		}
+		result.RemovedWords = len(result.RemovedWordIDs)
+
+		if len(sentenceIDs) > 0 {
+			_, err := tx.ExecContext(ctx, `
+				DELETE FROM sentences
+				WHERE id IN (?`+strings.Repeat(", ?", len(sentenceIDs)-1)+`) AND source = ?
+					AND NOT EXISTS (SELECT 1 FROM words_sentences ws WHERE ws.sentence_id = sentences.id)`,
+				append(sentenceIDs, models.SentenceSourceImport)...,
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Mappings of the words the pack created that are kept stay, as do ones
+	// another pack lists
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM words_groups
+		WHERE EXISTS (
+			SELECT 1 FROM content_pack_words_groups cpwg
+			WHERE cpwg.pack_name = ? AND cpwg.created
+				AND cpwg.word_id = words_groups.word_id AND cpwg.group_id = words_groups.group_id
+				AND NOT EXISTS (
+					SELECT 1 FROM content_pack_words_groups other
+					WHERE other.word_id = cpwg.word_id AND other.group_id = cpwg.group_id AND other.pack_name <> cpwg.pack_name
+				)
+		)
+			AND NOT EXISTS (SELECT 1 FROM content_pack_words cpw WHERE cpw.pack_name = ? AND cpw.word_id = words_groups.word_id AND cpw.created)
+	`, name, name)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		DELETE FROM groups
+		WHERE id IN (
+			SELECT cpg.group_id FROM content_pack_groups cpg
+			WHERE cpg.pack_name = ? AND cpg.created
+				AND NOT EXISTS (SELECT 1 FROM content_pack_groups other WHERE other.group_id = cpg.group_id AND other.pack_name <> cpg.pack_name)
+		)
+			AND NOT EXISTS (SELECT 1 FROM words_groups wg WHERE wg.group_id = groups.id)
+			AND NOT EXISTS (SELECT 1 FROM study_sessions ss WHERE ss.group_id = groups.id)
+	`, name)
+	if err != nil {
+		return nil, err
+	}
+	removedGroups, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	result.RemovedGroups = int(removedGroups)
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM content_packs WHERE name = ?", name); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+func (r *SQLRepository) GetLanguages(ctx context.Context) ([]models.LanguageResponse, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT code, name, script FROM languages ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	languages := []models.LanguageResponse{}
+	for rows.Next() {
+		var language models.LanguageResponse
+		if err := rows.Scan(&language.Code, &language.Name, &language.Script); err != nil {
+			return nil, err
+		}
+		languages = append(languages, language)
+	}
+	return languages, rows.Err()
+}
+
+func (r *SQLRepository) GetLanguage(ctx context.Context, code string) (*models.LanguageResponse, error) {
+	var language models.LanguageResponse
+	err := r.db.QueryRowContext(ctx,
+		"SELECT code, name, script FROM languages WHERE code = ?",
+		code,
+	).Scan(&language.Code, &language.Name, &language.Script)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFoundError("language")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &language, nil
+}
+
+func (r *SQLRepository) CreateLanguage(ctx context.Context, language *models.LanguageResponse) error {
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO languages (code, name, script) VALUES (?, ?, ?)",
+		language.Code,
+		language.Name,
+		language.Script,
+	)
+	return err
+}
+
+const courseQuery = `
+	SELECT
+		c.id, c.name, c.created_at,
+		s.code, s.name, s.script,
+		t.code, t.name, t.script
+	FROM courses c
+	JOIN languages s ON s.code = c.source_language
+	JOIN languages t ON t.code = c.target_language
+`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCourse(row rowScanner) (*models.CourseResponse, error) {
+	var course models.CourseResponse
+	err := row.Scan(
+		&course.ID,
+		&course.Name,
+		&course.CreatedAt,
+		&course.SourceLanguage.Code,
+		&course.SourceLanguage.Name,
+		&course.SourceLanguage.Script,
+		&course.TargetLanguage.Code,
+		&course.TargetLanguage.Name,
+		&course.TargetLanguage.Script,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+func (r *SQLRepository) GetCourses(ctx context.Context) ([]models.CourseResponse, error) {
+	rows, err := r.db.QueryContext(ctx, courseQuery+" ORDER BY c.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	courses := []models.CourseResponse{}
+	for rows.Next() {
+		course, err := scanCourse(rows)
+		if err != nil {
+			return nil, err
+		}
+		courses = append(courses, *course)
+	}
+	return courses, rows.Err()
+}
+
+func (r *SQLRepository) GetCourse(ctx context.Context, id int64) (*models.CourseResponse, error) {
+	course, err := scanCourse(r.db.QueryRowContext(ctx, courseQuery+" WHERE c.id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, models.NotFoundError("course")
+	}
+	return course, err
+}
+
+// GetCourseByLanguages returns the course for a language pair, or nil if
+// there is none
+func (r *SQLRepository) GetCourseByLanguages(ctx context.Context, source, target string) (*models.CourseResponse, error) {
+	course, err := scanCourse(r.db.QueryRowContext(ctx, courseQuery+" WHERE c.source_language = ? AND c.target_language = ?", source, target))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return course, err
+}
+
+func (r *SQLRepository) CreateCourse(ctx context.Context, name, source, target string) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx,
+		"INSERT INTO courses (name, source_language, target_language) VALUES (?, ?, ?) RETURNING id",
+		name,
+		source,
+		target,
+	).Scan(&id)
+	return id, err
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// GetTableVersion returns the combined version of tables, which triggers move
+// on every write to one of them. Only courses, words, groups, words_groups,
+// study_activities, study_sessions, word_review_items and listening_answers
+// are versioned.
+func (r *SQLRepository) GetTableVersion(ctx context.Context, tables ...string) (int64, error) {
+	if len(tables) == 0 {
+		return 0, nil
+	}
+
+	args := make([]interface{}, len(tables))
+	for i, table := range tables {
+		args[i] = table
+	}
+
+	var version int64
+	err := r.db.QueryRowContext(ctx,
+		"SELECT COALESCE(SUM(version), 0) FROM table_versions WHERE name IN (?"+strings.Repeat(", ?", len(tables)-1)+")",
+		args...,
+	).Scan(&version)
+	return version, err
+}
+
+// GetGroupVersion returns the version of a group, which triggers move when it
+// is renamed or words are added to or removed from it
+func (r *SQLRepository) GetGroupVersion(ctx context.Context, id int64) (int64, error) {
+	var version int64
+	err := r.db.QueryRowContext(ctx, "SELECT version FROM group_versions WHERE group_id = ?", id).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, models.NotFoundError("group")
+	}
+	return version, err
+}
+
+// UpdateGroup renames a group and returns the group's version after the
+// rename. Unless ifVersion is 0, the group is only renamed while its version
+// is still ifVersion, so a client cannot overwrite changes it has not seen.
+func (r *SQLRepository) UpdateGroup(ctx context.Context, id int64, name string, ifVersion int64) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var version int64
+	err = tx.QueryRowContext(ctx, "SELECT version FROM group_versions WHERE group_id = ?"+r.db.dialect.forUpdate, id).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, models.NotFoundError("group")
+	}
+	if err != nil {
+		return 0, err
+	}
+	if ifVersion != 0 && ifVersion != version {
+		return 0, models.VersionMismatchError()
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE groups SET name = ? WHERE id = ?", name, id); err != nil {
+		return 0, err
+	}
+
+	if err := tx.QueryRowContext(ctx, "SELECT version FROM group_versions WHERE group_id = ?", id).Scan(&version); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sqliteTimeFormat is the format CURRENT_TIMESTAMP stores times in with
+// SQLite, so time arguments compare correctly with stored times
+const sqliteTimeFormat = "2006-01-02 15:04:05"
+
+// dialect describes the SQL of one database. Queries are written with ?
+// placeholders and the syntax SQLite and PostgreSQL share; what differs
+// between them comes from the dialect.
+type dialect struct {
+	// driver is the name of the database/sql driver
+	driver string
+	// numberedParams is set when placeholders are written $1, $2, ...
+	numberedParams bool
+	// nowPlus is the current time moved by an interval argument such as
+	// "-7 days" or "+3600 seconds"
+	nowPlus string
+	// startOfMonth is the start of the current UTC month
+	startOfMonth string
+	// daysAgo is the start of the UTC day the given number of days ago
+	daysAgo func(days int) string
+	// day is the UTC date of a timestamp column, formatted YYYY-MM-DD
+	day func(column string) string
+	// skipLocked ends a subquery selecting a row to claim, so concurrent
+	// claims pass over rows being claimed instead of claiming them twice
+	skipLocked string
+	// forUpdate ends a query reading a row that the transaction updates
+	// depending on what it read, so concurrent transactions wait for it
+	forUpdate string
+	// timeArg converts a time into an argument compared with stored times
+	timeArg func(t time.Time) interface{}
+}
+
+var sqliteDialect = &dialect{
+	driver:       "sqlite",
+	nowPlus:      "datetime('now', ?)",
+	startOfMonth: "datetime('now', 'start of month')",
+	daysAgo: func(days int) string {
+		return fmt.Sprintf("date('now', '-%d days')", days)
+	},
+	day: func(column string) string {
+		return "date(" + column + ")"
+	},
+	timeArg: func(t time.Time) interface{} {
+		return t.UTC().Format(sqliteTimeFormat)
+	},
+}
+
+// postgresDialect expects sessions in the UTC time zone, see NewPostgresDB
+var postgresDialect = &dialect{
+	driver:         "pgx",
+	numberedParams: true,
+	nowPlus:        "CURRENT_TIMESTAMP + CAST(? AS INTERVAL)",
+	startOfMonth:   "date_trunc('month', CURRENT_TIMESTAMP)",
+	daysAgo: func(days int) string {
+		return fmt.Sprintf("(CURRENT_DATE - %d)", days)
+	},
+	day: func(column string) string {
+		return "to_char(" + column + ", 'YYYY-MM-DD')"
+	},
+	skipLocked: " FOR UPDATE SKIP LOCKED",
+	forUpdate:  " FOR UPDATE",
+	timeArg: func(t time.Time) interface{} {
+		return t
+	},
+}
+
+// rebind rewrites the ? placeholders of query as the dialect writes them.
+// Question marks in quoted strings are left alone.
+func (d *dialect) rebind(query string) string {
+	if !d.numberedParams || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 16)
+	n, quoted := 0, false
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '\'':
+			quoted = !quoted
+		case c == '?' && !quoted:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteByte(query[i])
+	}
+	return b.String()
+}
+
+// sqlDB runs queries written with ? placeholders against a database of any
+// dialect. DB is where writes and transactions go; with a reader, SELECT
+// statements run on that pool instead.
+type sqlDB struct {
+	*sql.DB
+	reader  *sql.DB
+	dialect *dialect
+}
+
+// pool returns the pool query runs on
+func (db *sqlDB) pool(query string) *sql.DB {
+	if db.reader != nil && isSelect(query) {
+		return db.reader
+	}
+	return db.DB
+}
+
+// isSelect reports whether query is a SELECT statement, which cannot write.
+// Statements such as INSERT ... RETURNING are not, even though they return
+// rows.
+func isSelect(query string) bool {
+	query = strings.TrimLeft(query, " \t\r\n")
+	return len(query) >= 6 && strings.EqualFold(query[:6], "SELECT")
+}
+
+func (db *sqlDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return db.DB.ExecContext(ctx, db.dialect.rebind(query), args...)
+}
+
+func (db *sqlDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return db.pool(query).QueryContext(ctx, db.dialect.rebind(query), args...)
+}
+
+func (db *sqlDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return db.pool(query).QueryRowContext(ctx, db.dialect.rebind(query), args...)
+}
+
+func (db *sqlDB) Close() error {
+	var err error
+	if db.reader != nil {
+		err = db.reader.Close()
+	}
+	return errors.Join(err, db.DB.Close())
+}
+
+func (db *sqlDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, dialect: db.dialect}, nil
+}
+
+// BeginReadTx starts a read-only transaction, on the reader pool when there
+// is one, in which every SELECT sees the same snapshot of the database
+func (db *sqlDB) BeginReadTx(ctx context.Context) (*Tx, error) {
+	pool := db.DB
+	if db.reader != nil {
+		pool = db.reader
+	}
+	opts := &sql.TxOptions{ReadOnly: true}
+	if db.dialect == postgresDialect {
+		// Read committed would take a new snapshot for each statement
+		opts.Isolation = sql.LevelRepeatableRead
+	}
+	tx, err := pool.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, dialect: db.dialect}, nil
+}
+
+// Tx is a transaction that, like the repository, takes queries written with
+// ? placeholders whatever the database
+type Tx struct {
+	*sql.Tx
+	dialect *dialect
+}
+
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return tx.Tx.ExecContext(ctx, tx.dialect.rebind(query), args...)
+}
+
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.Tx.QueryContext(ctx, tx.dialect.rebind(query), args...)
+}
+
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return tx.Tx.QueryRowContext(ctx, tx.dialect.rebind(query), args...)
+}
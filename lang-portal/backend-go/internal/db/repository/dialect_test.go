@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect *dialect
+		query   string
+		want    string
+	}{
+		{
+			name:    "sqlite keeps placeholders",
+			dialect: sqliteDialect,
+			query:   "SELECT id FROM words WHERE id = ? AND course_id = ?",
+			want:    "SELECT id FROM words WHERE id = ? AND course_id = ?",
+		},
+		{
+			name:    "postgres numbers placeholders",
+			dialect: postgresDialect,
+			query:   "SELECT id FROM words WHERE id = ? AND course_id = ?",
+			want:    "SELECT id FROM words WHERE id = $1 AND course_id = $2",
+		},
+		{
+			name:    "quoted question marks",
+			dialect: postgresDialect,
+			query:   "SELECT 'why?', ? FROM words WHERE term = 'it''s?' AND id = ?",
+			want:    "SELECT 'why?', $1 FROM words WHERE term = 'it''s?' AND id = $2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.dialect.rebind(tt.query))
+		})
+	}
+}
@@ -1,21 +1,30 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 )
 
-func (r *SQLiteRepository) GetGroups(limit, offset int) (*models.GroupListResponse, error) {
+// groupSortColumns maps models.GroupSortFields to the columns of the groups query
+var groupSortColumns = map[string]string{
+	"id":         "g.id",
+	"name":       "g.name",
+	"word_count": "word_count",
+}
+
+func (r *SQLRepository) GetGroups(ctx context.Context, params models.ListParams) (*models.GroupListResponse, error) {
 	query := `
 		SELECT 
 			g.id, g.name,
 			(SELECT COUNT(*) FROM words_groups wg WHERE wg.group_id = g.id) as word_count
-		FROM groups g
+		FROM groups g` + orderBy(params, groupSortColumns, "g.id") + `
 		LIMIT ? OFFSET ?
 	`
 
-	rows, err := r.db.Query(query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, params.Limit, params.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -38,23 +47,18 @@ func (r *SQLiteRepository) GetGroups(limit, offset int) (*models.GroupListRespon
 	// Get total count
 	countQuery := "SELECT COUNT(*) FROM groups"
 	var total int
-	err = r.db.QueryRow(countQuery).Scan(&total)
+	err = r.db.QueryRowContext(ctx, countQuery).Scan(&total)
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.GroupListResponse{
-		Items: groups,
-		Pagination: models.PaginationResponse{
-			CurrentPage:  offset/limit + 1,
-			TotalPages:   (total + limit - 1) / limit,
-			TotalItems:   total,
-			ItemsPerPage: limit,
-		},
+		Items:      groups,
+		Pagination: models.NewPagination(params.Limit, params.Offset, total),
 	}, nil
 }
 
-func (r *SQLiteRepository) GetGroupByID(id int64) (*models.GroupDetailResponse, error) {
+func (r *SQLRepository) GetGroupByID(ctx context.Context, id int64) (*models.GroupDetailResponse, error) {
 	query := `
 		SELECT 
 			g.id, g.name,
@@ -64,13 +68,13 @@ func (r *SQLiteRepository) GetGroupByID(id int64) (*models.GroupDetailResponse,
 	`
 
 	var group models.GroupDetailResponse
-	err := r.db.QueryRow(query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&group.ID,
 		&group.Name,
 		&group.Stats.TotalWordCount,
 	)
 	if err == sql.ErrNoRows {
-		return nil, nil
+		return nil, models.NotFoundError("group")
 	}
 	if err != nil {
 		return nil, err
@@ -79,10 +83,10 @@ func (r *SQLiteRepository) GetGroupByID(id int64) (*models.GroupDetailResponse,
 	return &group, nil
 }
 
-func (r *SQLiteRepository) GetGroupWords(groupID int64, limit, offset int) (*models.GroupWordsResponse, error) {
+func (r *SQLRepository) GetGroupWords(ctx context.Context, groupID int64, limit, offset int) (*models.GroupWordsResponse, error) {
 	query := `
 		SELECT 
-			w.id, w.italian, w.english, w.parts,
+			w.id, w.term, w.translation, w.course_id, w.parts,
 			COUNT(CASE WHEN wri.correct THEN 1 END) as correct_count,
 			COUNT(CASE WHEN NOT wri.correct THEN 1 END) as wrong_count
 		FROM words w
@@ -90,10 +94,11 @@ func (r *SQLiteRepository) GetGroupWords(groupID int64, limit, offset int) (*mod
 		LEFT JOIN word_review_items wri ON w.id = wri.word_id
 		WHERE wg.group_id = ?
 		GROUP BY w.id
+		ORDER BY w.id
 		LIMIT ? OFFSET ?
 	`
 
-	rows, err := r.db.Query(query, groupID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, groupID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -105,8 +110,9 @@ func (r *SQLiteRepository) GetGroupWords(groupID int64, limit, offset int) (*mod
 		var partsStr string
 		err := rows.Scan(
 			&word.ID,
-			&word.Italian,
-			&word.English,
+			&word.Term,
+			&word.Translation,
+			&word.CourseID,
 			&partsStr,
 			&word.CorrectCount,
 			&word.WrongCount,
@@ -114,6 +120,10 @@ func (r *SQLiteRepository) GetGroupWords(groupID int64, limit, offset int) (*mod
 		if err != nil {
 			return nil, err
 		}
+		// Parse parts JSON
+		if err := json.Unmarshal([]byte(partsStr), &word.Parts); err != nil {
+			return nil, err
+		}
 		words = append(words, word)
 	}
 
@@ -125,23 +135,18 @@ func (r *SQLiteRepository) GetGroupWords(groupID int64, limit, offset int) (*mod
 		WHERE wg.group_id = ?
 	`
 	var total int
-	err = r.db.QueryRow(countQuery, groupID).Scan(&total)
+	err = r.db.QueryRowContext(ctx, countQuery, groupID).Scan(&total)
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.GroupWordsResponse{
-		Items: words,
-		Pagination: models.PaginationResponse{
-			CurrentPage:  offset/limit + 1,
-			TotalPages:   (total + limit - 1) / limit,
-			TotalItems:   total,
-			ItemsPerPage: limit,
-		},
+		Items:      words,
+		Pagination: models.NewPagination(limit, offset, total),
 	}, nil
 }
 
-func (r *SQLiteRepository) GetGroupStudySessions(groupID int64, limit, offset int) (*models.GroupStudySessionsResponse, error) {
+func (r *SQLRepository) GetGroupStudySessions(ctx context.Context, groupID int64, limit, offset int) (*models.GroupStudySessionsResponse, error) {
 	query := `
 		SELECT 
 			ss.id,
@@ -158,7 +163,7 @@ func (r *SQLiteRepository) GetGroupStudySessions(groupID int64, limit, offset in
 		LIMIT ? OFFSET ?
 	`
 
-	rows, err := r.db.Query(query, groupID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, groupID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -184,18 +189,13 @@ func (r *SQLiteRepository) GetGroupStudySessions(groupID int64, limit, offset in
 	// Get total count
 	countQuery := "SELECT COUNT(*) FROM study_sessions WHERE group_id = ?"
 	var total int
-	err = r.db.QueryRow(countQuery, groupID).Scan(&total)
+	err = r.db.QueryRowContext(ctx, countQuery, groupID).Scan(&total)
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.GroupStudySessionsResponse{
-		Items: sessions,
-		Pagination: models.PaginationResponse{
-			CurrentPage:  offset/limit + 1,
-			TotalPages:   (total + limit - 1) / limit,
-			TotalItems:   total,
-			ItemsPerPage: limit,
-		},
+		Items:      sessions,
+		Pagination: models.NewPagination(limit, offset, total),
 	}, nil
 }
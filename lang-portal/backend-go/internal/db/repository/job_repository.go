@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+const jobColumns = `id, type, status, payload, progress, result, error, created_at, started_at, finished_at`
+
+// jobScanner is satisfied by both *sql.Row and *sql.Rows
+type jobScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanJob(row jobScanner) (*models.Job, error) {
+	var job models.Job
+	var payload string
+	var result, message sql.NullString
+	var startedAt, finishedAt sql.NullTime
+	err := row.Scan(
+		&job.ID,
+		&job.Type,
+		&job.Status,
+		&payload,
+		&job.Progress,
+		&result,
+		&message,
+		&job.CreatedAt,
+		&startedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.Payload = json.RawMessage(payload)
+	if result.Valid {
+		job.Result = json.RawMessage(result.String)
+	}
+	if message.Valid {
+		job.Error = &message.String
+	}
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+
+	return &job, nil
+}
+
+func (r *SQLRepository) CreateJob(ctx context.Context, jobType string, payload []byte) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx,
+		"INSERT INTO jobs (type, payload) VALUES (?, ?) RETURNING id",
+		jobType,
+		string(payload),
+	).Scan(&id)
+	return id, err
+}
+
+func (r *SQLRepository) GetJob(ctx context.Context, id int64) (*models.Job, error) {
+	job, err := scanJob(r.db.QueryRowContext(ctx, "SELECT "+jobColumns+" FROM jobs WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, models.NotFoundError("job")
+	}
+	return job, err
+}
+
+// GetJobs lists jobs newest first, optionally filtered by status. With a
+// cursor, the list continues after the job it points to.
+func (r *SQLRepository) GetJobs(ctx context.Context, status string, params models.ListParams) (*models.JobListResponse, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE (? = '' OR status = ?) AND (? = 0 OR id < ?)
+		ORDER BY id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	var after int64
+	if params.Cursor != nil {
+		after = params.Cursor.ID
+	}
+	// One job more than the page tells whether there is a next page
+	rows, err := r.db.QueryContext(ctx, query, status, status, after, after, params.Limit+1, params.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []models.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var total int
+	err = r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM jobs WHERE ? = '' OR status = ?", status, status).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := models.NewPagination(params.Limit, params.Offset, total)
+	if params.Cursor != nil {
+		pagination.CurrentPage = 0
+	}
+	if len(jobs) > params.Limit {
+		jobs = jobs[:params.Limit]
+		pagination.NextCursor = models.Cursor{ID: jobs[len(jobs)-1].ID}.Encode()
+	}
+
+	return &models.JobListResponse{
+		Items:      jobs,
+		Pagination: pagination,
+	}, nil
+}
+
+// ClaimNextJob marks the oldest pending job as running and returns it, or nil
+// when the queue is empty
+func (r *SQLRepository) ClaimNextJob(ctx context.Context) (*models.Job, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'running', started_at = CURRENT_TIMESTAMP
+		WHERE id = (SELECT id FROM jobs WHERE status = 'pending' ORDER BY id LIMIT 1` + r.db.dialect.skipLocked + `)
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, query))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return job, err
+}
+
+func (r *SQLRepository) UpdateJobProgress(ctx context.Context, id int64, progress int) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE jobs SET progress = ? WHERE id = ? AND status = 'running'", progress, id)
+	return err
+}
+
+// CompleteJob stores the result of a running job. Jobs cancelled while they
+// were running keep their cancelled status.
+func (r *SQLRepository) CompleteJob(ctx context.Context, id int64, result []byte) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = 'completed', progress = 100, result = ?, finished_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = 'running'
+	`, string(result), id)
+	return err
+}
+
+func (r *SQLRepository) FailJob(ctx context.Context, id int64, message string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = 'failed', error = ?, finished_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = 'running'
+	`, message, id)
+	return err
+}
+
+// CancelJob cancels a pending or running job, reporting whether it was still
+// cancellable
+func (r *SQLRepository) CancelJob(ctx context.Context, id int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = 'cancelled', finished_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status IN ('pending', 'running')
+	`, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// RequeueRunningJobs puts jobs that were running when the server stopped back
+// into the queue so they are resumed. They run again from the start; runners
+// reuse the words and group memberships they wrote before, so nothing is
+// written twice.
+func (r *SQLRepository) RequeueRunningJobs(ctx context.Context) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "UPDATE jobs SET status = 'pending', progress = 0, started_at = NULL WHERE status = 'running'")
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// CreateLearner stores the profile of the portal's single learner. There is
+// only ever one, so creating a second is a conflict.
+func (r *SQLRepository) CreateLearner(ctx context.Context, learner *models.Learner) error {
+	result, err := r.db.ExecContext(ctx,
+		"INSERT INTO learner (id, name, email) VALUES (1, ?, ?) ON CONFLICT (id) DO NOTHING",
+		learner.Name,
+		learner.Email,
+	)
+	if err != nil {
+		return err
+	}
+	created, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if created == 0 {
+		return models.ConflictError("learner_exists", "the learner has already been created")
+	}
+	return nil
+}
+
+func (r *SQLRepository) GetLearner(ctx context.Context) (*models.Learner, error) {
+	return scanLearner(r.db.QueryRowContext(ctx, learnerQuery))
+}
+
+const learnerQuery = "SELECT name, email, created_at FROM learner WHERE id = 1"
+
+func scanLearner(row *sql.Row) (*models.Learner, error) {
+	var learner models.Learner
+	var email sql.NullString
+	err := row.Scan(&learner.Name, &email, &learner.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFoundError("learner")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if email.Valid {
+		learner.Email = &email.String
+	}
+	return &learner, nil
+}
@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// CreateListeningExercise stores an exercise with its transcript and
+// questions. An exercise with the same content hash is not stored again;
+// its ID is returned with created set to false.
+func (r *SQLRepository) CreateListeningExercise(ctx context.Context, exercise *models.ListeningExercise, contentHash string, segments []models.ListeningSegment, questions []models.ListeningQuestion) (int64, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+
+	var existingID int64
+	err = tx.QueryRowContext(ctx, "SELECT id FROM listening_exercises WHERE content_hash = ?", contentHash).Scan(&existingID)
+	if err == nil {
+		return existingID, false, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, false, err
+	}
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO listening_exercises (course_id, title, level, topic, context, source_url, start_ms, end_ms, content_hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id`,
+		exercise.CourseID,
+		exercise.Title,
+		exercise.Level,
+		exercise.Topic,
+		exercise.Context,
+		exercise.SourceURL,
+		exercise.StartMs,
+		exercise.EndMs,
+		contentHash,
+	).Scan(&id)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, segment := range segments {
+		keyPhrases, err := json.Marshal(segment.KeyPhrases)
+		if err != nil {
+			return 0, false, err
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO listening_segments (exercise_id, position, speaker, text, translation, start_ms, key_phrases)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			id,
+			segment.Position,
+			segment.Speaker,
+			segment.Text,
+			segment.Translation,
+			segment.StartMs,
+			string(keyPhrases),
+		)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
+	for _, question := range questions {
+		options, err := json.Marshal(question.Options)
+		if err != nil {
+			return 0, false, err
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO listening_questions (exercise_id, position, question, options, correct_option, start_ms)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			id,
+			question.Position,
+			question.Question,
+			string(options),
+			question.CorrectOption,
+			question.StartMs,
+		)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
+	return id, true, tx.Commit()
+}
+
+const listeningExerciseQuery = `
+	SELECT
+		e.id, e.course_id, e.title, COALESCE(e.level, ''), COALESCE(e.topic, ''), COALESCE(e.context, ''),
+		e.source_url, e.start_ms, e.end_ms, e.created_at,
+		(SELECT COUNT(*) FROM listening_segments s WHERE s.exercise_id = e.id),
+		(SELECT COUNT(*) FROM listening_questions q WHERE q.exercise_id = e.id)
+	FROM listening_exercises e
+`
+
+func scanListeningExercise(row rowScanner) (*models.ListeningExercise, error) {
+	var exercise models.ListeningExercise
+	var sourceURL sql.NullString
+	var startMs, endMs sql.NullInt64
+	err := row.Scan(
+		&exercise.ID,
+		&exercise.CourseID,
+		&exercise.Title,
+		&exercise.Level,
+		&exercise.Topic,
+		&exercise.Context,
+		&sourceURL,
+		&startMs,
+		&endMs,
+		&exercise.CreatedAt,
+		&exercise.SegmentCount,
+		&exercise.QuestionCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceURL.Valid {
+		exercise.SourceURL = &sourceURL.String
+	}
+	exercise.StartMs = nullableInt64(startMs)
+	exercise.EndMs = nullableInt64(endMs)
+	return &exercise, nil
+}
+
+func nullableInt64(value sql.NullInt64) *int64 {
+	if !value.Valid {
+		return nil
+	}
+	v := value.Int64
+	return &v
+}
+
+// GetListeningExercises lists exercises, newest first, only those of one
+// course when courseID is not 0
+func (r *SQLRepository) GetListeningExercises(ctx context.Context, courseID int64, limit, offset int) (*models.ListeningExerciseListResponse, error) {
+	rows, err := r.db.QueryContext(ctx,
+		listeningExerciseQuery+" WHERE ? = 0 OR e.course_id = ? ORDER BY e.id DESC LIMIT ? OFFSET ?",
+		courseID, courseID, limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exercises := []models.ListeningExercise{}
+	for rows.Next() {
+		exercise, err := scanListeningExercise(rows)
+		if err != nil {
+			return nil, err
+		}
+		exercises = append(exercises, *exercise)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var total int
+	err = r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM listening_exercises WHERE ? = 0 OR course_id = ?", courseID, courseID).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ListeningExerciseListResponse{
+		Items:      exercises,
+		Pagination: models.NewPagination(limit, offset, total),
+	}, nil
+}
+
+func (r *SQLRepository) GetListeningExercise(ctx context.Context, id int64) (*models.ListeningExercise, error) {
+	exercise, err := scanListeningExercise(r.db.QueryRowContext(ctx, listeningExerciseQuery+" WHERE e.id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, models.NotFoundError("listening exercise")
+	}
+	return exercise, err
+}
+
+func (r *SQLRepository) GetListeningSegments(ctx context.Context, exerciseID int64) ([]models.ListeningSegment, error) {
+	query := `
+		SELECT id, position, COALESCE(speaker, ''), text, COALESCE(translation, ''), start_ms, key_phrases
+		FROM listening_segments
+		WHERE exercise_id = ?
+		ORDER BY position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	segments := []models.ListeningSegment{}
+	for rows.Next() {
+		var segment models.ListeningSegment
+		var startMs sql.NullInt64
+		var keyPhrases string
+		err := rows.Scan(
+			&segment.ID,
+			&segment.Position,
+			&segment.Speaker,
+			&segment.Text,
+			&segment.Translation,
+			&startMs,
+			&keyPhrases,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(keyPhrases), &segment.KeyPhrases); err != nil {
+			return nil, err
+		}
+		segment.StartMs = nullableInt64(startMs)
+		segments = append(segments, segment)
+	}
+
+	return segments, rows.Err()
+}
+
+const listeningQuestionQuery = `
+	SELECT id, exercise_id, position, question, options, correct_option, start_ms
+	FROM listening_questions
+`
+
+func scanListeningQuestion(row rowScanner) (*models.ListeningQuestion, error) {
+	var question models.ListeningQuestion
+	var options string
+	var startMs sql.NullInt64
+	err := row.Scan(
+		&question.ID,
+		&question.ExerciseID,
+		&question.Position,
+		&question.Question,
+		&options,
+		&question.CorrectOption,
+		&startMs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(options), &question.Options); err != nil {
+		return nil, err
+	}
+	question.StartMs = nullableInt64(startMs)
+	return &question, nil
+}
+
+func (r *SQLRepository) GetListeningQuestions(ctx context.Context, exerciseID int64) ([]models.ListeningQuestion, error) {
+	rows, err := r.db.QueryContext(ctx, listeningQuestionQuery+" WHERE exercise_id = ? ORDER BY position", exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions := []models.ListeningQuestion{}
+	for rows.Next() {
+		question, err := scanListeningQuestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, *question)
+	}
+
+	return questions, rows.Err()
+}
+
+func (r *SQLRepository) GetListeningQuestion(ctx context.Context, id int64) (*models.ListeningQuestion, error) {
+	question, err := scanListeningQuestion(r.db.QueryRowContext(ctx, listeningQuestionQuery+" WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, models.NotFoundError("listening question")
+	}
+	return question, err
+}
+
+func (r *SQLRepository) CreateListeningAnswer(ctx context.Context, questionID, sessionID int64, selectedOption int, correct bool) error {
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO listening_answers (question_id, study_session_id, selected_option, correct) VALUES (?, ?, ?, ?)",
+		questionID,
+		sessionID,
+		selectedOption,
+		correct,
+	)
+	return err
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// GetLLMCacheEntry returns the cached completion for key, or nil when there is
+// none or it has expired
+func (r *SQLRepository) GetLLMCacheEntry(ctx context.Context, key string) (*models.LLMCacheEntry, error) {
+	query := `
+		SELECT cache_key, provider, model, response, hits, created_at, expires_at
+		FROM llm_cache
+		WHERE cache_key = ? AND expires_at > CURRENT_TIMESTAMP
+	`
+
+	var entry models.LLMCacheEntry
+	err := r.db.QueryRowContext(ctx, query, key).Scan(
+		&entry.Key,
+		&entry.Provider,
+		&entry.Model,
+		&entry.Response,
+		&entry.Hits,
+		&entry.CreatedAt,
+		&entry.ExpiresAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// SaveLLMCacheEntry stores a completion that expires after ttl, replacing any
+// previous completion for the same key
+func (r *SQLRepository) SaveLLMCacheEntry(ctx context.Context, entry *models.LLMCacheEntry, ttl time.Duration) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO llm_cache (cache_key, provider, model, response, expires_at)
+		VALUES (?, ?, ?, ?, `+r.db.dialect.nowPlus+`)
+		ON CONFLICT(cache_key) DO UPDATE SET
+			provider = excluded.provider,
+			model = excluded.model,
+			response = excluded.response,
+			hits = 0,
+			created_at = CURRENT_TIMESTAMP,
+			expires_at = excluded.expires_at
+	`, entry.Key, entry.Provider, entry.Model, entry.Response, fmt.Sprintf("%+d seconds", int64(ttl.Seconds())))
+	return err
+}
+
+func (r *SQLRepository) RecordLLMCacheHit(ctx context.Context, key string) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE llm_cache SET hits = hits + 1 WHERE cache_key = ?", key)
+	return err
+}
+
+// GetLLMCacheStats counts the unexpired cache entries and the hits recorded
+// against them
+func (r *SQLRepository) GetLLMCacheStats(ctx context.Context) (entries int, storedHits int64, err error) {
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(hits), 0)
+		FROM llm_cache
+		WHERE expires_at > CURRENT_TIMESTAMP
+	`).Scan(&entries, &storedHits)
+	return entries, storedHits, err
+}
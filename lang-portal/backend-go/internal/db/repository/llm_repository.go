@@ -1,19 +1,20 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 )
 
-func (r *SQLiteRepository) BeginTx() (*sql.Tx, error) {
-	return r.db.Begin()
+func (r *SQLRepository) BeginTx(ctx context.Context) (*Tx, error) {
+	return r.db.BeginTx(ctx, nil)
 }
 
-func (r *SQLiteRepository) GetGroupIDByName(name string) (int64, error) {
+func (r *SQLRepository) GetGroupIDByName(ctx context.Context, name string) (int64, error) {
 	var id int64
-	err := r.db.QueryRow("SELECT id FROM groups WHERE name = ?", name).Scan(&id)
+	err := r.db.QueryRowContext(ctx, "SELECT id FROM groups WHERE name = ?", name).Scan(&id)
 	if err == sql.ErrNoRows {
 		return 0, nil
 	}
@@ -23,35 +24,63 @@ func (r *SQLiteRepository) GetGroupIDByName(name string) (int64, error) {
 	return id, nil
 }
 
-func (r *SQLiteRepository) CreateGroup(name string) (int64, error) {
-	result, err := r.db.Exec("INSERT INTO groups (name) VALUES (?)", name)
-	if err != nil {
-		return 0, err
-	}
-	return result.LastInsertId()
+func (r *SQLRepository) CreateGroup(ctx context.Context, name string) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx, "INSERT INTO groups (name) VALUES (?) RETURNING id", name).Scan(&id)
+	return id, err
 }
 
-func (r *SQLiteRepository) CreateWord(word *models.WordResponse) (int64, error) {
+func (r *SQLRepository) CreateWord(ctx context.Context, word *models.WordResponse) (int64, error) {
 	partsJSON, err := json.Marshal(word.Parts)
 	if err != nil {
 		return 0, err
 	}
 
-	result, err := r.db.Exec(
-		"INSERT INTO words (italian, english, parts) VALUES (?, ?, ?)",
-		word.Italian,
-		word.English,
-		partsJSON,
-	)
-	if err != nil {
-		return 0, err
-	}
+	var id int64
+	err = r.db.QueryRowContext(ctx,
+		"INSERT INTO words (term, translation, parts, prompt_version, course_id) VALUES (?, ?, ?, ?, ?) RETURNING id",
+		word.Term,
+		word.Translation,
+		string(partsJSON),
+		word.PromptVersion,
+		word.CourseID,
+	).Scan(&id)
+	return id, err
+}
 
-	return result.LastInsertId()
+// FindOrCreateWord returns the ID of the course's word with the same term
+// and translation, creating the word when there is none, so that writing a
+// list of words again, e.g. when a job is resumed, does not duplicate them
+func (r *SQLRepository) FindOrCreateWord(ctx context.Context, word *models.WordResponse) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx,
+		"SELECT id FROM words WHERE course_id = ? AND term = ? AND translation = ? ORDER BY id LIMIT 1",
+		word.CourseID,
+		word.Term,
+		word.Translation,
+	).Scan(&id)
+	if err == sql.ErrNoRows {
+		return r.CreateWord(ctx, word)
+	}
+	return id, err
 }
 
-func (r *SQLiteRepository) AddWordToGroup(wordID, groupID int64) error {
-	_, err := r.db.Exec(
+// AddWordToGroup adds a word to a group, unless it is in the group already
+func (r *SQLRepository) AddWordToGroup(ctx context.Context, wordID, groupID int64) error {
+	var exists int
+	err := r.db.QueryRowContext(ctx,
+		"SELECT 1 FROM words_groups WHERE word_id = ? AND group_id = ?",
+		wordID,
+		groupID,
+	).Scan(&exists)
+	if err == nil {
+		return nil
+	}
+	if err != sql.ErrNoRows {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx,
 		"INSERT INTO words_groups (word_id, group_id) VALUES (?, ?)",
 		wordID,
 		groupID,
@@ -59,8 +88,8 @@ func (r *SQLiteRepository) AddWordToGroup(wordID, groupID int64) error {
 	return err
 }
 
-func (r *SQLiteRepository) UpdateGroupWordsCount(groupID int64) error {
-	_, err := r.db.Exec(`
+func (r *SQLRepository) UpdateGroupWordsCount(ctx context.Context, groupID int64) error {
+	_, err := r.db.ExecContext(ctx, `
 		UPDATE groups 
 		SET words_count = (
 			SELECT COUNT(*) 
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+func (r *SQLRepository) RecordLLMUsage(ctx context.Context, record *models.LLMUsageRecord) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO llm_usage (
+			provider, model, endpoint, prompt_tokens, completion_tokens, total_tokens,
+			latency_ms, status, error, cost_usd
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		record.Provider,
+		record.Model,
+		record.Endpoint,
+		record.PromptTokens,
+		record.CompletionTokens,
+		record.TotalTokens,
+		record.LatencyMs,
+		record.Status,
+		record.Error,
+		record.CostUSD,
+	)
+	return err
+}
+
+// GetLLMUsageSummary aggregates the ledger over the last days, grouped by
+// "day" or "endpoint"
+func (r *SQLRepository) GetLLMUsageSummary(ctx context.Context, groupBy string, days int) ([]models.LLMUsageSummaryItem, error) {
+	var key, order string
+	switch groupBy {
+	case "day":
+		key, order = r.db.dialect.day("created_at"), "key DESC"
+	case "endpoint":
+		key, order = "endpoint", "cost_usd DESC, key"
+	default:
+		return nil, fmt.Errorf("unsupported usage grouping %q", groupBy)
+	}
+
+	query := `
+		SELECT
+			` + key + ` AS key,
+			COUNT(*),
+			SUM(CASE WHEN status = 'success' THEN 0 ELSE 1 END),
+			SUM(prompt_tokens),
+			SUM(completion_tokens),
+			SUM(total_tokens),
+			SUM(cost_usd) AS cost_usd,
+			AVG(latency_ms)
+		FROM llm_usage
+		WHERE created_at >= ` + r.db.dialect.nowPlus + `
+		GROUP BY key
+		ORDER BY ` + order
+
+	rows, err := r.db.QueryContext(ctx, query, fmt.Sprintf("-%d days", days))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.LLMUsageSummaryItem{}
+	for rows.Next() {
+		var item models.LLMUsageSummaryItem
+		err := rows.Scan(
+			&item.Key,
+			&item.Calls,
+			&item.Errors,
+			&item.PromptTokens,
+			&item.CompletionTokens,
+			&item.TotalTokens,
+			&item.CostUSD,
+			&item.AvgLatencyMs,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// GetLLMMonthlyUsage totals the cost and tokens of the calls made since the
+// start of the current (UTC) month
+func (r *SQLRepository) GetLLMMonthlyUsage(ctx context.Context) (costUSD float64, tokens int64, err error) {
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(cost_usd), 0), COALESCE(SUM(total_tokens), 0)
+		FROM llm_usage
+		WHERE created_at >= `+r.db.dialect.startOfMonth+`
+	`).Scan(&costUSD, &tokens)
+	return costUSD, tokens, err
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/pressly/goose/v3"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/migrations"
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/migrations/postgres"
+)
+
+// MigrationStatus is a schema migration and whether it is applied
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// migrationProvider returns a goose provider of the migrations of the
+// database's dialect. Versions are recorded in goose_db_version, as the
+// goose CLI records them, so the two can be used on the same database.
+func (r *SQLRepository) migrationProvider() (*goose.Provider, error) {
+	if r.db.dialect == postgresDialect {
+		return goose.NewProvider(goose.DialectPostgres, r.db.DB, postgres.FS)
+	}
+	return goose.NewProvider(goose.DialectSQLite3, r.db.DB, migrations.FS)
+}
+
+// MigrateUp applies the migrations that are not applied yet, and returns them
+func (r *SQLRepository) MigrateUp(ctx context.Context) ([]MigrationStatus, error) {
+	provider, err := r.migrationProvider()
+	if err != nil {
+		return nil, err
+	}
+	results, err := provider.Up(ctx)
+	applied := make([]MigrationStatus, 0, len(results))
+	for _, result := range results {
+		if result.Error == nil {
+			applied = append(applied, migrationStatus(result.Source, true, time.Time{}))
+		}
+	}
+	return applied, err
+}
+
+// MigrateDown rolls back the latest applied migration and returns it, or nil
+// when none is applied
+func (r *SQLRepository) MigrateDown(ctx context.Context) (*MigrationStatus, error) {
+	provider, err := r.migrationProvider()
+	if err != nil {
+		return nil, err
+	}
+	version, err := provider.GetDBVersion(ctx)
+	if err != nil || version == 0 {
+		return nil, err
+	}
+	result, err := provider.Down(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := migrationStatus(result.Source, false, time.Time{})
+	return &status, nil
+}
+
+// MigrationStatuses returns every migration in order, with whether it is
+// applied
+func (r *SQLRepository) MigrationStatuses(ctx context.Context) ([]MigrationStatus, error) {
+	provider, err := r.migrationProvider()
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]MigrationStatus, len(statuses))
+	for i, status := range statuses {
+		result[i] = migrationStatus(status.Source, status.State == goose.StateApplied, status.AppliedAt)
+	}
+	return result, nil
+}
+
+func migrationStatus(source *goose.Source, applied bool, appliedAt time.Time) MigrationStatus {
+	return MigrationStatus{
+		Version:   source.Version,
+		Name:      path.Base(source.Path),
+		Applied:   applied,
+		AppliedAt: appliedAt,
+	}
+}
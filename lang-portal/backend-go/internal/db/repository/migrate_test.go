@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewDB(filepath.Join(t.TempDir(), "migrate.db"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	statuses, err := repo.MigrationStatuses(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, statuses)
+	for _, status := range statuses {
+		assert.False(t, status.Applied, status.Name)
+	}
+
+	applied, err := repo.MigrateUp(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(statuses))
+	assert.Equal(t, "001_initial_schema.sql", applied[0].Name)
+
+	// The migrated schema serves the repository
+	_, err = repo.GetTableVersion(ctx, "words")
+	require.NoError(t, err)
+
+	// Writes leave the data version, now only a lock, as it is
+	var before, after int64
+	require.NoError(t, repo.db.QueryRowContext(ctx, "SELECT version FROM data_version").Scan(&before))
+	_, err = repo.CreateGroup(ctx, "Family")
+	require.NoError(t, err)
+	require.NoError(t, repo.db.QueryRowContext(ctx, "SELECT version FROM data_version").Scan(&after))
+	assert.Equal(t, before, after)
+
+	latest := statuses[len(statuses)-1]
+	rolledBack, err := repo.MigrateDown(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, rolledBack)
+	assert.Equal(t, latest.Version, rolledBack.Version)
+
+	statuses, err = repo.MigrationStatuses(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[len(statuses)-1].Applied)
+
+	applied, err = repo.MigrateUp(ctx)
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	assert.Equal(t, latest.Name, applied[0].Name)
+
+	applied, err = repo.MigrateUp(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// orderBy builds the ORDER BY clause of a sortable list. columns maps the
+// sort fields of the API to SQL expressions; idColumn breaks ties so that
+// pages do not overlap. Lists without a sort field are ordered by ID.
+func orderBy(params models.ListParams, columns map[string]string, idColumn string) string {
+	direction := " ASC"
+	if params.Desc {
+		direction = " DESC"
+	}
+
+	column, ok := columns[params.Sort]
+	if !ok || column == idColumn {
+		return " ORDER BY " + idColumn + direction
+	}
+	return " ORDER BY " + column + direction + ", " + idColumn + direction
+}
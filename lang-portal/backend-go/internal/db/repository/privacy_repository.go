@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// GetLearnerData reads the learner's profile, if any, and study history from
+// one snapshot, so the parts of it refer to each other even while the learner
+// keeps studying
+func (r *SQLRepository) GetLearnerData(ctx context.Context) (*models.LearnerData, error) {
+	tx, err := r.db.BeginReadTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	data := &models.LearnerData{}
+	data.Learner, err = scanLearner(tx.QueryRowContext(ctx, learnerQuery))
+	if err != nil && !errors.Is(err, models.ErrNotFound) {
+		return nil, err
+	}
+	if data.StudySessions, err = getExportedStudySessions(ctx, tx); err != nil {
+		return nil, err
+	}
+	if data.Reviews, err = getExportedReviews(ctx, tx); err != nil {
+		return nil, err
+	}
+	if data.ListeningAnswers, err = getExportedListeningAnswers(ctx, tx); err != nil {
+		return nil, err
+	}
+	if data.TutorConversations, err = getExportedTutorConversations(ctx, tx); err != nil {
+		return nil, err
+	}
+	return data, tx.Commit()
+}
+
+func getExportedStudySessions(ctx context.Context, tx *Tx) ([]models.ExportedStudySession, error) {
+	query := `
+		SELECT s.id, s.group_id, g.name, s.study_activity_id, a.name, s.client_id, s.created_at
+		FROM study_sessions s
+		JOIN groups g ON g.id = s.group_id
+		JOIN study_activities a ON a.id = s.study_activity_id
+		ORDER BY s.id
+	`
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.ExportedStudySession{}
+	for rows.Next() {
+		var session models.ExportedStudySession
+		var clientID sql.NullString
+		err := rows.Scan(
+			&session.ID,
+			&session.GroupID,
+			&session.GroupName,
+			&session.StudyActivityID,
+			&session.ActivityName,
+			&clientID,
+			&session.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if clientID.Valid {
+			session.ClientID = &clientID.String
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+func getExportedReviews(ctx context.Context, tx *Tx) ([]models.ExportedReview, error) {
+	query := `
+		SELECT r.id, r.study_session_id, r.word_id, w.term, w.translation, r.correct, r.review_mode, r.client_id, r.created_at
+		FROM word_review_items r
+		JOIN words w ON w.id = r.word_id
+		ORDER BY r.id
+	`
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []models.ExportedReview{}
+	for rows.Next() {
+		var review models.ExportedReview
+		var clientID sql.NullString
+		err := rows.Scan(
+			&review.ID,
+			&review.StudySessionID,
+			&review.WordID,
+			&review.Term,
+			&review.Translation,
+			&review.Correct,
+			&review.ReviewMode,
+			&clientID,
+			&review.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if clientID.Valid {
+			review.ClientID = &clientID.String
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, rows.Err()
+}
+
+func getExportedListeningAnswers(ctx context.Context, tx *Tx) ([]models.ExportedListeningAnswer, error) {
+	query := `
+		SELECT a.id, a.study_session_id, q.exercise_id, a.question_id, q.question, a.selected_option, a.correct, a.created_at
+		FROM listening_answers a
+		JOIN listening_questions q ON q.id = a.question_id
+		ORDER BY a.id
+	`
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	answers := []models.ExportedListeningAnswer{}
+	for rows.Next() {
+		var answer models.ExportedListeningAnswer
+		err := rows.Scan(
+			&answer.ID,
+			&answer.StudySessionID,
+			&answer.ExerciseID,
+			&answer.QuestionID,
+			&answer.Question,
+			&answer.SelectedOption,
+			&answer.Correct,
+			&answer.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, answer)
+	}
+	return answers, rows.Err()
+}
+
+// getExportedTutorConversations reads the conversations with their messages
+// and vocabulary, in three queries whatever the number of conversations
+func getExportedTutorConversations(ctx context.Context, tx *Tx) ([]models.ExportedTutorConversation, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT id, course_id, group_id, persona, created_at, updated_at FROM tutor_conversations ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conversations := []models.ExportedTutorConversation{}
+	index := map[int64]int{}
+	for rows.Next() {
+		conversation := models.ExportedTutorConversation{
+			Messages:   []models.TutorMessage{},
+			Vocabulary: []models.TutorVocabularyEntry{},
+		}
+		var groupID sql.NullInt64
+		err := rows.Scan(
+			&conversation.ID,
+			&conversation.CourseID,
+			&groupID,
+			&conversation.Persona,
+			&conversation.CreatedAt,
+			&conversation.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if groupID.Valid {
+			conversation.GroupID = &groupID.Int64
+		}
+		index[conversation.ID] = len(conversations)
+		conversations = append(conversations, conversation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// The system prompt is the portal's, not the learner's
+	messages, err := tx.QueryContext(ctx, `
+		SELECT conversation_id, id, role, content, created_at
+		FROM tutor_messages
+		WHERE role <> 'system'
+		ORDER BY conversation_id, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer messages.Close()
+	for messages.Next() {
+		var conversationID int64
+		var message models.TutorMessage
+		if err := messages.Scan(&conversationID, &message.ID, &message.Role, &message.Content, &message.CreatedAt); err != nil {
+			return nil, err
+		}
+		conversation := &conversations[index[conversationID]]
+		conversation.Messages = append(conversation.Messages, message)
+	}
+	if err := messages.Err(); err != nil {
+		return nil, err
+	}
+
+	vocabulary, err := tx.QueryContext(ctx, `
+		SELECT conversation_id, word_id, term, translation, part_of_speech, pronunciation
+		FROM tutor_vocabulary
+		ORDER BY conversation_id, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer vocabulary.Close()
+	for vocabulary.Next() {
+		var conversationID int64
+		var entry models.TutorVocabularyEntry
+		var wordID sql.NullInt64
+		var partOfSpeech, pronunciation sql.NullString
+		if err := vocabulary.Scan(&conversationID, &wordID, &entry.Term, &entry.Translation, &partOfSpeech, &pronunciation); err != nil {
+			return nil, err
+		}
+		if wordID.Valid {
+			entry.WordID = &wordID.Int64
+		}
+		entry.PartOfSpeech = partOfSpeech.String
+		entry.Pronunciation = pronunciation.String
+		conversation := &conversations[index[conversationID]]
+		conversation.Vocabulary = append(conversation.Vocabulary, entry)
+	}
+	return conversations, vocabulary.Err()
+}
+
+// EraseLearnerData deletes the learner's profile and study history in one
+// transaction: sessions, reviews, listening answers and tutor conversations, and the
+// review counts of words. Vocabulary, groups and content stay, as do the
+// deletions in the change log, which offline clients need to drop their
+// copies; the earlier entries, which tell when the learner studied, go.
+func (r *SQLRepository) EraseLearnerData(ctx context.Context) (*models.EraseLearnerDataResponse, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	erased := &models.EraseLearnerDataResponse{}
+	steps := []struct {
+		query string
+		count *int64
+	}{
+		{"DELETE FROM learner", &erased.Learner},
+		{"DELETE FROM word_review_items", &erased.Reviews},
+		{"DELETE FROM listening_answers", &erased.ListeningAnswers},
+		{"DELETE FROM study_sessions", &erased.StudySessions},
+		{"DELETE FROM tutor_vocabulary", nil},
+		{"DELETE FROM tutor_messages", nil},
+		{"DELETE FROM tutor_conversations", &erased.TutorConversations},
+		// Only words with counts, so the others are not logged as changed
+		{"UPDATE words SET correct_count = 0, wrong_count = 0 WHERE correct_count <> 0 OR wrong_count <> 0", nil},
+		{"DELETE FROM change_log WHERE entity IN ('study_session', 'review') AND op <> 'delete'", nil},
+	}
+	for _, step := range steps {
+		result, err := tx.ExecContext(ctx, step.query)
+		if err != nil {
+			return nil, err
+		}
+		if step.count != nil {
+			if *step.count, err = result.RowsAffected(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return erased, tx.Commit()
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+func (r *SQLRepository) GetWordSentences(ctx context.Context, wordID int64, limit, offset int) (*models.WordSentencesResponse, error) {
+	query := `
+		SELECT s.id, s.text, s.translation, s.source, s.created_at
+		FROM sentences s
+		JOIN words_sentences ws ON s.id = ws.sentence_id
+		WHERE ws.word_id = ?
+		ORDER BY s.id
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, wordID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sentences := []models.SentenceResponse{}
+	for rows.Next() {
+		var sentence models.SentenceResponse
+		err := rows.Scan(
+			&sentence.ID,
+			&sentence.Text,
+			&sentence.Translation,
+			&sentence.Source,
+			&sentence.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		sentences = append(sentences, sentence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Get total count
+	countQuery := "SELECT COUNT(*) FROM words_sentences WHERE word_id = ?"
+	var total int
+	err = r.db.QueryRowContext(ctx, countQuery, wordID).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WordSentencesResponse{
+		Items:      sentences,
+		Pagination: models.NewPagination(limit, offset, total),
+	}, nil
+}
+
+func (r *SQLRepository) CreateSentence(ctx context.Context, sentence *models.SentenceResponse) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx,
+		"INSERT INTO sentences (text, translation, source) VALUES (?, ?, ?) RETURNING id",
+		sentence.Text,
+		sentence.Translation,
+		sentence.Source,
+	).Scan(&id)
+	return id, err
+}
+
+func (r *SQLRepository) AddSentenceToWord(ctx context.Context, sentenceID, wordID int64) error {
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO words_sentences (word_id, sentence_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+		wordID,
+		sentenceID,
+	)
+	return err
+}
+
+// GetWordSentence returns a sentence only if it is linked to the given word
+func (r *SQLRepository) GetWordSentence(ctx context.Context, wordID, sentenceID int64) (*models.SentenceResponse, error) {
+	query := `
+		SELECT s.id, s.text, s.translation, s.source, s.created_at
+		FROM sentences s
+		JOIN words_sentences ws ON s.id = ws.sentence_id
+		WHERE ws.word_id = ? AND s.id = ?
+	`
+
+	var sentence models.SentenceResponse
+	err := r.db.QueryRowContext(ctx, query, wordID, sentenceID).Scan(
+		&sentence.ID,
+		&sentence.Text,
+		&sentence.Translation,
+		&sentence.Source,
+		&sentence.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFoundError("sentence")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &sentence, nil
+}
@@ -1,35 +1,36 @@
 package repository
 
-func (r *SQLiteRepository) ResetHistory() error {
-	tx, err := r.db.Begin()
+import (
+	"context"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/migrations/postgres"
+)
+
+func (r *SQLRepository) ResetHistory(ctx context.Context) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
 	// Delete all study sessions and word reviews
-	_, err = tx.Exec("DELETE FROM word_review_items")
+	_, err = tx.ExecContext(ctx, "DELETE FROM word_review_items")
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec("DELETE FROM study_sessions")
+	_, err = tx.ExecContext(ctx, "DELETE FROM listening_answers")
 	if err != nil {
 		return err
 	}
 
-	// Try to reset word statistics if columns exist
-	_, err = tx.Exec(`
-		UPDATE words 
-		SET correct_count = CASE 
-			WHEN (SELECT COUNT(*) FROM pragma_table_info('words') WHERE name='correct_count') > 0 THEN 0 
-			ELSE correct_count 
-			END,
-			wrong_count = CASE 
-			WHEN (SELECT COUNT(*) FROM pragma_table_info('words') WHERE name='wrong_count') > 0 THEN 0 
-			ELSE wrong_count 
-			END
-	`)
+	_, err = tx.ExecContext(ctx, "DELETE FROM study_sessions")
+	if err != nil {
+		return err
+	}
+
+	// Reset word statistics
+	_, err = tx.ExecContext(ctx, "UPDATE words SET correct_count = 0, wrong_count = 0")
 	if err != nil {
 		return err
 	}
@@ -37,26 +38,61 @@ func (r *SQLiteRepository) ResetHistory() error {
 	return tx.Commit()
 }
 
-func (r *SQLiteRepository) DropAllTables() error {
-	tx, err := r.db.Begin()
+func (r *SQLRepository) DropAllTables(ctx context.Context) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	// Views are dropped before the tables they select from
+	if _, err := tx.ExecContext(ctx, `DROP VIEW IF EXISTS italian_words`); err != nil {
+		return err
+	}
+
 	// List of tables to drop
 	tables := []string{
+		"learner",
+		"content_pack_words_groups",
+		"content_pack_groups",
+		"content_pack_words",
+		"content_packs",
+		"seed_packs",
+		"sync_state",
+		"change_log",
+		"group_versions",
+		"table_versions",
+		"data_version",
+		"listening_answers",
+		"listening_questions",
+		"listening_segments",
+		"listening_exercises",
+		"llm_usage",
+		"jobs",
+		"llm_cache",
+		"tutor_vocabulary",
+		"tutor_messages",
+		"tutor_conversations",
+		"words_sentences",
+		"sentences",
 		"word_review_items",
 		"study_sessions",
 		"words_groups",
 		"study_activities",
 		"groups",
 		"words",
+		"courses",
+		"languages",
 	}
 
-	// Drop each table if it exists
+	// Drop each table if it exists. PostgreSQL also drops what depends on
+	// it, such as views created outside the migrations.
+	cascade := ""
+	if r.db.dialect == postgresDialect {
+		cascade = " CASCADE"
+	}
 	for _, table := range tables {
-		_, err := tx.Exec(`DROP TABLE IF EXISTS ` + table)
+		_, err := tx.ExecContext(ctx, `DROP TABLE IF EXISTS `+table+cascade)
 		if err != nil {
 			return err
 		}
@@ -65,28 +101,67 @@ func (r *SQLiteRepository) DropAllTables() error {
 	return tx.Commit()
 }
 
-func (r *SQLiteRepository) CreateTables() error {
+func (r *SQLRepository) CreateTables(ctx context.Context) error {
 	// Read the schema file
-	schema, err := r.readSchemaFile()
+	schema, err := r.readSchemaFile(ctx)
 	if err != nil {
 		return err
 	}
 
 	// Execute the schema
-	_, err = r.db.Exec(schema)
+	_, err = r.db.ExecContext(ctx, schema)
 	return err
 }
 
-func (r *SQLiteRepository) readSchemaFile() (string, error) {
+func (r *SQLRepository) readSchemaFile(ctx context.Context) (string, error) {
+	if r.db.dialect == postgresDialect {
+		return postgres.Schema()
+	}
 	return `
+CREATE TABLE IF NOT EXISTS languages (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    script TEXT NOT NULL CHECK (script IN ('latin', 'cyrillic', 'greek', 'arabic', 'han', 'hangul', 'japanese'))
+);
+
+INSERT OR IGNORE INTO languages (code, name, script) VALUES
+    ('en', 'English', 'latin'),
+    ('it', 'Italian', 'latin'),
+    ('es', 'Spanish', 'latin'),
+    ('ja', 'Japanese', 'japanese');
+
+CREATE TABLE IF NOT EXISTS courses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    source_language TEXT NOT NULL,
+    target_language TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (source_language) REFERENCES languages(code),
+    FOREIGN KEY (target_language) REFERENCES languages(code),
+    UNIQUE (source_language, target_language),
+    CHECK (source_language <> target_language)
+);
+
+INSERT OR IGNORE INTO courses (id, name, source_language, target_language) VALUES (1, 'Italian for English speakers', 'en', 'it');
+
 CREATE TABLE IF NOT EXISTS words (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
-    italian TEXT NOT NULL,
-    english TEXT NOT NULL,
+    term TEXT NOT NULL,
+    translation TEXT NOT NULL,
     parts JSON,
+    prompt_version TEXT,
+    course_id INTEGER NOT NULL DEFAULT 1,
+    correct_count INTEGER DEFAULT 0,
+    wrong_count INTEGER DEFAULT 0,
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE VIEW IF NOT EXISTS italian_words AS
+SELECT w.id, w.term AS italian, w.translation AS english, w.parts, w.prompt_version, w.created_at
+FROM words w
+JOIN courses c ON c.id = w.course_id
+WHERE c.source_language = 'en' AND c.target_language = 'it';
+
 CREATE TABLE IF NOT EXISTS groups (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     name TEXT NOT NULL,
@@ -108,6 +183,7 @@ CREATE TABLE IF NOT EXISTS study_activities (
     name TEXT NOT NULL,
     thumbnail_url TEXT,
     description TEXT,
+    launch_url TEXT,
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
 
@@ -116,6 +192,7 @@ CREATE TABLE IF NOT EXISTS study_sessions (
     group_id INTEGER NOT NULL,
     study_activity_id INTEGER NOT NULL,
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    client_id TEXT,
     FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE,
     FOREIGN KEY (study_activity_id) REFERENCES study_activities(id) ON DELETE CASCADE
 );
@@ -125,16 +202,461 @@ CREATE TABLE IF NOT EXISTS word_review_items (
     word_id INTEGER NOT NULL,
     study_session_id INTEGER NOT NULL,
     correct BOOLEAN NOT NULL,
+    review_mode TEXT NOT NULL DEFAULT 'recognition',
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    client_id TEXT,
     FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
     FOREIGN KEY (study_session_id) REFERENCES study_sessions(id) ON DELETE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS sentences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    text TEXT NOT NULL,
+    translation TEXT NOT NULL,
+    source TEXT NOT NULL DEFAULT 'manual' CHECK (source IN ('manual', 'llm', 'import')),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS words_sentences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL,
+    sentence_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    FOREIGN KEY (sentence_id) REFERENCES sentences(id) ON DELETE CASCADE,
+    UNIQUE (word_id, sentence_id)
+);
+
+CREATE TABLE IF NOT EXISTS tutor_conversations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    course_id INTEGER NOT NULL DEFAULT 1,
+    group_id INTEGER,
+    persona TEXT NOT NULL DEFAULT 'luca',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (course_id) REFERENCES courses(id),
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS tutor_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    conversation_id INTEGER NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('system', 'user', 'assistant')),
+    content TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (conversation_id) REFERENCES tutor_conversations(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS tutor_vocabulary (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    conversation_id INTEGER NOT NULL,
+    course_id INTEGER NOT NULL DEFAULT 1,
+    word_id INTEGER,
+    term TEXT NOT NULL,
+    translation TEXT NOT NULL,
+    part_of_speech TEXT,
+    pronunciation TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (conversation_id) REFERENCES tutor_conversations(id) ON DELETE CASCADE,
+    FOREIGN KEY (course_id) REFERENCES courses(id),
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE SET NULL,
+    UNIQUE (conversation_id, term)
+);
+
 -- Create indexes for better query performance
+CREATE TABLE IF NOT EXISTS llm_cache (
+    cache_key TEXT PRIMARY KEY,
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL,
+    response TEXT NOT NULL,
+    hits INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled')),
+    payload TEXT NOT NULL,
+    progress INTEGER NOT NULL DEFAULT 0,
+    result TEXT,
+    error TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    started_at DATETIME,
+    finished_at DATETIME
+);
+
+CREATE TABLE IF NOT EXISTS llm_usage (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    provider TEXT NOT NULL,
+    model TEXT NOT NULL,
+    endpoint TEXT NOT NULL,
+    prompt_tokens INTEGER NOT NULL DEFAULT 0,
+    completion_tokens INTEGER NOT NULL DEFAULT 0,
+    total_tokens INTEGER NOT NULL DEFAULT 0,
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL CHECK (status IN ('success', 'error', 'cancelled')),
+    error TEXT,
+    cost_usd REAL NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS listening_exercises (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    course_id INTEGER NOT NULL DEFAULT 1,
+    title TEXT NOT NULL,
+    level TEXT,
+    topic TEXT,
+    context TEXT,
+    source_url TEXT,
+    start_ms INTEGER,
+    end_ms INTEGER,
+    content_hash TEXT NOT NULL UNIQUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (course_id) REFERENCES courses(id)
+);
+
+CREATE TABLE IF NOT EXISTS listening_segments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    exercise_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    speaker TEXT,
+    text TEXT NOT NULL,
+    translation TEXT,
+    start_ms INTEGER,
+    key_phrases TEXT NOT NULL DEFAULT '[]',
+    FOREIGN KEY (exercise_id) REFERENCES listening_exercises(id) ON DELETE CASCADE,
+    UNIQUE (exercise_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS listening_questions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    exercise_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    question TEXT NOT NULL,
+    options TEXT NOT NULL,
+    correct_option INTEGER NOT NULL,
+    start_ms INTEGER,
+    FOREIGN KEY (exercise_id) REFERENCES listening_exercises(id) ON DELETE CASCADE,
+    UNIQUE (exercise_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS listening_answers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    question_id INTEGER NOT NULL,
+    study_session_id INTEGER NOT NULL,
+    selected_option INTEGER NOT NULL,
+    correct BOOLEAN NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (question_id) REFERENCES listening_questions(id) ON DELETE CASCADE,
+    FOREIGN KEY (study_session_id) REFERENCES study_sessions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_words_course_id ON words(course_id);
 CREATE INDEX IF NOT EXISTS idx_words_groups_word_id ON words_groups(word_id);
 CREATE INDEX IF NOT EXISTS idx_words_groups_group_id ON words_groups(group_id);
 CREATE INDEX IF NOT EXISTS idx_study_sessions_group_id ON study_sessions(group_id);
+CREATE INDEX IF NOT EXISTS idx_study_sessions_created_at ON study_sessions(created_at, id);
 CREATE INDEX IF NOT EXISTS idx_word_review_items_word_id ON word_review_items(word_id);
 CREATE INDEX IF NOT EXISTS idx_word_review_items_study_session_id ON word_review_items(study_session_id);
+CREATE INDEX IF NOT EXISTS idx_word_review_items_word_id_review_mode ON word_review_items(word_id, review_mode);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_study_sessions_client_id ON study_sessions(client_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_word_review_items_client_id ON word_review_items(client_id);
+CREATE INDEX IF NOT EXISTS idx_words_sentences_word_id ON words_sentences(word_id);
+CREATE INDEX IF NOT EXISTS idx_words_sentences_sentence_id ON words_sentences(sentence_id);
+CREATE INDEX IF NOT EXISTS idx_tutor_messages_conversation_id ON tutor_messages(conversation_id);
+CREATE INDEX IF NOT EXISTS idx_tutor_vocabulary_conversation_id ON tutor_vocabulary(conversation_id);
+CREATE INDEX IF NOT EXISTS idx_tutor_vocabulary_course_term ON tutor_vocabulary(course_id, term);
+CREATE INDEX IF NOT EXISTS idx_llm_cache_expires_at ON llm_cache(expires_at);
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status, id);
+CREATE INDEX IF NOT EXISTS idx_llm_usage_created_at ON llm_usage(created_at);
+CREATE INDEX IF NOT EXISTS idx_listening_exercises_course_id ON listening_exercises(course_id);
+CREATE INDEX IF NOT EXISTS idx_listening_answers_question_id ON listening_answers(question_id);
+CREATE INDEX IF NOT EXISTS idx_listening_answers_study_session_id ON listening_answers(study_session_id);
+
+CREATE TABLE IF NOT EXISTS content_packs (
+    name TEXT PRIMARY KEY,
+    version INTEGER NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    course_id INTEGER NOT NULL DEFAULT 1,
+    installed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (course_id) REFERENCES courses(id)
+);
+
+CREATE TABLE IF NOT EXISTS content_pack_words (
+    pack_name TEXT NOT NULL,
+    word_id INTEGER NOT NULL,
+    created BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (pack_name, word_id),
+    FOREIGN KEY (pack_name) REFERENCES content_packs(name) ON DELETE CASCADE,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS content_pack_groups (
+    pack_name TEXT NOT NULL,
+    group_id INTEGER NOT NULL,
+    created BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (pack_name, group_id),
+    FOREIGN KEY (pack_name) REFERENCES content_packs(name) ON DELETE CASCADE,
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_pack_words_word_id ON content_pack_words(word_id);
+CREATE INDEX IF NOT EXISTS idx_content_pack_groups_group_id ON content_pack_groups(group_id);
+
+CREATE TABLE IF NOT EXISTS content_pack_words_groups (
+    pack_name TEXT NOT NULL,
+    word_id INTEGER NOT NULL,
+    group_id INTEGER NOT NULL,
+    created BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (pack_name, word_id, group_id),
+    FOREIGN KEY (pack_name) REFERENCES content_packs(name) ON DELETE CASCADE,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_pack_words_groups_word_id ON content_pack_words_groups(word_id, group_id);
+
+CREATE TABLE IF NOT EXISTS learner (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    name TEXT NOT NULL,
+    email TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS seed_packs (
+    name TEXT PRIMARY KEY,
+    version INTEGER NOT NULL,
+    applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The data version no longer changes; its row is the lock sync pushes take
+CREATE TABLE IF NOT EXISTS data_version (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    version INTEGER NOT NULL
+);
+
+INSERT OR IGNORE INTO data_version (id, version) VALUES (1, 1);
+
+-- ETags are derived from the versions of the tables a response is read from,
+-- or of the group it describes, rather than from the data version
+CREATE TABLE IF NOT EXISTS table_versions (
+    name TEXT PRIMARY KEY,
+    version INTEGER NOT NULL
+);
+
+INSERT OR IGNORE INTO table_versions (name, version) VALUES
+    ('courses', 1),
+    ('words', 1),
+    ('groups', 1),
+    ('words_groups', 1),
+    ('study_activities', 1),
+    ('study_sessions', 1),
+    ('word_review_items', 1),
+    ('listening_answers', 1);
+
+CREATE TABLE IF NOT EXISTS group_versions (
+    group_id INTEGER PRIMARY KEY,
+    version INTEGER NOT NULL DEFAULT 1,
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
+);
+
+INSERT OR IGNORE INTO group_versions (group_id) SELECT id FROM groups;
+
+CREATE TABLE IF NOT EXISTS change_log (
+    seq INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity TEXT NOT NULL CHECK (entity IN ('word', 'group', 'group_word', 'study_session', 'review')),
+    entity_id INTEGER NOT NULL,
+    op TEXT NOT NULL CHECK (op IN ('insert', 'update', 'delete')),
+    changed_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_change_log_entity ON change_log(entity, entity_id, seq);
+
+CREATE TABLE IF NOT EXISTS sync_state (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    epoch TEXT NOT NULL
+);
+
+INSERT OR IGNORE INTO sync_state (id, epoch) VALUES (1, lower(hex(randomblob(8))));
+
+CREATE TRIGGER IF NOT EXISTS bump_table_version_courses_insert AFTER INSERT ON courses
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'courses';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_courses_update AFTER UPDATE ON courses
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'courses';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_courses_delete AFTER DELETE ON courses
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'courses';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_words_insert AFTER INSERT ON words
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'words';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_words_update AFTER UPDATE ON words
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'words';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_words_delete AFTER DELETE ON words
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'words';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_groups_insert AFTER INSERT ON groups
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'groups';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_groups_update AFTER UPDATE ON groups
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'groups';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_groups_delete AFTER DELETE ON groups
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'groups';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_words_groups_insert AFTER INSERT ON words_groups
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'words_groups';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_words_groups_update AFTER UPDATE ON words_groups
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'words_groups';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_words_groups_delete AFTER DELETE ON words_groups
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'words_groups';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_study_activities_insert AFTER INSERT ON study_activities
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'study_activities';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_study_activities_update AFTER UPDATE ON study_activities
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'study_activities';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_study_activities_delete AFTER DELETE ON study_activities
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'study_activities';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_study_sessions_insert AFTER INSERT ON study_sessions
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'study_sessions';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_study_sessions_update AFTER UPDATE ON study_sessions
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'study_sessions';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_study_sessions_delete AFTER DELETE ON study_sessions
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'study_sessions';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_word_review_items_insert AFTER INSERT ON word_review_items
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'word_review_items';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_word_review_items_update AFTER UPDATE ON word_review_items
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'word_review_items';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_word_review_items_delete AFTER DELETE ON word_review_items
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'word_review_items';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_listening_answers_insert AFTER INSERT ON listening_answers
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'listening_answers';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_listening_answers_update AFTER UPDATE ON listening_answers
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'listening_answers';
+END;
+CREATE TRIGGER IF NOT EXISTS bump_table_version_listening_answers_delete AFTER DELETE ON listening_answers
+BEGIN
+    UPDATE table_versions SET version = version + 1 WHERE name = 'listening_answers';
+END;
+CREATE TRIGGER IF NOT EXISTS add_group_version AFTER INSERT ON groups
+BEGIN
+    INSERT INTO group_versions (group_id) VALUES (NEW.id);
+END;
+CREATE TRIGGER IF NOT EXISTS bump_group_version_rename AFTER UPDATE OF name ON groups
+WHEN NEW.name IS NOT OLD.name
+BEGIN
+    UPDATE group_versions SET version = version + 1 WHERE group_id = NEW.id;
+END;
+CREATE TRIGGER IF NOT EXISTS bump_group_version_words_insert AFTER INSERT ON words_groups
+BEGIN
+    UPDATE group_versions SET version = version + 1 WHERE group_id = NEW.group_id;
+END;
+CREATE TRIGGER IF NOT EXISTS bump_group_version_words_update AFTER UPDATE ON words_groups
+BEGIN
+    UPDATE group_versions SET version = version + 1 WHERE group_id IN (OLD.group_id, NEW.group_id);
+END;
+CREATE TRIGGER IF NOT EXISTS bump_group_version_words_delete AFTER DELETE ON words_groups
+BEGIN
+    UPDATE group_versions SET version = version + 1 WHERE group_id = OLD.group_id;
+END;
+CREATE TRIGGER IF NOT EXISTS log_words_insert AFTER INSERT ON words
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('word', NEW.id, 'insert');
+END;
+CREATE TRIGGER IF NOT EXISTS log_words_update AFTER UPDATE ON words
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('word', NEW.id, 'update');
+END;
+CREATE TRIGGER IF NOT EXISTS log_words_delete AFTER DELETE ON words
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('word', OLD.id, 'delete');
+END;
+CREATE TRIGGER IF NOT EXISTS log_groups_insert AFTER INSERT ON groups
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('group', NEW.id, 'insert');
+END;
+CREATE TRIGGER IF NOT EXISTS log_groups_update AFTER UPDATE ON groups
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('group', NEW.id, 'update');
+END;
+CREATE TRIGGER IF NOT EXISTS log_groups_delete AFTER DELETE ON groups
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('group', OLD.id, 'delete');
+END;
+CREATE TRIGGER IF NOT EXISTS log_words_groups_insert AFTER INSERT ON words_groups
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('group_word', NEW.id, 'insert');
+END;
+CREATE TRIGGER IF NOT EXISTS log_words_groups_update AFTER UPDATE ON words_groups
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('group_word', NEW.id, 'update');
+END;
+CREATE TRIGGER IF NOT EXISTS log_words_groups_delete AFTER DELETE ON words_groups
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('group_word', OLD.id, 'delete');
+END;
+CREATE TRIGGER IF NOT EXISTS log_study_sessions_insert AFTER INSERT ON study_sessions
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('study_session', NEW.id, 'insert');
+END;
+CREATE TRIGGER IF NOT EXISTS log_study_sessions_update AFTER UPDATE ON study_sessions
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('study_session', NEW.id, 'update');
+END;
+CREATE TRIGGER IF NOT EXISTS log_study_sessions_delete AFTER DELETE ON study_sessions
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('study_session', OLD.id, 'delete');
+END;
+CREATE TRIGGER IF NOT EXISTS log_word_review_items_insert AFTER INSERT ON word_review_items
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('review', NEW.id, 'insert');
+END;
+CREATE TRIGGER IF NOT EXISTS log_word_review_items_update AFTER UPDATE ON word_review_items
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('review', NEW.id, 'update');
+END;
+CREATE TRIGGER IF NOT EXISTS log_word_review_items_delete AFTER DELETE ON word_review_items
+BEGIN
+    INSERT INTO change_log (entity, entity_id, op) VALUES ('review', OLD.id, 'delete');
+END;
 `, nil
 }
@@ -1,13 +1,14 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 )
 
-func (r *SQLiteRepository) GetStudyActivities(limit, offset int) (*models.StudyActivityListResponse, error) {
+func (r *SQLRepository) GetStudyActivities(ctx context.Context, limit, offset int) (*models.StudyActivityListResponse, error) {
 	query := `
 		SELECT id, name, thumbnail_url, description, created_at, launch_url
 		FROM study_activities
@@ -15,7 +16,7 @@ func (r *SQLiteRepository) GetStudyActivities(limit, offset int) (*models.StudyA
 		LIMIT ? OFFSET ?
 	`
 
-	rows, err := r.db.Query(query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -57,23 +58,18 @@ func (r *SQLiteRepository) GetStudyActivities(limit, offset int) (*models.StudyA
 
 	// Get total count for pagination
 	var total int
-	if err := r.db.QueryRow("SELECT COUNT(*) FROM study_activities").Scan(&total); err != nil {
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM study_activities").Scan(&total); err != nil {
 		return nil, err
 	}
 
 	return &models.StudyActivityListResponse{
-		Items: activities,
-		Pagination: models.PaginationResponse{
-			CurrentPage:  offset/limit + 1,
-			TotalPages:   (total + limit - 1) / limit,
-			TotalItems:   total,
-			ItemsPerPage: limit,
-		},
+		Items:      activities,
+		Pagination: models.NewPagination(limit, offset, total),
 	}, nil
 }
 
-func (r *SQLiteRepository) CreateStudyActivitySession(activityID, groupID int64) (*models.LaunchStudyActivityResponse, error) {
-	tx, err := r.db.Begin()
+func (r *SQLRepository) CreateStudyActivitySession(ctx context.Context, activityID, groupID int64) (*models.LaunchStudyActivityResponse, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -82,30 +78,21 @@ func (r *SQLiteRepository) CreateStudyActivitySession(activityID, groupID int64)
 	// Insert study session
 	sessionQuery := `
 		INSERT INTO study_sessions (group_id, created_at)
-		VALUES (?, datetime('now'))
+		VALUES (?, CURRENT_TIMESTAMP)
+		RETURNING id
 	`
-	sessionResult, err := tx.Exec(sessionQuery, groupID)
-	if err != nil {
-		return nil, err
-	}
-
-	sessionID, err := sessionResult.LastInsertId()
-	if err != nil {
+	var sessionID int64
+	if err := tx.QueryRowContext(ctx, sessionQuery, groupID).Scan(&sessionID); err != nil {
 		return nil, err
 	}
 
 	// Insert study activity
 	activityQuery := `
 		INSERT INTO study_activities (study_session_id, group_id, created_at)
-		VALUES (?, ?, datetime('now'))
+		VALUES (?, ?, CURRENT_TIMESTAMP)
+		RETURNING id
 	`
-	activityResult, err := tx.Exec(activityQuery, sessionID, groupID)
-	if err != nil {
-		return nil, err
-	}
-
-	activityID, err = activityResult.LastInsertId()
-	if err != nil {
+	if err := tx.QueryRowContext(ctx, activityQuery, sessionID, groupID).Scan(&activityID); err != nil {
 		return nil, err
 	}
 
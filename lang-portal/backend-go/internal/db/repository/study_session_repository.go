@@ -1,47 +1,119 @@
 package repository
 
 import (
+	"context"
+	"strings"
+
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 )
 
-func (r *SQLiteRepository) GetAllStudySessions(limit, offset int) ([]models.StudySession, error) {
-	query := `
-		SELECT 
-			id, 
-			group_id,
-			study_activity_id,
-			created_at
+// GetAllStudySessions lists study sessions newest first, with the names of
+// their activity and group and the stats of their reviews. With a cursor,
+// the list continues after the session it points to. The page is selected
+// first, so only its sessions are joined and counted.
+func (r *SQLRepository) GetAllStudySessions(ctx context.Context, params models.ListParams) ([]models.StudySessionDetailResponse, error) {
+	page := `
+		SELECT id, group_id, study_activity_id, created_at
 		FROM study_sessions
-		ORDER BY created_at DESC
-		LIMIT ? OFFSET ?
 	`
+	args := []interface{}{}
+	if params.Cursor != nil {
+		createdAt := r.db.dialect.timeArg(params.Cursor.CreatedAt)
+		page += " WHERE created_at < ? OR (created_at = ? AND id < ?)"
+		args = append(args, createdAt, createdAt, params.Cursor.ID)
+	}
+	page += " ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?"
+	args = append(args, params.Limit, params.Offset)
 
-	rows, err := r.db.Query(query, limit, offset)
+	query := `
+		SELECT
+			ss.id,
+			sa.name,
+			g.name,
+			ss.created_at,
+			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_session_id = ss.id),
+			(SELECT COUNT(*) FROM word_review_items wri WHERE wri.study_session_id = ss.id AND wri.correct)
+		FROM (` + page + `) ss
+		JOIN study_activities sa ON sa.id = ss.study_activity_id
+		JOIN groups g ON g.id = ss.group_id
+		ORDER BY ss.created_at DESC, ss.id DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var sessions []models.StudySession
+	sessions := []models.StudySessionDetailResponse{}
 	for rows.Next() {
-		var session models.StudySession
+		var session models.StudySessionDetailResponse
 		err := rows.Scan(
 			&session.ID,
-			&session.StudyActivityID, // activity_id maps to StudyActivityID
-			&session.GroupID,
+			&session.ActivityName,
+			&session.GroupName,
 			&session.CreatedAt,
+			&session.Stats.TotalWords,
+			&session.Stats.CorrectWords,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if session.Stats.TotalWords > 0 {
+			session.Stats.SuccessRate = float64(session.Stats.CorrectWords) / float64(session.Stats.TotalWords) * 100
+		}
 		sessions = append(sessions, session)
 	}
 
-	return sessions, nil
+	return sessions, rows.Err()
+}
+
+// GetWordReviewsBySessionIDs returns the reviews of several study sessions
+// by session ID, in the order they were made
+func (r *SQLRepository) GetWordReviewsBySessionIDs(ctx context.Context, sessionIDs []int64) (map[int64][]models.WordReviewItem, error) {
+	reviews := map[int64][]models.WordReviewItem{}
+	if len(sessionIDs) == 0 {
+		return reviews, nil
+	}
+
+	args := make([]interface{}, len(sessionIDs))
+	for i, id := range sessionIDs {
+		args[i] = id
+	}
+	query := `
+		SELECT id, word_id, study_session_id, correct, review_mode, created_at
+		FROM word_review_items
+		WHERE study_session_id IN (?` + strings.Repeat(", ?", len(sessionIDs)-1) + `)
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var review models.WordReviewItem
+		err := rows.Scan(
+			&review.ID,
+			&review.WordID,
+			&review.StudySessionID,
+			&review.Correct,
+			&review.ReviewMode,
+			&review.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reviews[review.StudySessionID] = append(reviews[review.StudySessionID], review)
+	}
+
+	return reviews, rows.Err()
 }
 
-func (r *SQLiteRepository) GetTotalStudySessions() (int, error) {
+func (r *SQLRepository) GetTotalStudySessions(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRow("SELECT COUNT(*) FROM study_sessions").Scan(&count)
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM study_sessions").Scan(&count)
 	return count, err
 }
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// BenchmarkGetAllStudySessions lists pages of a long study history, 10k
+// sessions of 10 reviews each.
+//
+//	go test -run '^$' -bench GetAllStudySessions ./internal/db/repository
+func BenchmarkGetAllStudySessions(b *testing.B) {
+	ctx := context.Background()
+	repo, err := NewDB(filepath.Join(b.TempDir(), "bench.db"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer repo.Close()
+	if err := repo.CreateTables(ctx); err != nil {
+		b.Fatal(err)
+	}
+	firstID, wordIDs := seedReviews(b, repo, 10, 0)
+
+	tx, err := repo.BeginTx(ctx)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < 10000; i++ {
+		sessionID := firstID
+		if i > 0 {
+			err := tx.QueryRowContext(ctx, "INSERT INTO study_sessions (group_id, study_activity_id) SELECT group_id, study_activity_id FROM study_sessions WHERE id = ? RETURNING id", firstID).Scan(&sessionID)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+		for j, wordID := range wordIDs {
+			_, err := tx.ExecContext(ctx, "INSERT INTO word_review_items (word_id, study_session_id, correct) VALUES (?, ?, ?)", wordID, sessionID, j%3 != 0)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		b.Fatal(err)
+	}
+
+	page, err := repo.GetAllStudySessions(ctx, models.ListParams{Limit: 100, Offset: 5000})
+	if err != nil {
+		b.Fatal(err)
+	}
+	last := page[len(page)-1]
+	cursor := &models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+
+	b.Run("first page", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.GetAllStudySessions(ctx, models.ListParams{Limit: 100}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cursor page", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := repo.GetAllStudySessions(ctx, models.ListParams{Limit: 100, Cursor: cursor}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("with reviews", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sessions, err := repo.GetAllStudySessions(ctx, models.ListParams{Limit: 100})
+			if err != nil {
+				b.Fatal(err)
+			}
+			ids := make([]int64, len(sessions))
+			for i, session := range sessions {
+				ids[i] = session.ID
+			}
+			if _, err := repo.GetWordReviewsBySessionIDs(ctx, ids); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// GetChangeLogState returns the epoch of the change log and the sequence
+// number of its latest change, 0 while it is empty
+func (r *SQLRepository) GetChangeLogState(ctx context.Context) (epoch string, latest int64, err error) {
+	err = r.db.QueryRowContext(ctx, `
+		SELECT epoch, (SELECT COALESCE(MAX(seq), 0) FROM change_log)
+		FROM sync_state
+		WHERE id = 1
+	`).Scan(&epoch, &latest)
+	return epoch, latest, err
+}
+
+// GetChanges returns up to limit changes after the sequence number since,
+// oldest first, with only the latest change of each entity. Entities that
+// still exist come with their current state; one deleted after its change
+// was read is reported deleted, and its delete follows in a later change.
+func (r *SQLRepository) GetChanges(ctx context.Context, since int64, limit int) ([]models.SyncChange, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT seq, entity, entity_id, op
+		FROM change_log c
+		WHERE seq > ?
+			AND seq = (SELECT MAX(seq) FROM change_log l WHERE l.entity = c.entity AND l.entity_id = c.entity_id)
+		ORDER BY seq
+		LIMIT ?
+	`, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []models.SyncChange{}
+	ids := map[string][]int64{}
+	for rows.Next() {
+		var change models.SyncChange
+		if err := rows.Scan(&change.Seq, &change.Entity, &change.ID, &change.Op); err != nil {
+			return nil, err
+		}
+		if change.Op != models.SyncOpDelete {
+			ids[change.Entity] = append(ids[change.Entity], change.ID)
+		}
+		changes = append(changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	data := map[string]map[int64]interface{}{}
+	for entity, entityIDs := range ids {
+		if data[entity], err = r.getSyncEntities(ctx, entity, entityIDs); err != nil {
+			return nil, err
+		}
+	}
+	for i := range changes {
+		change := &changes[i]
+		if change.Op == models.SyncOpDelete {
+			continue
+		}
+		if entity, ok := data[change.Entity][change.ID]; ok {
+			change.Data = entity
+		} else {
+			change.Op = models.SyncOpDelete
+		}
+	}
+	return changes, nil
+}
+
+// getSyncEntities loads the current state of entities of one kind by ID.
+// Entities that no longer exist are missing from the result.
+func (r *SQLRepository) getSyncEntities(ctx context.Context, entity string, ids []int64) (map[int64]interface{}, error) {
+	var query string
+	switch entity {
+	case models.SyncEntityWord:
+		query = "SELECT id, course_id, term, translation, parts FROM words"
+	case models.SyncEntityGroup:
+		query = "SELECT id, name FROM groups"
+	case models.SyncEntityGroupWord:
+		query = "SELECT id, group_id, word_id FROM words_groups"
+	case models.SyncEntityStudySession:
+		query = "SELECT id, group_id, study_activity_id, client_id, created_at FROM study_sessions"
+	case models.SyncEntityReview:
+		query = "SELECT id, study_session_id, word_id, correct, review_mode, client_id, created_at FROM word_review_items"
+	default:
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := r.db.QueryContext(ctx, query+" WHERE id IN (?"+strings.Repeat(", ?", len(ids)-1)+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entities := map[int64]interface{}{}
+	for rows.Next() {
+		switch entity {
+		case models.SyncEntityWord:
+			var word models.SyncWord
+			var parts sql.NullString
+			if err := rows.Scan(&word.ID, &word.CourseID, &word.Term, &word.Translation, &parts); err != nil {
+				return nil, err
+			}
+			if parts.Valid && parts.String != "" {
+				if err := json.Unmarshal([]byte(parts.String), &word.Parts); err != nil {
+					return nil, err
+				}
+			}
+			entities[word.ID] = word
+		case models.SyncEntityGroup:
+			var group models.SyncGroup
+			if err := rows.Scan(&group.ID, &group.Name); err != nil {
+				return nil, err
+			}
+			entities[group.ID] = group
+		case models.SyncEntityGroupWord:
+			var member models.SyncGroupWord
+			if err := rows.Scan(&member.ID, &member.GroupID, &member.WordID); err != nil {
+				return nil, err
+			}
+			entities[member.ID] = member
+		case models.SyncEntityStudySession:
+			var session models.SyncStudySession
+			if err := rows.Scan(&session.ID, &session.GroupID, &session.StudyActivityID, &session.ClientID, &session.CreatedAt); err != nil {
+				return nil, err
+			}
+			entities[session.ID] = session
+		case models.SyncEntityReview:
+			var review models.SyncReview
+			if err := rows.Scan(&review.ID, &review.StudySessionID, &review.WordID, &review.Correct, &review.ReviewMode, &review.ClientID, &review.CreatedAt); err != nil {
+				return nil, err
+			}
+			entities[review.ID] = review
+		}
+	}
+	return entities, rows.Err()
+}
+
+// ApplySyncPush records study sessions and reviews pushed by a client, in
+// the order given, in one transaction. An item whose client ID is recorded
+// already is a duplicate and left as it is. An item that refers to a group,
+// activity, word or session that does not exist, or to a rejected session,
+// is rejected with the reason.
+func (r *SQLRepository) ApplySyncPush(ctx context.Context, sessions []models.PushedSession, reviews []models.PushedReview) (*models.SyncPushResponse, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Pushes wait for each other, so two pushes of the same item cannot both
+	// find it missing
+	var version int64
+	if err := tx.QueryRowContext(ctx, "SELECT version FROM data_version WHERE id = 1"+r.db.dialect.forUpdate).Scan(&version); err != nil {
+		return nil, err
+	}
+
+	response := &models.SyncPushResponse{Sessions: []models.SyncPushResult{}, Reviews: []models.SyncPushResult{}}
+	rejectedSessions := map[string]bool{}
+	for _, session := range sessions {
+		result, err := r.pushSession(ctx, tx, session)
+		if err != nil {
+			return nil, err
+		}
+		if result.Status == models.SyncStatusRejected {
+			rejectedSessions[session.ClientID] = true
+		}
+		response.Sessions = append(response.Sessions, result)
+	}
+	for _, review := range reviews {
+		result, err := r.pushReview(ctx, tx, review, rejectedSessions)
+		if err != nil {
+			return nil, err
+		}
+		response.Reviews = append(response.Reviews, result)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (r *SQLRepository) pushSession(ctx context.Context, tx *Tx, session models.PushedSession) (models.SyncPushResult, error) {
+	result := models.SyncPushResult{ClientID: session.ClientID}
+	id, found, err := findID(ctx, tx, "SELECT id FROM study_sessions WHERE client_id = ?", session.ClientID)
+	if err != nil || found {
+		result.Status, result.ID = models.SyncStatusDuplicate, id
+		return result, err
+	}
+
+	if _, found, err := findID(ctx, tx, "SELECT id FROM groups WHERE id = ?", session.GroupID); err != nil || !found {
+		result.Status, result.Reason = models.SyncStatusRejected, "group_not_found"
+		return result, err
+	}
+	if _, found, err := findID(ctx, tx, "SELECT id FROM study_activities WHERE id = ?", session.StudyActivityID); err != nil || !found {
+		result.Status, result.Reason = models.SyncStatusRejected, "study_activity_not_found"
+		return result, err
+	}
+
+	result.Status = models.SyncStatusCreated
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO study_sessions (group_id, study_activity_id, client_id, created_at)
+		VALUES (?, ?, ?, ?)
+		RETURNING id
+	`, session.GroupID, session.StudyActivityID, session.ClientID, r.db.dialect.timeArg(session.StartedAt)).Scan(&result.ID)
+	return result, err
+}
+
+func (r *SQLRepository) pushReview(ctx context.Context, tx *Tx, review models.PushedReview, rejectedSessions map[string]bool) (models.SyncPushResult, error) {
+	result := models.SyncPushResult{ClientID: review.ClientID}
+	id, found, err := findID(ctx, tx, "SELECT id FROM word_review_items WHERE client_id = ?", review.ClientID)
+	if err != nil || found {
+		result.Status, result.ID = models.SyncStatusDuplicate, id
+		return result, err
+	}
+
+	if rejectedSessions[review.SessionClientID] {
+		result.Status, result.Reason = models.SyncStatusRejected, "study_session_rejected"
+		return result, nil
+	}
+	var sessionID int64
+	if review.SessionClientID != "" {
+		sessionID, found, err = findID(ctx, tx, "SELECT id FROM study_sessions WHERE client_id = ?", review.SessionClientID)
+	} else {
+		sessionID, found, err = findID(ctx, tx, "SELECT id FROM study_sessions WHERE id = ?", review.StudySessionID)
+	}
+	if err != nil || !found {
+		result.Status, result.Reason = models.SyncStatusRejected, "study_session_not_found"
+		return result, err
+	}
+	if _, found, err := findID(ctx, tx, "SELECT id FROM words WHERE id = ?", review.WordID); err != nil || !found {
+		result.Status, result.Reason = models.SyncStatusRejected, "word_not_found"
+		return result, err
+	}
+
+	result.Status = models.SyncStatusCreated
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO word_review_items (word_id, study_session_id, correct, review_mode, client_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`, review.WordID, sessionID, review.Correct, review.ReviewMode, review.ClientID, r.db.dialect.timeArg(review.ReviewedAt)).Scan(&result.ID)
+	return result, err
+}
+
+// findID returns the ID a query selects, and whether it selected one
+func findID(ctx context.Context, tx *Tx, query string, args ...interface{}) (int64, bool, error) {
+	var id int64
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	return id, err == nil, err
+}
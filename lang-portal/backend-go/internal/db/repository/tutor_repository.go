@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+func (r *SQLRepository) CreateTutorConversation(ctx context.Context, courseID int64, groupID *int64, persona string) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx,
+		"INSERT INTO tutor_conversations (course_id, group_id, persona) VALUES (?, ?, ?) RETURNING id",
+		courseID,
+		groupID,
+		persona,
+	).Scan(&id)
+	return id, err
+}
+
+func (r *SQLRepository) GetTutorConversation(ctx context.Context, id int64) (*models.TutorConversation, error) {
+	query := `
+		SELECT id, course_id, group_id, persona, created_at, updated_at
+		FROM tutor_conversations
+		WHERE id = ?
+	`
+
+	var conversation models.TutorConversation
+	var groupID sql.NullInt64
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&conversation.ID,
+		&conversation.CourseID,
+		&groupID,
+		&conversation.Persona,
+		&conversation.CreatedAt,
+		&conversation.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, models.NotFoundError("conversation")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if groupID.Valid {
+		id := groupID.Int64
+		conversation.GroupID = &id
+	}
+
+	return &conversation, nil
+}
+
+func (r *SQLRepository) AddTutorMessage(ctx context.Context, conversationID int64, role, content string) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRowContext(ctx,
+		"INSERT INTO tutor_messages (conversation_id, role, content) VALUES (?, ?, ?) RETURNING id",
+		conversationID,
+		role,
+		content,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE tutor_conversations SET updated_at = CURRENT_TIMESTAMP WHERE id = ?", conversationID)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+func (r *SQLRepository) GetTutorMessages(ctx context.Context, conversationID int64) ([]models.TutorMessage, error) {
+	query := `
+		SELECT id, role, content, created_at
+		FROM tutor_messages
+		WHERE conversation_id = ?
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []models.TutorMessage{}
+	for rows.Next() {
+		var message models.TutorMessage
+		err := rows.Scan(
+			&message.ID,
+			&message.Role,
+			&message.Content,
+			&message.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+// SaveTutorVocabulary stores vocabulary entries for a conversation under its
+// course, linking them to the course's words where the term matches
+func (r *SQLRepository) SaveTutorVocabulary(ctx context.Context, conversationID int64, entries []models.TutorVocabularyEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var courseID int64
+	err = tx.QueryRowContext(ctx, "SELECT course_id FROM tutor_conversations WHERE id = ?", conversationID).Scan(&courseID)
+	if err == sql.ErrNoRows {
+		return models.NotFoundError("conversation")
+	}
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO tutor_vocabulary (conversation_id, course_id, word_id, term, translation, part_of_speech, pronunciation)
+		VALUES (?, ?, (SELECT id FROM words WHERE course_id = ? AND lower(term) = lower(?) LIMIT 1), ?, ?, ?, ?)
+		ON CONFLICT (conversation_id, term) DO UPDATE SET
+			translation = excluded.translation,
+			part_of_speech = excluded.part_of_speech,
+			pronunciation = excluded.pronunciation
+	`
+	for _, entry := range entries {
+		_, err := tx.ExecContext(ctx, query,
+			conversationID,
+			courseID,
+			courseID,
+			entry.Term,
+			entry.Term,
+			entry.Translation,
+			entry.PartOfSpeech,
+			entry.Pronunciation,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *SQLRepository) GetTutorVocabulary(ctx context.Context, conversationID int64) ([]models.TutorVocabularyEntry, error) {
+	query := `
+		SELECT word_id, term, translation, COALESCE(part_of_speech, ''), COALESCE(pronunciation, '')
+		FROM tutor_vocabulary
+		WHERE conversation_id = ?
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.TutorVocabularyEntry{}
+	for rows.Next() {
+		var entry models.TutorVocabularyEntry
+		var wordID sql.NullInt64
+		err := rows.Scan(
+			&wordID,
+			&entry.Term,
+			&entry.Translation,
+			&entry.PartOfSpeech,
+			&entry.Pronunciation,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if wordID.Valid {
+			id := wordID.Int64
+			entry.WordID = &id
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -1,25 +1,37 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 )
 
-func (r *SQLiteRepository) GetWords(limit, offset int) (*models.WordListResponse, error) {
+// GetWords lists words, only those of one course when courseID is not 0
+// wordSortColumns maps models.WordSortFields to the columns of the words query
+var wordSortColumns = map[string]string{
+	"id":            "w.id",
+	"term":          "w.term",
+	"translation":   "w.translation",
+	"correct_count": "correct_count",
+	"wrong_count":   "wrong_count",
+}
+
+func (r *SQLRepository) GetWords(ctx context.Context, courseID int64, params models.ListParams) (*models.WordListResponse, error) {
 	query := `
 		SELECT 
-			w.id, w.italian, w.english, w.parts,
+			w.id, w.term, w.translation, w.course_id, w.parts, w.prompt_version,
 			COUNT(CASE WHEN wri.correct THEN 1 END) as correct_count,
 			COUNT(CASE WHEN NOT wri.correct THEN 1 END) as wrong_count
 		FROM words w
 		LEFT JOIN word_review_items wri ON w.id = wri.word_id
-		GROUP BY w.id
+		WHERE ? = 0 OR w.course_id = ?
+		GROUP BY w.id` + orderBy(params, wordSortColumns, "w.id") + `
 		LIMIT ? OFFSET ?
 	`
 
-	rows, err := r.db.Query(query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, courseID, courseID, params.Limit, params.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -29,11 +41,14 @@ func (r *SQLiteRepository) GetWords(limit, offset int) (*models.WordListResponse
 	for rows.Next() {
 		var word models.WordResponse
 		var partsStr string
+		var promptVersion sql.NullString
 		err := rows.Scan(
 			&word.ID,
-			&word.Italian,
-			&word.English,
+			&word.Term,
+			&word.Translation,
+			&word.CourseID,
 			&partsStr,
+			&promptVersion,
 			&word.CorrectCount,
 			&word.WrongCount,
 		)
@@ -44,32 +59,30 @@ func (r *SQLiteRepository) GetWords(limit, offset int) (*models.WordListResponse
 		if err := json.Unmarshal([]byte(partsStr), &word.Parts); err != nil {
 			return nil, err
 		}
+		if promptVersion.Valid {
+			word.PromptVersion = &promptVersion.String
+		}
 		words = append(words, word)
 	}
 
 	// Get total count
-	countQuery := "SELECT COUNT(*) FROM words"
+	countQuery := "SELECT COUNT(*) FROM words WHERE ? = 0 OR course_id = ?"
 	var total int
-	err = r.db.QueryRow(countQuery).Scan(&total)
+	err = r.db.QueryRowContext(ctx, countQuery, courseID, courseID).Scan(&total)
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.WordListResponse{
-		Items: words,
-		Pagination: models.PaginationResponse{
-			CurrentPage:  offset/limit + 1,
-			TotalPages:   (total + limit - 1) / limit,
-			TotalItems:   total,
-			ItemsPerPage: limit,
-		},
+		Items:      words,
+		Pagination: models.NewPagination(params.Limit, params.Offset, total),
 	}, nil
 }
 
-func (r *SQLiteRepository) GetWordByID(id int64) (*models.WordResponse, error) {
+func (r *SQLRepository) GetWordByID(ctx context.Context, id int64) (*models.WordResponse, error) {
 	query := `
 		SELECT 
-			w.id, w.italian, w.english, w.parts,
+			w.id, w.term, w.translation, w.course_id, w.parts, w.prompt_version,
 			COUNT(CASE WHEN wri.correct THEN 1 END) as correct_count,
 			COUNT(CASE WHEN NOT wri.correct THEN 1 END) as wrong_count
 		FROM words w
@@ -80,16 +93,19 @@ func (r *SQLiteRepository) GetWordByID(id int64) (*models.WordResponse, error) {
 
 	var word models.WordResponse
 	var partsStr string
-	err := r.db.QueryRow(query, id).Scan(
+	var promptVersion sql.NullString
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&word.ID,
-		&word.Italian,
-		&word.English,
+		&word.Term,
+		&word.Translation,
+		&word.CourseID,
 		&partsStr,
+		&promptVersion,
 		&word.CorrectCount,
 		&word.WrongCount,
 	)
 	if err == sql.ErrNoRows {
-		return nil, nil
+		return nil, models.NotFoundError("word")
 	}
 	if err != nil {
 		return nil, err
@@ -99,6 +115,9 @@ func (r *SQLiteRepository) GetWordByID(id int64) (*models.WordResponse, error) {
 	if err := json.Unmarshal([]byte(partsStr), &word.Parts); err != nil {
 		return nil, err
 	}
+	if promptVersion.Valid {
+		word.PromptVersion = &promptVersion.String
+	}
 
 	return &word, nil
 }
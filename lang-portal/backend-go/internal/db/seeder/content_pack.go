@@ -0,0 +1,156 @@
+package seeder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// packNamePattern limits content pack names to ones that are safe in URLs
+var packNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// InstallPack installs the content pack in fsys, e.g. an opened zip, in one
+// transaction. A newer version of an installed pack updates it; the same
+// version is left as it is, though the ids of its words are still returned so
+// their audio can be stored again.
+func (s *Seeder) InstallPack(ctx context.Context, fsys fs.FS) (*models.InstalledPack, error) {
+	p, err := loadPack(fsys)
+	if err != nil {
+		return nil, models.WrapError(models.ErrValidation, "invalid_pack", err.Error(), err)
+	}
+	if !packNamePattern.MatchString(p.manifest.Name) {
+		return nil, models.ValidationError("invalid_pack", "pack names are lowercase letters, digits, dots, dashes and underscores")
+	}
+	if len(p.activities) > 0 {
+		return nil, models.ValidationError("invalid_pack", "content packs cannot add study activities")
+	}
+
+	// Begin transaction
+	tx, err := s.db.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM courses WHERE id = ?)", p.manifest.CourseID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, models.NotFoundError("course")
+	}
+
+	var version int
+	err = tx.QueryRowContext(ctx, "SELECT version FROM content_packs WHERE name = ?", p.manifest.Name).Scan(&version)
+	switch {
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	case version > p.manifest.Version:
+		return nil, models.ConflictError("pack_version_older", fmt.Sprintf("version %d of pack %s is installed, which is newer", version, p.manifest.Name))
+	case version == p.manifest.Version:
+		pack, err := getContentPack(ctx, tx.QueryRowContext, p.manifest.Name)
+		if err != nil {
+			return nil, err
+		}
+		wordIDs, err := packWordIDs(ctx, tx, p)
+		if err != nil {
+			return nil, err
+		}
+		return &models.InstalledPack{Pack: *pack, WordIDs: wordIDs}, nil
+	}
+
+	a, err := applyPack(ctx, tx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the pack, and what it lists. Rows the pack created stay marked
+	// as such across versions.
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO content_packs (name, version, description, course_id) VALUES (?, ?, ?, ?)
+		ON CONFLICT (name) DO UPDATE SET version = excluded.version, description = excluded.description,
+			course_id = excluded.course_id, installed_at = CURRENT_TIMESTAMP`,
+		p.manifest.Name, p.manifest.Version, p.manifest.Description, p.manifest.CourseID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record content pack: %w", err)
+	}
+	for i, wordID := range a.wordIDs {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO content_pack_words (pack_name, word_id, created) VALUES (?, ?, ?) ON CONFLICT DO NOTHING",
+			p.manifest.Name, wordID, a.wordsCreated[i],
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record content pack word: %w", err)
+		}
+	}
+	for i, groupID := range a.groupIDs {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO content_pack_groups (pack_name, group_id, created) VALUES (?, ?, ?) ON CONFLICT DO NOTHING",
+			p.manifest.Name, groupID, a.groupsCreated[i],
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record content pack group: %w", err)
+		}
+	}
+	for i, wg := range p.wordGroups {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO content_pack_words_groups (pack_name, word_id, group_id, created) VALUES (?, ?, ?, ?) ON CONFLICT DO NOTHING",
+			p.manifest.Name, a.wordIDs[wg.WordID-1], a.groupIDs[wg.GroupID-1], a.wordGroupsCreated[i],
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record content pack word group mapping: %w", err)
+		}
+	}
+
+	pack, err := getContentPack(ctx, tx.QueryRowContext, p.manifest.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &models.InstalledPack{Pack: *pack, WordIDs: a.wordIDs, Updated: true}, nil
+}
+
+// packWordIDs finds the words of an installed pack, in the order it lists
+// them. A word deleted since the pack was installed has id 0.
+func packWordIDs(ctx context.Context, tx *repository.Tx, p *pack) ([]int64, error) {
+	wordIDs := make([]int64, len(p.words))
+	for i, word := range p.words {
+		err := tx.QueryRowContext(ctx,
+			"SELECT id FROM words WHERE course_id = ? AND term = ? AND translation = ? ORDER BY id LIMIT 1",
+			p.manifest.CourseID, word.Term, word.Translation,
+		).Scan(&wordIDs[i])
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to find word %s: %w", word.Term, err)
+		}
+	}
+	return wordIDs, nil
+}
+
+// getContentPack reads an installed pack with queryRow, so the pack can be
+// read within a transaction
+func getContentPack(ctx context.Context, queryRow func(ctx context.Context, query string, args ...interface{}) *sql.Row, name string) (*models.ContentPackResponse, error) {
+	var pack models.ContentPackResponse
+	err := queryRow(ctx, `
+		SELECT name, version, description, course_id, installed_at,
+			(SELECT COUNT(*) FROM content_pack_words WHERE pack_name = p.name),
+			(SELECT COUNT(*) FROM content_pack_groups WHERE pack_name = p.name)
+		FROM content_packs p
+		WHERE name = ?`, name,
+	).Scan(&pack.Name, &pack.Version, &pack.Description, &pack.CourseID, &pack.InstalledAt, &pack.WordCount, &pack.GroupCount)
+	if err != nil {
+		return nil, err
+	}
+	return &pack, nil
+}
@@ -0,0 +1,194 @@
+package seeder
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+func travelPack(version int) fstest.MapFS {
+	return fstest.MapFS{
+		"pack.json":   {Data: []byte(`{"name": "a1-travel", "version": ` + strconv.Itoa(version) + `, "description": "Getting around"}`)},
+		"groups.json": {Data: []byte(`{"groups": [{"name": "Travel"}, {"name": "Core"}]}`)},
+		"words.json": {Data: []byte(`{"words": [
+			{"term": "treno", "translation": "train", "parts": {"type": "noun"}},
+			{"term": "biglietto", "translation": "ticket", "parts": {"type": "noun"}},
+			{"term": "ciao", "translation": "hello"}
+		]}`)},
+		"words_groups.json": {Data: []byte(`{"words_groups": [{"word_id": 1, "group_id": 1}, {"word_id": 2, "group_id": 1}, {"word_id": 3, "group_id": 2}]}`)},
+		"sentences.json": {Data: []byte(`{"sentences": [
+			{"word_id": 1, "text": "Il treno è in ritardo.", "translation": "The train is late."}
+		]}`)},
+	}
+}
+
+func TestInstallPack(t *testing.T) {
+	ctx := context.Background()
+	s, db := newTestSeeder(t)
+
+	// A word and a group the learner had before the pack
+	_, err := db.DB().Exec(`INSERT INTO words (term, translation, course_id) VALUES ('ciao', 'hello', 1)`)
+	require.NoError(t, err)
+	_, err = db.DB().Exec(`INSERT INTO groups (name) VALUES ('Core')`)
+	require.NoError(t, err)
+
+	installed, err := s.InstallPack(ctx, travelPack(1))
+	require.NoError(t, err)
+	assert.True(t, installed.Updated)
+	assert.Len(t, installed.WordIDs, 3)
+	assert.Equal(t, "a1-travel", installed.Pack.Name)
+	assert.Equal(t, 3, installed.Pack.WordCount)
+	assert.Equal(t, 2, installed.Pack.GroupCount)
+	assert.Equal(t, 3, count(t, db, "words"))
+	assert.Equal(t, 1, count(t, db, "sentences"))
+
+	// The same version changes nothing, an older one is refused
+	again, err := s.InstallPack(ctx, travelPack(1))
+	require.NoError(t, err)
+	assert.False(t, again.Updated)
+	assert.Equal(t, installed.WordIDs, again.WordIDs, "so the audio can be stored again")
+	assert.Equal(t, 3, count(t, db, "words"))
+
+	_, err = s.InstallPack(ctx, travelPack(2))
+	require.NoError(t, err)
+	var version int
+	require.NoError(t, db.DB().QueryRow("SELECT version FROM content_packs WHERE name = 'a1-travel'").Scan(&version))
+	assert.Equal(t, 2, version)
+
+	_, err = s.InstallPack(ctx, travelPack(1))
+	assert.True(t, errors.Is(err, models.ErrConflict))
+
+	packs, err := db.GetContentPacks(ctx)
+	require.NoError(t, err)
+	require.Len(t, packs, 1)
+	assert.Equal(t, 2, packs[0].Version)
+
+	// Reviewing "treno" keeps it when the pack is uninstalled
+	_, err = db.DB().Exec(`INSERT INTO study_activities (name) VALUES ('Flashcards')`)
+	require.NoError(t, err)
+	_, err = db.DB().Exec(`INSERT INTO study_sessions (group_id, study_activity_id) SELECT id, 1 FROM groups WHERE name = 'Core'`)
+	require.NoError(t, err)
+	_, err = db.DB().Exec(`INSERT INTO word_review_items (word_id, study_session_id, correct) VALUES (?, 1, TRUE)`, installed.WordIDs[0])
+	require.NoError(t, err)
+
+	removed, err := db.DeleteContentPack(ctx, "a1-travel")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed.RemovedWords)
+	assert.Equal(t, []int64{installed.WordIDs[1]}, removed.RemovedWordIDs)
+	assert.Equal(t, 1, removed.KeptWords)
+	assert.Equal(t, 0, removed.RemovedGroups, "Travel still holds treno, and Core existed before")
+
+	var terms []string
+	rows, err := db.DB().Query("SELECT term FROM words ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var term string
+		require.NoError(t, rows.Scan(&term))
+		terms = append(terms, term)
+	}
+	assert.Equal(t, []string{"ciao", "treno"}, terms)
+	assert.Equal(t, 1, count(t, db, "sentences"), "the sentence of a kept word stays")
+	assert.Zero(t, count(t, db, "content_packs"))
+
+	_, err = db.DeleteContentPack(ctx, "a1-travel")
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+}
+
+func TestInstallPack_RemovesUnreviewedContent(t *testing.T) {
+	ctx := context.Background()
+	s, db := newTestSeeder(t)
+
+	_, err := s.InstallPack(ctx, travelPack(1))
+	require.NoError(t, err)
+
+	// Words listed without parts get empty ones, which the API can read
+	words, err := db.GetWords(ctx, models.DefaultCourseID, models.ListParams{Limit: 10, Sort: "term"})
+	require.NoError(t, err)
+	require.Len(t, words.Items, 3)
+	assert.Empty(t, words.Items[1].Parts)
+
+	removed, err := db.DeleteContentPack(ctx, "a1-travel")
+	require.NoError(t, err)
+	assert.Equal(t, 3, removed.RemovedWords)
+	assert.Equal(t, 2, removed.RemovedGroups)
+	for _, table := range []string{"words", "groups", "words_groups", "sentences", "words_sentences", "content_pack_words"} {
+		assert.Zero(t, count(t, db, table), table)
+	}
+}
+
+func TestInstallPack_RemovesGroupsOfExistingWords(t *testing.T) {
+	ctx := context.Background()
+	s, db := newTestSeeder(t)
+
+	// "ciao" and its group existed before the pack, which puts it in Core
+	_, err := db.DB().Exec(`INSERT INTO words (term, translation, course_id) VALUES ('ciao', 'hello', 1)`)
+	require.NoError(t, err)
+	_, err = db.DB().Exec(`INSERT INTO groups (name) VALUES ('Saluti')`)
+	require.NoError(t, err)
+	_, err = db.DB().Exec(`INSERT INTO words_groups (word_id, group_id) VALUES (1, 1)`)
+	require.NoError(t, err)
+
+	_, err = s.InstallPack(ctx, travelPack(1))
+	require.NoError(t, err)
+	assert.Equal(t, 4, count(t, db, "words_groups"))
+
+	removed, err := db.DeleteContentPack(ctx, "a1-travel")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed.RemovedWords)
+	assert.Equal(t, 2, removed.RemovedGroups, "Core only held a word the pack did not create")
+	assert.Equal(t, 1, count(t, db, "words"))
+	assert.Equal(t, 1, count(t, db, "groups"))
+	assert.Equal(t, 1, count(t, db, "words_groups"), "ciao stays in Saluti")
+	assert.Zero(t, count(t, db, "content_pack_words_groups"))
+}
+
+func TestInstallPack_Invalid(t *testing.T) {
+	ctx := context.Background()
+	s, db := newTestSeeder(t)
+
+	tests := map[string]fstest.MapFS{
+		"no manifest": {"words.json": {Data: []byte(`{"words": []}`)}},
+		"unsafe name": {"pack.json": {Data: []byte(`{"name": "../travel", "version": 1}`)}},
+		"activities": {
+			"pack.json":             {Data: []byte(`{"name": "travel", "version": 1}`)},
+			"study_activities.json": {Data: []byte(`{"study_activities": [{"name": "Quiz"}]}`)},
+		},
+		"sentence of a missing word": {
+			"pack.json":      {Data: []byte(`{"name": "travel", "version": 1}`)},
+			"sentences.json": {Data: []byte(`{"sentences": [{"word_id": 1, "text": "Ciao!", "translation": "Hi!"}]}`)},
+		},
+	}
+	for name, pack := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.InstallPack(ctx, pack)
+			assert.True(t, errors.Is(err, models.ErrValidation), err)
+		})
+	}
+
+	_, err := s.InstallPack(ctx, fstest.MapFS{"pack.json": {Data: []byte(`{"name": "travel", "version": 1, "course_id": 42}`)}})
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+	assert.Zero(t, count(t, db, "content_packs"))
+}
+
+func TestInstallPack_AudioOfMissingWord(t *testing.T) {
+	ctx := context.Background()
+	s, db := newTestSeeder(t)
+
+	pack := travelPack(1)
+	pack["audio/1.mp3"] = &fstest.MapFile{Data: []byte("treno")}
+	pack["audio/4.mp3"] = &fstest.MapFile{Data: []byte("stazione")}
+
+	_, err := s.InstallPack(ctx, pack)
+	assert.True(t, errors.Is(err, models.ErrValidation), err)
+	for _, table := range []string{"content_packs", "content_pack_words", "content_pack_groups", "content_pack_words_groups", "words", "groups", "words_groups", "sentences"} {
+		assert.Zero(t, count(t, db, table), table)
+	}
+}
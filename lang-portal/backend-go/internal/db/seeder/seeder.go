@@ -1,28 +1,54 @@
 package seeder
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
 
 	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/seeds"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 )
 
+// maxPackFileSize caps the JSON files of a pack
+const maxPackFileSize = 32 << 20
+
+// Seeder applies packs: the seed packs built into the binary, and content
+// packs installed from zips. Packs are applied by natural keys: groups and
+// study activities by name, words by course, term and translation, and
+// sentences by text and translation, so applying a pack again updates what it
+// seeded instead of duplicating it.
 type Seeder struct {
-	db *repository.SQLiteRepository
+	db    *repository.SQLRepository
+	packs fs.FS
+}
+
+// New returns a seeder of the packs embedded in the seeds package
+func New(db *repository.SQLRepository) *Seeder {
+	return &Seeder{db: db, packs: seeds.Packs}
 }
 
-func New(db *repository.SQLiteRepository) *Seeder {
-	return &Seeder{db: db}
+// Manifest names and versions a pack
+type Manifest struct {
+	Name        string `json:"name"`
+	Version     int    `json:"version"`
+	Description string `json:"description"`
+	// CourseID is the course of the pack's words, the default course when 0
+	CourseID int64 `json:"course_id"`
 }
 
 type Word struct {
-	ID        int64           `json:"id"`
-	Italian   string         `json:"italian"`
-	English   string         `json:"english"`
-	Parts     json.RawMessage `json:"parts"`
-	CreatedAt string         `json:"created_at"`
+	ID          int64           `json:"id"`
+	Term        string          `json:"term"`
+	Translation string          `json:"translation"`
+	Parts       json.RawMessage `json:"parts"`
+	CreatedAt   string          `json:"created_at"`
 }
 
 type Group struct {
@@ -32,11 +58,20 @@ type Group struct {
 	Level      string `json:"level"`
 }
 
+// WordGroup adds a word to a group. Both are numbered from 1 in the order
+// the pack lists them.
 type WordGroup struct {
 	WordID  int64 `json:"word_id"`
 	GroupID int64 `json:"group_id"`
 }
 
+// Sentence is an example sentence of the word numbered WordID
+type Sentence struct {
+	WordID      int64  `json:"word_id"`
+	Text        string `json:"text"`
+	Translation string `json:"translation"`
+}
+
 type StudyActivity struct {
 	ID           int64   `json:"id"`
 	Name         string  `json:"name"`
@@ -45,134 +80,320 @@ type StudyActivity struct {
 	LaunchURL    *string `json:"launch_url,omitempty"`
 }
 
-func (s *Seeder) SeedFromJSON(seedDir string) error {
+// pack is the content of a pack's files. All of them but pack.json are
+// optional.
+type pack struct {
+	manifest   Manifest
+	groups     []Group
+	words      []Word
+	wordGroups []WordGroup
+	sentences  []Sentence
+	activities []StudyActivity
+}
+
+// applied records the rows a pack's groups, words and word group mappings
+// were stored as, in the order the pack lists them, and which of them
+// applying the pack created
+type applied struct {
+	groupIDs          []int64
+	groupsCreated     []bool
+	wordIDs           []int64
+	wordsCreated      []bool
+	wordGroupsCreated []bool
+}
+
+// Seed applies the packs that were not applied yet, or only at an older
+// version
+func (s *Seeder) Seed(ctx context.Context) error {
+	manifests, err := fs.Glob(s.packs, "*/pack.json")
+	if err != nil {
+		return err
+	}
+
+	for _, manifest := range manifests {
+		fsys, err := fs.Sub(s.packs, path.Dir(manifest))
+		if err != nil {
+			return err
+		}
+		if err := s.seedPack(ctx, fsys); err != nil {
+			return fmt.Errorf("failed to apply seed pack %s: %w", path.Dir(manifest), err)
+		}
+	}
+	return nil
+}
+
+// seedPack applies the seed pack in fsys in one transaction, unless the same
+// or a newer version of it was applied
+func (s *Seeder) seedPack(ctx context.Context, fsys fs.FS) error {
+	p, err := loadPack(fsys)
+	if err != nil {
+		return err
+	}
+
 	// Begin transaction
-	tx, err := s.db.DB().Begin()
+	tx, err := s.db.BeginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	// Seed groups
-	groups, err := s.loadGroups(filepath.Join(seedDir, "groups.json"))
+	var version int
+	err = tx.QueryRowContext(ctx, "SELECT version FROM seed_packs WHERE name = ?", p.manifest.Name).Scan(&version)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if version >= p.manifest.Version {
+		return nil
+	}
+
+	if _, err := applyPack(ctx, tx, p); err != nil {
+		return err
+	}
+
+	// Record the pack as applied
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO seed_packs (name, version) VALUES (?, ?)
+		ON CONFLICT (name) DO UPDATE SET version = excluded.version, applied_at = CURRENT_TIMESTAMP`,
+		p.manifest.Name, p.manifest.Version,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to load groups: %w", err)
+		return fmt.Errorf("failed to record seed pack: %w", err)
 	}
 
-	for _, group := range groups {
-		_, err := tx.Exec(
-			"INSERT INTO groups (name) VALUES (?)",
-			group.Name,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to insert group %s: %w", group.Name, err)
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// loadPack reads and checks the files of the pack in fsys
+func loadPack(fsys fs.FS) (*pack, error) {
+	p := &pack{}
+	if err := loadJSON(fsys, "pack.json", &p.manifest); err != nil {
+		return nil, err
+	}
+	if p.manifest.Name == "" || p.manifest.Version < 1 {
+		return nil, fmt.Errorf("pack.json needs a name and a version from 1")
+	}
+	if p.manifest.CourseID == 0 {
+		p.manifest.CourseID = models.DefaultCourseID
+	}
+
+	files := []struct {
+		name, key string
+		list      interface{}
+	}{
+		{"groups.json", "groups", &p.groups},
+		{"words.json", "words", &p.words},
+		{"words_groups.json", "words_groups", &p.wordGroups},
+		{"sentences.json", "sentences", &p.sentences},
+		{"study_activities.json", "study_activities", &p.activities},
+	}
+	for _, file := range files {
+		if err := loadOptionalJSON(fsys, file.name, file.key, file.list); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, group := range p.groups {
+		if group.Name == "" {
+			return nil, fmt.Errorf("groups.json lists a group without a name")
+		}
+	}
+	for _, word := range p.words {
+		if word.Term == "" || word.Translation == "" {
+			return nil, fmt.Errorf("words.json lists a word without a term or translation")
+		}
+	}
+	for _, wg := range p.wordGroups {
+		if wg.WordID < 1 || wg.WordID > int64(len(p.words)) || wg.GroupID < 1 || wg.GroupID > int64(len(p.groups)) {
+			return nil, fmt.Errorf("word group mapping %d-%d refers to a word or group the pack does not list", wg.WordID, wg.GroupID)
+		}
+	}
+	for _, sentence := range p.sentences {
+		if sentence.WordID < 1 || sentence.WordID > int64(len(p.words)) {
+			return nil, fmt.Errorf("sentence %q refers to word %d, which the pack does not list", sentence.Text, sentence.WordID)
+		}
+		if sentence.Text == "" || sentence.Translation == "" {
+			return nil, fmt.Errorf("sentences.json lists a sentence without a text or translation")
+		}
+	}
+	for _, activity := range p.activities {
+		if activity.Name == "" {
+			return nil, fmt.Errorf("study_activities.json lists an activity without a name")
 		}
 	}
 
-	// Seed words
-	words, err := s.loadWords(filepath.Join(seedDir, "words.json"))
+	// Audio is stored after the pack, so the words it belongs to are checked
+	// before anything is
+	audio, err := fs.Glob(fsys, "audio/*")
 	if err != nil {
-		return fmt.Errorf("failed to load words: %w", err)
+		return nil, err
+	}
+	for _, name := range audio {
+		if info, err := fs.Stat(fsys, name); err != nil || info.IsDir() {
+			continue
+		}
+		base := path.Base(name)
+		word, err := strconv.Atoi(strings.TrimSuffix(base, path.Ext(base)))
+		if err != nil || word < 1 || word > len(p.words) {
+			return nil, fmt.Errorf("%s is not the audio of a word the pack lists", name)
+		}
 	}
+	return p, nil
+}
 
-	for _, word := range words {
-		_, err := tx.Exec(
-			"INSERT INTO words (italian, english, parts) VALUES (?, ?, ?)",
-			word.Italian, word.English, word.Parts,
+// applyPack stores the content of a pack
+func applyPack(ctx context.Context, tx *repository.Tx, p *pack) (*applied, error) {
+	a := &applied{
+		groupIDs:          make([]int64, len(p.groups)),
+		groupsCreated:     make([]bool, len(p.groups)),
+		wordIDs:           make([]int64, len(p.words)),
+		wordsCreated:      make([]bool, len(p.words)),
+		wordGroupsCreated: make([]bool, len(p.wordGroups)),
+	}
+	var err error
+
+	// Seed groups
+	for i, group := range p.groups {
+		a.groupIDs[i], a.groupsCreated[i], err = upsert(ctx, tx,
+			"SELECT id FROM groups WHERE name = ? ORDER BY id LIMIT 1", []interface{}{group.Name},
+			"", nil,
+			"INSERT INTO groups (name) VALUES (?) RETURNING id", []interface{}{group.Name},
 		)
 		if err != nil {
-			return fmt.Errorf("failed to insert word %s: %w", word.Italian, err)
+			return nil, fmt.Errorf("failed to seed group %s: %w", group.Name, err)
 		}
 	}
 
-	// Seed word_groups
-	wordGroups, err := s.loadWordGroups(filepath.Join(seedDir, "words_groups.json"))
-	if err != nil {
-		return fmt.Errorf("failed to load word groups: %w", err)
-	}
-
-	for _, wg := range wordGroups {
-		_, err := tx.Exec(
-			"INSERT INTO words_groups (word_id, group_id) VALUES (?, ?)",
-			wg.WordID, wg.GroupID,
+	// Seed words. A word listed again is seeded as first listed.
+	listed := make(map[[2]string]int, len(p.words))
+	for i, word := range p.words {
+		key := [2]string{word.Term, word.Translation}
+		if first, ok := listed[key]; ok {
+			a.wordIDs[i] = a.wordIDs[first]
+			continue
+		}
+		// Words listed without parts keep the parts they have
+		var parts interface{}
+		if len(word.Parts) > 0 {
+			parts = string(word.Parts)
+		}
+		a.wordIDs[i], a.wordsCreated[i], err = upsert(ctx, tx,
+			"SELECT id FROM words WHERE course_id = ? AND term = ? AND translation = ? ORDER BY id LIMIT 1", []interface{}{p.manifest.CourseID, word.Term, word.Translation},
+			"UPDATE words SET parts = COALESCE(?, parts) WHERE id = ?", []interface{}{parts},
+			"INSERT INTO words (term, translation, parts, course_id) VALUES (?, ?, COALESCE(?, '{}'), ?) RETURNING id", []interface{}{word.Term, word.Translation, parts, p.manifest.CourseID},
 		)
 		if err != nil {
-			return fmt.Errorf("failed to insert word group mapping: %w", err)
+			return nil, fmt.Errorf("failed to seed word %s: %w", word.Term, err)
 		}
+		listed[key] = i
 	}
 
-	// Seed study activities
-	activities, err := s.loadStudyActivities(filepath.Join(seedDir, "study_activities.json"))
-	if err != nil {
-		return fmt.Errorf("failed to load study activities: %w", err)
+	// Seed word_groups
+	for i, wg := range p.wordGroups {
+		wordID, groupID := a.wordIDs[wg.WordID-1], a.groupIDs[wg.GroupID-1]
+		_, a.wordGroupsCreated[i], err = upsert(ctx, tx,
+			"SELECT id FROM words_groups WHERE word_id = ? AND group_id = ? LIMIT 1", []interface{}{wordID, groupID},
+			"", nil,
+			"INSERT INTO words_groups (word_id, group_id) VALUES (?, ?) RETURNING id", []interface{}{wordID, groupID},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to seed word group mapping: %w", err)
+		}
 	}
 
-	for _, activity := range activities {
-		_, err := tx.Exec(
-			"INSERT INTO study_activities (name, thumbnail_url, description, launch_url) VALUES (?, ?, ?, ?)",
-			activity.Name, activity.ThumbnailURL, activity.Description, activity.LaunchURL,
+	// Seed example sentences
+	for _, sentence := range p.sentences {
+		sentenceID, _, err := upsert(ctx, tx,
+			"SELECT id FROM sentences WHERE text = ? AND translation = ? ORDER BY id LIMIT 1", []interface{}{sentence.Text, sentence.Translation},
+			"", nil,
+			"INSERT INTO sentences (text, translation, source) VALUES (?, ?, ?) RETURNING id", []interface{}{sentence.Text, sentence.Translation, models.SentenceSourceImport},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to seed sentence %q: %w", sentence.Text, err)
+		}
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO words_sentences (word_id, sentence_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			a.wordIDs[sentence.WordID-1], sentenceID,
 		)
 		if err != nil {
-			return fmt.Errorf("failed to insert study activity %s: %w", activity.Name, err)
+			return nil, fmt.Errorf("failed to seed sentence %q: %w", sentence.Text, err)
 		}
 	}
 
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	// Seed study activities
+	for _, activity := range p.activities {
+		_, _, err := upsert(ctx, tx,
+			"SELECT id FROM study_activities WHERE name = ? ORDER BY id LIMIT 1", []interface{}{activity.Name},
+			"UPDATE study_activities SET thumbnail_url = ?, description = ?, launch_url = ? WHERE id = ?", []interface{}{activity.ThumbnailURL, activity.Description, activity.LaunchURL},
+			"INSERT INTO study_activities (name, thumbnail_url, description, launch_url) VALUES (?, ?, ?, ?) RETURNING id", []interface{}{activity.Name, activity.ThumbnailURL, activity.Description, activity.LaunchURL},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to seed study activity %s: %w", activity.Name, err)
+		}
 	}
 
-	return nil
+	return a, nil
 }
 
-func (s *Seeder) loadGroups(path string) ([]Group, error) {
-	var data struct {
-		Groups []Group `json:"groups"`
-	}
-	if err := loadJSON(path, &data); err != nil {
-		return nil, err
+// upsert returns the id of the row the find query selects, after running the
+// update query, if any, with the update arguments followed by the id. Without
+// a row, it returns the id of the row the insert query inserts, and true.
+func upsert(ctx context.Context, tx *repository.Tx, find string, findArgs []interface{}, update string, updateArgs []interface{}, insert string, insertArgs []interface{}) (int64, bool, error) {
+	var id int64
+	err := tx.QueryRowContext(ctx, find, findArgs...).Scan(&id)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		err = tx.QueryRowContext(ctx, insert, insertArgs...).Scan(&id)
+		return id, err == nil, err
+	case err != nil:
+		return 0, false, err
+	case update != "":
+		_, err = tx.ExecContext(ctx, update, append(updateArgs, id)...)
 	}
-	return data.Groups, nil
+	return id, false, err
 }
 
-func (s *Seeder) loadWords(path string) ([]Word, error) {
-	var data struct {
-		Words []Word `json:"words"`
+// loadOptionalJSON reads the list under key in the named file of a pack,
+// which packs may leave out
+func loadOptionalJSON(fsys fs.FS, name, key string, list interface{}) error {
+	var data map[string]json.RawMessage
+	err := loadJSON(fsys, name, &data)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
 	}
-	if err := loadJSON(path, &data); err != nil {
-		return nil, err
+	if err != nil {
+		return err
 	}
-	return data.Words, nil
-}
-
-func (s *Seeder) loadWordGroups(path string) ([]WordGroup, error) {
-	var data struct {
-		WordGroups []WordGroup `json:"words_groups"`
+	raw, ok := data[key]
+	if !ok {
+		return nil
 	}
-	if err := loadJSON(path, &data); err != nil {
-		return nil, err
+	if err := json.Unmarshal(raw, list); err != nil {
+		return fmt.Errorf("failed to unmarshal %s from %s: %w", key, name, err)
 	}
-	return data.WordGroups, nil
+	return nil
 }
 
-func (s *Seeder) loadStudyActivities(path string) ([]StudyActivity, error) {
-	var data struct {
-		Activities []StudyActivity `json:"study_activities"`
+func loadJSON(fsys fs.FS, name string, v interface{}) error {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", name, err)
 	}
-	if err := loadJSON(path, &data); err != nil {
-		return nil, err
+	if info.Size() > maxPackFileSize {
+		return fmt.Errorf("file %s is larger than %d MB", name, maxPackFileSize>>20)
 	}
-	return data.Activities, nil
-}
 
-func loadJSON(path string, v interface{}) error {
-	data, err := os.ReadFile(path)
+	data, err := fs.ReadFile(fsys, name)
 	if err != nil {
-		return fmt.Errorf("failed to read file %s: %w", path, err)
+		return fmt.Errorf("failed to read file %s: %w", name, err)
 	}
 
 	if err := json.Unmarshal(data, v); err != nil {
-		return fmt.Errorf("failed to unmarshal JSON from %s: %w", path, err)
+		return fmt.Errorf("failed to unmarshal JSON from %s: %w", name, err)
 	}
 
 	return nil
@@ -0,0 +1,101 @@
+package seeder
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+)
+
+func newTestSeeder(t *testing.T) (*Seeder, *repository.SQLRepository) {
+	t.Helper()
+	db, err := repository.NewDB(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, db.CreateTables(context.Background()))
+	return New(db), db
+}
+
+func count(t *testing.T, db *repository.SQLRepository, table string) int {
+	t.Helper()
+	var n int
+	require.NoError(t, db.DB().QueryRow("SELECT COUNT(*) FROM "+table).Scan(&n))
+	return n
+}
+
+func TestSeed(t *testing.T) {
+	ctx := context.Background()
+	s, db := newTestSeeder(t)
+
+	require.NoError(t, s.Seed(ctx))
+	counts := map[string]int{}
+	for _, table := range []string{"groups", "words", "words_groups", "study_activities", "seed_packs"} {
+		counts[table] = count(t, db, table)
+		assert.NotZero(t, counts[table], table)
+	}
+
+	// Seeding again, even once the packs are forgotten, adds nothing
+	require.NoError(t, s.Seed(ctx))
+	_, err := db.DB().Exec("DELETE FROM seed_packs")
+	require.NoError(t, err)
+	require.NoError(t, s.Seed(ctx))
+	for table, n := range counts {
+		assert.Equal(t, n, count(t, db, table), table)
+	}
+}
+
+func TestSeed_NewVersion(t *testing.T) {
+	ctx := context.Background()
+	s, db := newTestSeeder(t)
+
+	pack := fstest.MapFS{
+		"kitchen/pack.json":   {Data: []byte(`{"name": "kitchen", "version": 1}`)},
+		"kitchen/groups.json": {Data: []byte(`{"groups": [{"name": "Kitchen"}]}`)},
+		"kitchen/words.json": {Data: []byte(`{"words": [
+			{"term": "pentola", "translation": "pot", "parts": {"type": "noun"}}
+		]}`)},
+		"kitchen/words_groups.json": {Data: []byte(`{"words_groups": [{"word_id": 1, "group_id": 1}]}`)},
+	}
+	s.packs = pack
+	require.NoError(t, s.Seed(ctx))
+
+	// Applied versions are skipped
+	pack["kitchen/words.json"].Data = []byte(`{"words": [
+		{"term": "pentola", "translation": "pot", "parts": {"type": "noun", "gender": "feminine"}},
+		{"term": "padella", "translation": "pan", "parts": {"type": "noun"}}
+	]}`)
+	require.NoError(t, s.Seed(ctx))
+	assert.Equal(t, 1, count(t, db, "words"))
+
+	// Newer versions update what the pack seeded and add what is new
+	pack["kitchen/pack.json"].Data = []byte(`{"name": "kitchen", "version": 2}`)
+	pack["kitchen/words_groups.json"].Data = []byte(`{"words_groups": [{"word_id": 1, "group_id": 1}, {"word_id": 2, "group_id": 1}]}`)
+	require.NoError(t, s.Seed(ctx))
+	assert.Equal(t, 2, count(t, db, "words"))
+	assert.Equal(t, 1, count(t, db, "groups"))
+	assert.Equal(t, 2, count(t, db, "words_groups"))
+
+	var parts string
+	require.NoError(t, db.DB().QueryRow("SELECT parts FROM words WHERE term = 'pentola'").Scan(&parts))
+	assert.JSONEq(t, `{"type": "noun", "gender": "feminine"}`, parts)
+	var version int
+	require.NoError(t, db.DB().QueryRow("SELECT version FROM seed_packs WHERE name = 'kitchen'").Scan(&version))
+	assert.Equal(t, 2, version)
+}
+
+func TestSeed_InvalidPack(t *testing.T) {
+	s, db := newTestSeeder(t)
+	s.packs = fstest.MapFS{
+		"broken/pack.json":         {Data: []byte(`{"name": "broken", "version": 1}`)},
+		"broken/groups.json":       {Data: []byte(`{"groups": [{"name": "Broken"}]}`)},
+		"broken/words_groups.json": {Data: []byte(`{"words_groups": [{"word_id": 3, "group_id": 1}]}`)},
+	}
+
+	assert.Error(t, s.Seed(context.Background()))
+	assert.Zero(t, count(t, db, "groups"))
+	assert.Zero(t, count(t, db, "seed_packs"))
+}
@@ -5,15 +5,15 @@ from datetime import datetime, timedelta
 # A1 Level basic words (sample - you'll need to expand this)
 a1_words = {
     "Greetings and Basic Expressions": [
-        {"italian": "ciao", "english": "hello/bye", "parts": {"type": "interjection", "usage": ["greeting", "farewell"]}},
-        {"italian": "grazie", "english": "thank you", "parts": {"type": "interjection", "usage": ["gratitude"]}},
-        {"italian": "prego", "english": "you're welcome", "parts": {"type": "interjection", "usage": ["response"]}},
+        {"term": "ciao", "translation": "hello/bye", "parts": {"type": "interjection", "usage": ["greeting", "farewell"]}},
+        {"term": "grazie", "translation": "thank you", "parts": {"type": "interjection", "usage": ["gratitude"]}},
+        {"term": "prego", "translation": "you're welcome", "parts": {"type": "interjection", "usage": ["response"]}},
         # Add more words here
     ],
     "Numbers and Time": [
-        {"italian": "uno", "english": "one", "parts": {"type": "number"}},
-        {"italian": "due", "english": "two", "parts": {"type": "number"}},
-        {"italian": "ora", "english": "hour", "parts": {"type": "noun", "gender": "feminine"}},
+        {"term": "uno", "translation": "one", "parts": {"type": "number"}},
+        {"term": "due", "translation": "two", "parts": {"type": "number"}},
+        {"term": "ora", "translation": "hour", "parts": {"type": "noun", "gender": "feminine"}},
         # Add more words here
     ],
     # Add more categories
@@ -22,13 +22,13 @@ a1_words = {
 # A2 Level words (sample - you'll need to expand this)
 a2_words = {
     "House and Furniture": [
-        {"italian": "tavolo", "english": "table", "parts": {"type": "noun", "gender": "masculine"}},
-        {"italian": "sedia", "english": "chair", "parts": {"type": "noun", "gender": "feminine"}},
+        {"term": "tavolo", "translation": "table", "parts": {"type": "noun", "gender": "masculine"}},
+        {"term": "sedia", "translation": "chair", "parts": {"type": "noun", "gender": "feminine"}},
         # Add more words here
     ],
     "Travel and Transportation": [
-        {"italian": "biglietto", "english": "ticket", "parts": {"type": "noun", "gender": "masculine"}},
-        {"italian": "treno", "english": "train", "parts": {"type": "noun", "gender": "masculine"}},
+        {"term": "biglietto", "translation": "ticket", "parts": {"type": "noun", "gender": "masculine"}},
+        {"term": "treno", "translation": "train", "parts": {"type": "noun", "gender": "masculine"}},
         # Add more words here
     ],
     # Add more categories
@@ -43,8 +43,8 @@ def generate_word_data():
         for word in word_list:
             word_data = {
                 "id": word_id,
-                "italian": word["italian"],
-                "english": word["english"],
+                "term": word["term"],
+                "translation": word["translation"],
                 "parts": word["parts"],
                 "created_at": (datetime.now() - timedelta(days=random.randint(0, 30))).isoformat()
             }
@@ -56,8 +56,8 @@ def generate_word_data():
         for word in word_list:
             word_data = {
                 "id": word_id,
-                "italian": word["italian"],
-                "english": word["english"],
+                "term": word["term"],
+                "translation": word["translation"],
                 "parts": word["parts"],
                 "created_at": (datetime.now() - timedelta(days=random.randint(0, 30))).isoformat()
             }
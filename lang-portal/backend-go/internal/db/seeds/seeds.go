@@ -0,0 +1,11 @@
+// Package seeds embeds the seed packs the database is seeded with. Each
+// directory is a pack: a pack.json manifest with the pack's name and version,
+// and the groups, words and study activities it provides.
+package seeds
+
+import "embed"
+
+// Packs holds one directory per seed pack
+//
+//go:embed */*.json
+var Packs embed.FS
@@ -33,8 +33,8 @@ type StudySession struct {
 
 type Word struct {
 	ID           int64         `json:"id"`
-	Italian      string        `json:"italian"`
-	English      string        `json:"english"`
+	Term         string        `json:"term"`
+	Translation  string        `json:"translation"`
 	Parts        interface{}   `json:"parts"`
 	CreatedAt    sql.NullTime  `json:"created_at"`
 	CorrectCount sql.NullInt64 `json:"correct_count"`
@@ -11,26 +11,26 @@ import (
 
 const createWord = `-- name: CreateWord :one
 INSERT INTO words (
-  italian, english, parts
+  term, translation, parts
 ) VALUES (
   ?, ?, ?
 )
-RETURNING id, italian, english, parts, created_at, correct_count, wrong_count
+RETURNING id, term, translation, parts, created_at, correct_count, wrong_count
 `
 
 type CreateWordParams struct {
-	Italian string      `json:"italian"`
-	English string      `json:"english"`
-	Parts   interface{} `json:"parts"`
+	Term        string      `json:"term"`
+	Translation string      `json:"translation"`
+	Parts       interface{} `json:"parts"`
 }
 
 func (q *Queries) CreateWord(ctx context.Context, arg CreateWordParams) (Word, error) {
-	row := q.db.QueryRowContext(ctx, createWord, arg.Italian, arg.English, arg.Parts)
+	row := q.db.QueryRowContext(ctx, createWord, arg.Term, arg.Translation, arg.Parts)
 	var i Word
 	err := row.Scan(
 		&i.ID,
-		&i.Italian,
-		&i.English,
+		&i.Term,
+		&i.Translation,
 		&i.Parts,
 		&i.CreatedAt,
 		&i.CorrectCount,
@@ -50,7 +50,7 @@ func (q *Queries) DeleteWord(ctx context.Context, id int64) error {
 }
 
 const getWord = `-- name: GetWord :one
-SELECT id, italian, english, parts, created_at, correct_count, wrong_count FROM words
+SELECT id, term, translation, parts, created_at, correct_count, wrong_count FROM words
 WHERE id = ? LIMIT 1
 `
 
@@ -59,8 +59,8 @@ func (q *Queries) GetWord(ctx context.Context, id int64) (Word, error) {
 	var i Word
 	err := row.Scan(
 		&i.ID,
-		&i.Italian,
-		&i.English,
+		&i.Term,
+		&i.Translation,
 		&i.Parts,
 		&i.CreatedAt,
 		&i.CorrectCount,
@@ -70,7 +70,7 @@ func (q *Queries) GetWord(ctx context.Context, id int64) (Word, error) {
 }
 
 const listWords = `-- name: ListWords :many
-SELECT id, italian, english, parts, created_at, correct_count, wrong_count FROM words
+SELECT id, term, translation, parts, created_at, correct_count, wrong_count FROM words
 ORDER BY id
 `
 
@@ -85,8 +85,8 @@ func (q *Queries) ListWords(ctx context.Context) ([]Word, error) {
 		var i Word
 		if err := rows.Scan(
 			&i.ID,
-			&i.Italian,
-			&i.English,
+			&i.Term,
+			&i.Translation,
 			&i.Parts,
 			&i.CreatedAt,
 			&i.CorrectCount,
@@ -107,30 +107,30 @@ func (q *Queries) ListWords(ctx context.Context) ([]Word, error) {
 
 const updateWord = `-- name: UpdateWord :one
 UPDATE words
-SET italian = ?, english = ?, parts = ?
+SET term = ?, translation = ?, parts = ?
 WHERE id = ?
-RETURNING id, italian, english, parts, created_at, correct_count, wrong_count
+RETURNING id, term, translation, parts, created_at, correct_count, wrong_count
 `
 
 type UpdateWordParams struct {
-	Italian string      `json:"italian"`
-	English string      `json:"english"`
-	Parts   interface{} `json:"parts"`
-	ID      int64       `json:"id"`
+	Term        string      `json:"term"`
+	Translation string      `json:"translation"`
+	Parts       interface{} `json:"parts"`
+	ID          int64       `json:"id"`
 }
 
 func (q *Queries) UpdateWord(ctx context.Context, arg UpdateWordParams) (Word, error) {
 	row := q.db.QueryRowContext(ctx, updateWord,
-		arg.Italian,
-		arg.English,
+		arg.Term,
+		arg.Translation,
 		arg.Parts,
 		arg.ID,
 	)
 	var i Word
 	err := row.Scan(
 		&i.ID,
-		&i.Italian,
-		&i.English,
+		&i.Term,
+		&i.Translation,
 		&i.Parts,
 		&i.CreatedAt,
 		&i.CorrectCount,
@@ -0,0 +1,79 @@
+package models
+
+// TextAnalysisRequest represents a text to check against the vocabulary
+// swagger:model
+type TextAnalysisRequest struct {
+	// The text to analyze, e.g. a pasted article or transcript
+	// required: true
+	Text string `json:"text" binding:"required,max=100000" example:"L'amico di mia sorella parla italiano."`
+	// Course whose vocabulary the text is checked against, defaults to the Italian course
+	CourseID int64 `json:"course_id,omitempty" example:"1"`
+}
+
+// TextAnalysisResponse represents which words of a text are in the vocabulary
+// swagger:model
+type TextAnalysisResponse struct {
+	// Number of words in the text; elisions like l'amico count as two
+	TotalTokens int `json:"total_tokens" example:"7"`
+	// Number of words that are forms of vocabulary entries
+	KnownTokens int `json:"known_tokens" example:"3"`
+	// Percentage of words that are known
+	Coverage float64 `json:"coverage" example:"42.9"`
+	// Vocabulary entries found in the text, in order of first appearance
+	KnownWords []KnownWordResponse `json:"known_words"`
+	// Words not in the vocabulary, most frequent first
+	UnknownTokens []UnknownTokenResponse `json:"unknown_tokens"`
+}
+
+// KnownWordResponse represents a vocabulary entry found in an analyzed text
+type KnownWordResponse struct {
+	WordID      int64  `json:"word_id" example:"12"`
+	Term        string `json:"term" example:"sorella"`
+	Translation string `json:"translation" example:"sister"`
+	// The forms of the word that appear in the text
+	Forms []string `json:"forms" example:"sorella,sorelle"`
+	// How often the word appears in the text
+	Occurrences  int `json:"occurrences" example:"2"`
+	CorrectCount int `json:"correct_count" example:"5"`
+	WrongCount   int `json:"wrong_count" example:"1"`
+	// Percentage of the word's reviews answered correctly, 0 when never reviewed
+	Mastery float64 `json:"mastery" example:"83.3"`
+}
+
+// UnknownTokenResponse represents a word of an analyzed text that is not in the vocabulary
+type UnknownTokenResponse struct {
+	Token       string `json:"token" example:"amico"`
+	Occurrences int    `json:"occurrences" example:"1"`
+}
+
+// AddUnknownWordsRequest represents unknown words of an analyzed text to add to a group
+// swagger:model
+type AddUnknownWordsRequest struct {
+	// required: true
+	GroupID int64 `json:"group_id" binding:"required" example:"1"`
+	// Course the words belong to, defaults to the Italian course
+	CourseID int64 `json:"course_id,omitempty" example:"1"`
+	// required: true
+	Words []UnknownWordSelection `json:"words" binding:"required,min=1,max=500,dive"`
+}
+
+// UnknownWordSelection is an unknown token chosen to be learned, with its translation
+type UnknownWordSelection struct {
+	// required: true
+	Term string `json:"term" binding:"required" example:"amico"`
+	// required: true
+	Translation string `json:"translation" binding:"required" example:"friend"`
+	// Grammatical details like type, gender, plural form
+	Parts map[string]interface{} `json:"parts,omitempty"`
+}
+
+// AddUnknownWordsResponse represents the outcome of adding unknown words to a group
+// swagger:model
+type AddUnknownWordsResponse struct {
+	// Number of words added to the vocabulary and the group
+	AddedCount int `json:"added_count" example:"2"`
+	// Words that turned out to be forms of existing vocabulary entries
+	AlreadyKnown []string `json:"already_known"`
+	// Words rejected because they are not written in the course's languages
+	SkippedCount int `json:"skipped_count" example:"0"`
+}
@@ -0,0 +1,27 @@
+package models
+
+// WordAudio is a cached pronunciation recording of a word
+type WordAudio struct {
+	// Path of the audio file in the audio cache
+	Path string
+	// MIME type of the audio, e.g. audio/wav
+	ContentType string
+}
+
+// GenerateAudioJobPayload is the input of a generate_audio job
+type GenerateAudioJobPayload struct {
+	// The group whose words get pronunciation audio
+	GroupID int64 `json:"group_id" example:"1"`
+	// The TTS voice to use (default: TTS_VOICE)
+	Voice string `json:"voice,omitempty" example:"default"`
+}
+
+// GenerateAudioJobResult is the output of a generate_audio job
+type GenerateAudioJobResult struct {
+	// Words whose audio was synthesized by this job
+	Generated int `json:"generated"`
+	// Words whose audio was already cached
+	Cached int `json:"cached"`
+	// Words whose audio could not be synthesized
+	Failed int `json:"failed"`
+}
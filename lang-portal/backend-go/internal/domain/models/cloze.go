@@ -0,0 +1,32 @@
+package models
+
+// ClozeCardResponse represents a fill-in-the-blank card built from an example sentence
+// swagger:model
+type ClozeCardResponse struct {
+	StudySessionID int64 `json:"study_session_id" example:"1"`
+	WordID         int64 `json:"word_id" example:"1"`
+	SentenceID     int64 `json:"sentence_id" example:"1"`
+	// The sentence with the target word blanked out
+	Prompt string `json:"prompt" example:"Mia ____ vive a Roma."`
+	// Translation of the whole sentence
+	Translation string `json:"translation" example:"My sister lives in Rome."`
+	// Translation of the missing word
+	Hint string `json:"hint" example:"sister"`
+}
+
+// ClozeAnswerRequest represents an answer to a cloze card
+// swagger:model
+type ClozeAnswerRequest struct {
+	SentenceID int64  `json:"sentence_id" binding:"required" example:"1"`
+	Answer     string `json:"answer" binding:"required" example:"sorella"`
+}
+
+// ClozeAnswerResponse represents the graded result of a cloze answer
+// swagger:model
+type ClozeAnswerResponse struct {
+	WordID   int64  `json:"word_id" example:"1"`
+	Correct  bool   `json:"correct" example:"true"`
+	Expected string `json:"expected" example:"sorella"`
+	// The full sentence
+	Sentence string `json:"sentence" example:"Mia sorella vive a Roma."`
+}
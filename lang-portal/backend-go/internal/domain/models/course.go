@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// DefaultCourseID is the Italian for English speakers course that words
+// created before courses existed belong to
+const DefaultCourseID int64 = 1
+
+// Writing systems used to validate terms and sentences of a language
+const (
+	ScriptLatin    = "latin"
+	ScriptCyrillic = "cyrillic"
+	ScriptGreek    = "greek"
+	ScriptArabic   = "arabic"
+	ScriptHan      = "han"
+	ScriptHangul   = "hangul"
+	ScriptJapanese = "japanese"
+)
+
+// LanguageResponse represents a language that courses can teach or translate into
+// swagger:model
+type LanguageResponse struct {
+	// ISO 639-1 code
+	// required: true
+	Code string `json:"code" example:"it"`
+	// required: true
+	Name string `json:"name" example:"Italian"`
+	// The writing system terms in this language must use
+	// required: true
+	Script string `json:"script" example:"latin"`
+}
+
+type LanguageListResponse struct {
+	Items []LanguageResponse `json:"items"`
+}
+
+// CreateLanguageRequest represents a request to add a language
+// swagger:model
+type CreateLanguageRequest struct {
+	Code   string `json:"code" binding:"required,alpha,min=2,max=3" example:"fr"`
+	Name   string `json:"name" binding:"required" example:"French"`
+	Script string `json:"script" binding:"required,oneof=latin cyrillic greek arabic han hangul japanese" example:"latin"`
+}
+
+// CourseResponse represents a course teaching the target language to speakers
+// of the source language
+// swagger:model
+type CourseResponse struct {
+	// required: true
+	ID int64 `json:"id" example:"1"`
+	// required: true
+	Name string `json:"name" example:"Italian for English speakers"`
+	// The language learners already speak, used for translations
+	// required: true
+	SourceLanguage LanguageResponse `json:"source_language"`
+	// The language being learned
+	// required: true
+	TargetLanguage LanguageResponse `json:"target_language"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+type CourseListResponse struct {
+	Items []CourseResponse `json:"items"`
+}
+
+// CreateCourseRequest represents a request to add a course
+// swagger:model
+type CreateCourseRequest struct {
+	Name string `json:"name" binding:"required" example:"Spanish for English speakers"`
+	// Code of the language learners already speak
+	SourceLanguage string `json:"source_language" binding:"required" example:"en"`
+	// Code of the language being learned
+	TargetLanguage string `json:"target_language" binding:"required" example:"es"`
+}
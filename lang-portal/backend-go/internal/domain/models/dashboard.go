@@ -23,4 +23,7 @@ type DashboardQuickStats struct {
 	TotalStudySessions int     `json:"total_study_sessions"`
 	TotalActiveGroups  int     `json:"total_active_groups"`
 	StudyStreakDays    int     `json:"study_streak_days"`
-}
+	// Listening comprehension answers of the last 30 days
+	ListeningQuestionsAnswered int     `json:"listening_questions_answered"`
+	ListeningSuccessRate       float64 `json:"listening_success_rate"`
+}
\ No newline at end of file
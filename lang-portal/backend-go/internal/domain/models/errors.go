@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// Kinds of domain errors. Repositories and services return them wrapped in an
+// *Error, and the API answers each kind with its own status; test them with
+// errors.Is.
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrConflict      = errors.New("conflict")
+	ErrPrecondition  = errors.New("precondition failed")
+	ErrValidation    = errors.New("validation failed")
+	ErrLimitExceeded = errors.New("limit exceeded")
+	ErrTooLarge      = errors.New("too large")
+	ErrUnavailable   = errors.New("unavailable")
+	ErrUpstream      = errors.New("upstream service failed")
+)
+
+// Error is a domain error with a stable code that clients can rely on, unlike
+// its message
+type Error struct {
+	// Kind is one of the Err* kinds above
+	Kind error
+	// Code identifies the error, e.g. "group_not_found"
+	Code string
+	// Message describes the error to the client
+	Message string
+	// Err is the underlying cause, logged but not shown to clients
+	Err error
+}
+
+// NewError returns an error of the given kind
+func NewError(kind error, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// WrapError returns an error of the given kind caused by err
+func WrapError(kind error, code, message string, err error) *Error {
+	return &Error{Kind: kind, Code: code, Message: message, Err: err}
+}
+
+// NotFoundError reports that a resource, e.g. "group", does not exist. Its
+// code is the resource followed by _not_found.
+func NotFoundError(resource string) *Error {
+	return NewError(ErrNotFound, strings.ReplaceAll(resource, " ", "_")+"_not_found", resource+" not found")
+}
+
+// ValidationError reports invalid input
+func ValidationError(code, message string) *Error {
+	return NewError(ErrValidation, code, message)
+}
+
+// ConflictError reports a request that conflicts with the current state
+func ConflictError(code, message string) *Error {
+	return NewError(ErrConflict, code, message)
+}
+
+// VersionMismatchError reports a write conditioned on a data version that
+// has changed since the client read it
+func VersionMismatchError() *Error {
+	return NewError(ErrPrecondition, "version_mismatch", "the data changed since it was read; reload it and try again")
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Is reports whether target is the kind of the error
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -11,6 +11,14 @@ type GroupListResponse struct {
 	Pagination PaginationResponse `json:"pagination"`
 }
 
+// GroupSortFields are the fields a group list can be sorted by
+var GroupSortFields = []string{"id", "name", "word_count"}
+
+// UpdateGroupRequest renames a group
+type UpdateGroupRequest struct {
+	Name string `json:"name" binding:"required" example:"Food and drinks"`
+}
+
 type GroupDetailResponse struct {
 	ID        int64 `json:"id"`
 	Name      string `json:"name"`
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Background job types
+const (
+	JobTypeGenerateWords     = "generate_words"
+	JobTypeGenerateSentences = "generate_sentences"
+	JobTypeImportWords       = "import_words"
+	JobTypeGenerateAudio     = "generate_audio"
+)
+
+// Background job statuses
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+// Job is a long-running task executed by the background workers
+// swagger:model
+type Job struct {
+	// The unique identifier of the job
+	// required: true
+	ID int64 `json:"id" example:"1"`
+	// The kind of work (generate_words, generate_sentences, import_words, generate_audio)
+	// required: true
+	Type string `json:"type" example:"generate_words"`
+	// pending, running, completed, failed or cancelled
+	// required: true
+	Status string `json:"status" example:"running"`
+	// The job input, shaped by the job type
+	Payload json.RawMessage `json:"payload" swaggertype:"object"`
+	// Completion percentage from 0 to 100
+	// required: true
+	Progress int `json:"progress" example:"40"`
+	// The job output once completed, shaped by the job type
+	Result json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	// Why the job failed
+	Error      *string    `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+type JobListResponse struct {
+	Items      []Job              `json:"items"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+// CreateJobRequest represents a request to queue a background job
+// swagger:model
+type CreateJobRequest struct {
+	// The kind of work to run
+	// required: true
+	Type string `json:"type" binding:"required,oneof=generate_words generate_sentences import_words generate_audio" example:"generate_words"`
+	// The job input: GenerateWordsJobPayload, GenerateSentencesJobPayload, ImportWordsRequest or GenerateAudioJobPayload
+	// required: true
+	Payload json.RawMessage `json:"payload" binding:"required" swaggertype:"object"`
+}
+
+// GenerateWordsJobPayload is the input of a generate_words job
+type GenerateWordsJobPayload struct {
+	GenerateWordsRequest
+	// When set, the generated words are added to this group
+	GroupID *int64    `json:"group_id,omitempty" example:"1"`
+	Cache   CacheMode `json:"cache,omitempty" example:"refresh"`
+}
+
+// GenerateSentencesJobPayload is the input of a generate_sentences job
+type GenerateSentencesJobPayload struct {
+	WordID int64     `json:"word_id" binding:"required" example:"1"`
+	Count  int       `json:"count,omitempty" example:"3"`
+	Cache  CacheMode `json:"cache,omitempty" example:"refresh"`
+}
+
+// GenerateWordsJobResult is the output of a generate_words job
+type GenerateWordsJobResult struct {
+	Words      []WordResponse `json:"words"`
+	WordsAdded int            `json:"words_added"`
+}
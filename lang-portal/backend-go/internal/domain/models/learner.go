@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Learner is the profile of the portal's single learner. There are no
+// passwords or logins; the portal serves whoever can reach it.
+type Learner struct {
+	Name      string    `json:"name" example:"Giulia"`
+	Email     *string   `json:"email,omitempty" example:"giulia@example.com"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateLearnerRequest holds the profile of the learner to create
+type CreateLearnerRequest struct {
+	Name  string
+	Email string
+}
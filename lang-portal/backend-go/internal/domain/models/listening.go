@@ -0,0 +1,142 @@
+package models
+
+import "time"
+
+// ListeningExercise is a listening comprehension exercise built from a video
+// by the listening-comp pipeline
+type ListeningExercise struct {
+	ID        int64   `json:"id" example:"1"`
+	CourseID  int64   `json:"course_id" example:"1"`
+	Title     string  `json:"title" example:"Italian for Beginners - Roma Metro Overview"`
+	Level     string  `json:"level" example:"A1"`
+	Topic     string  `json:"topic" example:"Using Public Transport in Italy"`
+	Context   string  `json:"context"`
+	SourceURL *string `json:"source_url,omitempty" example:"https://www.youtube.com/watch?v=xo2JBr0x58Q"`
+	// Part of the video the exercise covers, in milliseconds
+	StartMs       *int64    `json:"start_ms,omitempty" example:"0"`
+	EndMs         *int64    `json:"end_ms,omitempty" example:"330000"`
+	SegmentCount  int       `json:"segment_count" example:"6"`
+	QuestionCount int       `json:"question_count" example:"3"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ListeningSegment is a line of an exercise's transcript
+type ListeningSegment struct {
+	ID          int64  `json:"id" example:"1"`
+	Position    int    `json:"position" example:"1"`
+	Speaker     string `json:"speaker" example:"Narrator"`
+	Text        string `json:"text" example:"Vive a Milano, una città grande e bellissima."`
+	Translation string `json:"translation" example:"He lives in Milan, a large and beautiful city."`
+	StartMs     *int64 `json:"start_ms,omitempty" example:"10000"`
+	// Start of the next segment, or the end of the exercise for the last one
+	EndMs      *int64   `json:"end_ms,omitempty" example:"25000"`
+	KeyPhrases []string `json:"key_phrases"`
+}
+
+// ListeningQuestion is a multiple-choice question about an exercise. The
+// correct option is only revealed once the question is answered.
+type ListeningQuestion struct {
+	ID         int64    `json:"id" example:"1"`
+	ExerciseID int64    `json:"exercise_id" example:"1"`
+	Position   int      `json:"position" example:"1"`
+	Question   string   `json:"question" example:"Dove vive Marco?"`
+	Options    []string `json:"options" example:"Roma,Milano,Torino"`
+	// Where in the video the answer is heard
+	StartMs       *int64 `json:"start_ms,omitempty" example:"10000"`
+	CorrectOption int    `json:"-"`
+}
+
+// ListeningExerciseListResponse represents a paginated list of listening exercises
+// swagger:model
+type ListeningExerciseListResponse struct {
+	Items      []ListeningExercise `json:"items"`
+	Pagination PaginationResponse  `json:"pagination"`
+}
+
+// ListeningExerciseResponse represents a listening exercise with its
+// transcript and questions
+// swagger:model
+type ListeningExerciseResponse struct {
+	ListeningExercise
+	Segments  []ListeningSegment  `json:"segments"`
+	Questions []ListeningQuestion `json:"questions"`
+}
+
+// ListeningAnswerRequest represents a learner's answer to a listening question
+// swagger:model
+type ListeningAnswerRequest struct {
+	QuestionID int64 `json:"question_id" binding:"required" example:"1"`
+	// Index of the chosen option
+	SelectedOption *int `json:"selected_option" binding:"required,min=0" example:"1"`
+}
+
+// ListeningAnswerResponse represents a graded answer to a listening question
+// swagger:model
+type ListeningAnswerResponse struct {
+	QuestionID     int64 `json:"question_id" example:"1"`
+	SelectedOption int   `json:"selected_option" example:"1"`
+	CorrectOption  int   `json:"correct_option" example:"1"`
+	Correct        bool  `json:"correct" example:"true"`
+}
+
+// ListeningImportOptions are the settings of an import that are not part of
+// the pipeline output
+type ListeningImportOptions struct {
+	// Course of the exercise; the default course when 0
+	CourseID int64
+	// Group to add the exercise's vocabulary to; vocabulary is not imported when 0
+	GroupID   int64
+	SourceURL string
+}
+
+// ListeningImportResponse represents the result of importing pipeline output
+// swagger:model
+type ListeningImportResponse struct {
+	ExerciseID int64 `json:"exercise_id" example:"1"`
+	// False when the same output had already been imported
+	Created       bool                     `json:"created" example:"true"`
+	SegmentCount  int                      `json:"segment_count" example:"6"`
+	QuestionCount int                      `json:"question_count" example:"3"`
+	Vocabulary    *AddUnknownWordsResponse `json:"vocabulary,omitempty"`
+}
+
+// ListeningPipelineOutput is the structured JSON the listening-comp pipeline
+// writes to output/structured-data
+// swagger:model
+type ListeningPipelineOutput struct {
+	Metadata   ListeningPipelineMetadata     `json:"metadata" binding:"required"`
+	Dialogue   []ListeningPipelineLine       `json:"dialogue" binding:"dive"`
+	Vocabulary []ListeningPipelineVocabulary `json:"vocabulary" binding:"dive"`
+	Exercises  []ListeningPipelineExercise   `json:"exercises" binding:"dive"`
+}
+
+type ListeningPipelineMetadata struct {
+	VideoTitle     string `json:"video_title" binding:"required" example:"Italian for Beginners - Roma Metro Overview"`
+	LanguageLevel  string `json:"language_level" example:"A1"`
+	Topic          string `json:"topic" example:"Using Public Transport in Italy"`
+	VideoTimestamp string `json:"video_timestamp" example:"00:00-05:30"`
+	Context        string `json:"context"`
+}
+
+type ListeningPipelineLine struct {
+	Speaker            string   `json:"speaker" example:"Narrator"`
+	ItalianText        string   `json:"italian_text" binding:"required" example:"Vive a Milano."`
+	EnglishTranslation string   `json:"english_translation" example:"He lives in Milan."`
+	AudioTimestamp     string   `json:"audio_timestamp" example:"00:10"`
+	KeyPhrases         []string `json:"key_phrases"`
+}
+
+type ListeningPipelineVocabulary struct {
+	ItalianTerm        string `json:"italian_term" binding:"required" example:"metropolitana"`
+	PartOfSpeech       string `json:"part_of_speech" example:"noun"`
+	EnglishTranslation string `json:"english_translation" binding:"required" example:"metro, subway"`
+	ExampleSentence    string `json:"example_sentence"`
+}
+
+type ListeningPipelineExercise struct {
+	Type           string   `json:"type" example:"multiple_choice"`
+	Question       string   `json:"question" binding:"required" example:"Dove vive Marco?"`
+	Options        []string `json:"options" example:"Roma,Milano,Torino"`
+	CorrectAnswer  string   `json:"correct_answer" example:"Milano"`
+	AudioTimestamp string   `json:"audio_timestamp" example:"00:10"`
+}
@@ -1,19 +1,82 @@
 package models
 
+import "time"
+
+// Chat message roles understood by the LLM provider
+const (
+	ChatRoleSystem    = "system"
+	ChatRoleUser      = "user"
+	ChatRoleAssistant = "assistant"
+)
+
+// ChatMessage is a single message in an LLM conversation
+type ChatMessage struct {
+	Role    string `json:"role" example:"user"`
+	Content string `json:"content" example:"How do I say 'I eat an apple'?"`
+}
+
+// CacheMode controls how an LLM request uses the completion cache
+type CacheMode string
+
+const (
+	// CacheModeDefault serves cached completions and stores new ones
+	CacheModeDefault CacheMode = ""
+	// CacheModeBypass neither reads nor writes the cache
+	CacheModeBypass CacheMode = "bypass"
+	// CacheModeRefresh skips the cached completion but stores the new one
+	CacheModeRefresh CacheMode = "refresh"
+)
+
+// LLMCacheEntry is a stored LLM completion
+type LLMCacheEntry struct {
+	Key       string    `json:"key"`
+	Provider  string    `json:"provider"`
+	Model     string    `json:"model"`
+	Response  string    `json:"response"`
+	Hits      int64     `json:"hits"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LLMCacheStatsResponse reports how effective the completion cache is
+// swagger:model
+type LLMCacheStatsResponse struct {
+	// Cache hits since the server started
+	Hits int64 `json:"hits" example:"12"`
+	// Cache misses since the server started
+	Misses int64 `json:"misses" example:"4"`
+	// Requests that skipped the cache lookup (bypass or refresh) since the server started
+	Skipped int64 `json:"skipped" example:"1"`
+	// Unexpired completions currently stored
+	Entries int `json:"entries" example:"16"`
+	// Hits recorded against the stored completions, including previous runs
+	StoredHits int64 `json:"stored_hits" example:"40"`
+}
+
 // GenerateWordsRequest represents a request to generate words for a thematic category
 // swagger:model
 type GenerateWordsRequest struct {
 	// The thematic category for word generation (e.g., "family members", "food", etc.)
 	// required: true
 	Category string `json:"category" binding:"required" example:"family members"`
+	// How many words to generate, defaults to 10
+	Count int `json:"count,omitempty" binding:"omitempty,min=1,max=50" example:"10"`
+	// Optional CEFR level the words should suit
+	Level string `json:"level,omitempty" binding:"omitempty,oneof=A1 A2 B1 B2 C1 C2" example:"A1"`
+	// Optional part of speech to restrict the words to
+	PartOfSpeech string `json:"part_of_speech,omitempty" example:"noun"`
+	// Course to generate words for, defaults to the Italian course
+	CourseID int64 `json:"course_id,omitempty" example:"1"`
 }
 
 // GenerateWordsResponse represents the response from the LLM with generated words
 // swagger:model
 type GenerateWordsResponse struct {
-	// List of generated Italian words with translations and grammatical details
+	// List of generated words with translations and grammatical details
 	// required: true
 	Words []WordResponse `json:"words"`
+	// The prompt template version that produced the words
+	PromptVersion string `json:"prompt_version" example:"generate_words@v2"`
 }
 
 // AddWordsToGroupRequest represents a request to add words to a group
@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+// LLM call outcomes recorded in the usage ledger
+const (
+	LLMCallStatusSuccess   = "success"
+	LLMCallStatusError     = "error"
+	LLMCallStatusCancelled = "cancelled"
+)
+
+// Periods of usage summaries, in days
+const (
+	DefaultUsageDays = 30
+	MaxUsageDays     = 366
+)
+
+// LLMPrice is the price of a model in USD per million tokens
+type LLMPrice struct {
+	PromptPerMillion     float64 `json:"prompt_per_million"`
+	CompletionPerMillion float64 `json:"completion_per_million"`
+}
+
+// LLMUsageRecord is one call to an LLM provider in the usage ledger
+type LLMUsageRecord struct {
+	ID               int64     `json:"id"`
+	Provider         string    `json:"provider"`
+	Model            string    `json:"model"`
+	Endpoint         string    `json:"endpoint"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	TotalTokens      int       `json:"total_tokens"`
+	LatencyMs        int64     `json:"latency_ms"`
+	Status           string    `json:"status"`
+	Error            *string   `json:"error,omitempty"`
+	CostUSD          float64   `json:"cost_usd"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// LLMUsageSummaryItem aggregates the ledger for one day or endpoint
+// swagger:model
+type LLMUsageSummaryItem struct {
+	// The day (YYYY-MM-DD) or endpoint the row aggregates
+	// required: true
+	Key              string  `json:"key" example:"2025-02-14"`
+	Calls            int     `json:"calls" example:"12"`
+	Errors           int     `json:"errors" example:"1"`
+	PromptTokens     int64   `json:"prompt_tokens" example:"5400"`
+	CompletionTokens int64   `json:"completion_tokens" example:"7200"`
+	TotalTokens      int64   `json:"total_tokens" example:"12600"`
+	CostUSD          float64 `json:"cost_usd" example:"0.003"`
+	AvgLatencyMs     float64 `json:"avg_latency_ms" example:"1830"`
+}
+
+// LLMUsageSummaryResponse summarises LLM usage over a period
+// swagger:model
+type LLMUsageSummaryResponse struct {
+	// How the items are grouped (day or endpoint)
+	GroupBy string                `json:"group_by" example:"day"`
+	Days    int                   `json:"days" example:"30"`
+	Items   []LLMUsageSummaryItem `json:"items"`
+	Totals  LLMUsageSummaryItem   `json:"totals"`
+}
+
+// LLMBudgetResponse reports spending against the monthly LLM budgets
+// swagger:model
+type LLMBudgetResponse struct {
+	// The month being reported (YYYY-MM)
+	Month      string  `json:"month" example:"2025-02"`
+	SpentUSD   float64 `json:"spent_usd" example:"0.42"`
+	TokensUsed int64   `json:"tokens_used" example:"1750000"`
+	// Monthly cost budget in USD, omitted when unlimited
+	BudgetUSD *float64 `json:"budget_usd,omitempty" example:"5"`
+	// Monthly token budget, omitted when unlimited
+	TokenBudget *int64 `json:"token_budget,omitempty" example:"10000000"`
+	// Whether new LLM calls are currently rejected
+	Exceeded bool `json:"exceeded" example:"false"`
+}
@@ -7,8 +7,9 @@ import (
 
 type Word struct {
 	ID       int64           `json:"id"`
-	Italian  string         `json:"italian"`
-	English  string         `json:"english"`
+	Term        string         `json:"term"`
+	Translation string         `json:"translation"`
+	CourseID    int64          `json:"course_id"`
 	Parts    json.RawMessage `json:"parts,omitempty"`
 	CreatedAt time.Time     `json:"created_at"`
 }
@@ -41,6 +42,7 @@ type WordReviewItem struct {
 	WordID         int64     `json:"word_id"`
 	StudySessionID int64     `json:"study_session_id"`
 	Correct        bool      `json:"correct"`
+	ReviewMode     string    `json:"review_mode"`
 	CreatedAt      time.Time `json:"created_at"`
 }
 
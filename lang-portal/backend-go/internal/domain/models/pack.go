@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// ContentPackResponse is a vocabulary pack installed from a zip, such as
+// "A1 travel" or "kitchen"
+// swagger:model
+type ContentPackResponse struct {
+	Name        string `json:"name" example:"a1-travel"`
+	Version     int    `json:"version" example:"1"`
+	Description string `json:"description" example:"Words for getting around on an A1 level trip"`
+	CourseID    int64  `json:"course_id" example:"1"`
+	// Words and groups the pack lists, including ones that existed before
+	WordCount   int       `json:"word_count" example:"120"`
+	GroupCount  int       `json:"group_count" example:"4"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+type ContentPackListResponse struct {
+	Items []ContentPackResponse `json:"items"`
+}
+
+// InstalledPack is the outcome of applying a content pack to the database
+type InstalledPack struct {
+	Pack ContentPackResponse
+	// WordIDs are the ids of the pack's words, in the order it lists them;
+	// 0 for a word of an installed pack that has since been deleted
+	WordIDs []int64
+	// Updated is false when the same version was installed already, and
+	// nothing was changed
+	Updated bool
+}
+
+// InstallPackResponse reports what installing a content pack did
+// swagger:model
+type InstallPackResponse struct {
+	Pack ContentPackResponse `json:"pack"`
+	// Audio files stored for the pack's words, and ones that could not be
+	AudioStored int `json:"audio_stored" example:"118"`
+	AudioFailed int `json:"audio_failed" example:"0"`
+}
+
+// UninstallPackResponse reports what uninstalling a content pack removed.
+// Words the learner has reviewed are kept, and so are groups with study
+// sessions, and words and groups that another installed pack lists or that
+// existed before the pack.
+// swagger:model
+type UninstallPackResponse struct {
+	RemovedWords  int `json:"removed_words" example:"110"`
+	KeptWords     int `json:"kept_words" example:"10"`
+	RemovedGroups int `json:"removed_groups" example:"3"`
+	// Ids of the removed words, whose audio can be discarded
+	RemovedWordIDs []int64 `json:"-"`
+}
@@ -1,9 +1,77 @@
 package models
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Page sizes of list endpoints
+const (
+	DefaultPageLimit = 100
+	MaxPageLimit     = 1000
+)
+
 // PaginationResponse represents pagination metadata
 type PaginationResponse struct {
+	// 0 for pages fetched with a cursor
 	CurrentPage  int `json:"current_page"`
 	TotalPages   int `json:"total_pages"`
 	TotalItems   int `json:"total_items"`
 	ItemsPerPage int `json:"items_per_page"`
+	// Cursor of the next page on lists that support cursor pagination; absent
+	// on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewPagination describes the page of limit items starting at offset in a
+// list of total items
+func NewPagination(limit, offset, total int) PaginationResponse {
+	pagination := PaginationResponse{TotalItems: total, ItemsPerPage: limit}
+	if limit > 0 {
+		pagination.CurrentPage = offset/limit + 1
+		pagination.TotalPages = (total + limit - 1) / limit
+	}
+	return pagination
+}
+
+// ListParams are the validated paging and sorting parameters of a list request
+type ListParams struct {
+	Limit  int
+	Offset int
+	// Cursor continues a list after the last item of the previous page. When
+	// set, Offset is 0.
+	Cursor *Cursor
+	// Sort is one of the fields the list can be sorted by, or empty for the
+	// list's default order
+	Sort string
+	Desc bool
+}
+
+// Cursor is the position of the last item of a page, for keyset pagination
+// of lists ordered by creation time and ID; lists ordered by ID alone ignore
+// CreatedAt. Clients only see it encoded as an opaque string.
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int64     `json:"id"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned by Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID <= 0 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &cursor, nil
 }
@@ -0,0 +1,101 @@
+package models
+
+import "time"
+
+// LearnerExportFormat is the version of the layout of the data export zip
+const LearnerExportFormat = 1
+
+// LearnerData is what the portal keeps about its learner: their profile, if
+// one was created, and their study history. The portal has a single learner,
+// so this is all of it. Vocabulary, groups, courses and content are shared and
+// not part of it.
+type LearnerData struct {
+	Learner            *Learner
+	StudySessions      []ExportedStudySession
+	Reviews            []ExportedReview
+	ListeningAnswers   []ExportedListeningAnswer
+	TutorConversations []ExportedTutorConversation
+}
+
+// ExportedStudySession is a study session in the data export
+type ExportedStudySession struct {
+	ID              int64     `json:"id" example:"41"`
+	GroupID         int64     `json:"group_id" example:"3"`
+	GroupName       string    `json:"group_name" example:"Family"`
+	StudyActivityID int64     `json:"study_activity_id" example:"1"`
+	ActivityName    string    `json:"activity_name" example:"Flashcards"`
+	ClientID        *string   `json:"client_id,omitempty" example:"0b6f3c3e-8c1a-4d2e-9a51-6f0d7b2f4e11"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// ExportedReview is a word review in the data export
+type ExportedReview struct {
+	ID             int64     `json:"id" example:"905"`
+	StudySessionID int64     `json:"study_session_id" example:"41"`
+	WordID         int64     `json:"word_id" example:"17"`
+	Term           string    `json:"term" example:"madre"`
+	Translation    string    `json:"translation" example:"mother"`
+	Correct        bool      `json:"correct" example:"true"`
+	ReviewMode     string    `json:"review_mode" example:"recognition"`
+	ClientID       *string   `json:"client_id,omitempty" example:"5e0a1d9c-7b55-4a8f-b1d4-2c7e9f03a6b2"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ExportedListeningAnswer is an answer to a listening question in the data
+// export
+type ExportedListeningAnswer struct {
+	ID             int64     `json:"id" example:"12"`
+	StudySessionID int64     `json:"study_session_id" example:"41"`
+	ExerciseID     int64     `json:"exercise_id" example:"2"`
+	QuestionID     int64     `json:"question_id" example:"7"`
+	Question       string    `json:"question" example:"Where is Marco going?"`
+	SelectedOption int       `json:"selected_option" example:"1"`
+	Correct        bool      `json:"correct" example:"true"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ExportedWordProgress sums up the reviews of a word in the data export
+type ExportedWordProgress struct {
+	WordID         int64     `json:"word_id" example:"17"`
+	Term           string    `json:"term" example:"madre"`
+	Translation    string    `json:"translation" example:"mother"`
+	CorrectCount   int       `json:"correct_count" example:"4"`
+	WrongCount     int       `json:"wrong_count" example:"1"`
+	LastReviewedAt time.Time `json:"last_reviewed_at"`
+}
+
+// ExportedTutorConversation is a tutoring conversation in the data export,
+// with the learner's and the tutor's messages and the vocabulary the tutor
+// collected. The tutor's instructions are not part of it.
+type ExportedTutorConversation struct {
+	TutorConversation
+	Messages   []TutorMessage         `json:"messages"`
+	Vocabulary []TutorVocabularyEntry `json:"vocabulary"`
+}
+
+// LearnerExportManifest is manifest.json of the data export, describing the
+// other files
+type LearnerExportManifest struct {
+	Format     int                 `json:"format" example:"1"`
+	ExportedAt time.Time           `json:"exported_at"`
+	Files      []LearnerExportFile `json:"files"`
+	// Kinds of data the portal does not keep, so the export has no file for
+	NotStored []string `json:"not_stored"`
+}
+
+// LearnerExportFile describes a file of the data export
+type LearnerExportFile struct {
+	Name        string `json:"name" example:"reviews.json"`
+	Description string `json:"description" example:"Every answer given to a word"`
+	Records     int    `json:"records" example:"120"`
+}
+
+// EraseLearnerDataResponse counts what DELETE /api/me removed
+// swagger:model
+type EraseLearnerDataResponse struct {
+	Learner            int64 `json:"learner" example:"1"`
+	StudySessions      int64 `json:"study_sessions" example:"12"`
+	Reviews            int64 `json:"reviews" example:"120"`
+	ListeningAnswers   int64 `json:"listening_answers" example:"8"`
+	TutorConversations int64 `json:"tutor_conversations" example:"2"`
+}
@@ -0,0 +1,42 @@
+package models
+
+// PromptTemplateResponse describes one version of a prompt template
+type PromptTemplateResponse struct {
+	Name    string `json:"name" example:"generate_words"`
+	Version string `json:"version" example:"v1"`
+	// embedded or override
+	Source string `json:"source" example:"embedded"`
+	// Whether this version is used when generating content
+	Active bool   `json:"active" example:"true"`
+	Text   string `json:"text"`
+}
+
+// PromptTemplateListResponse lists every loaded prompt template version
+type PromptTemplateListResponse struct {
+	Items []PromptTemplateResponse `json:"items"`
+}
+
+// PreviewPromptRequest renders a prompt template without calling the LLM
+type PreviewPromptRequest struct {
+	// required: true
+	Name string `json:"name" binding:"required" example:"generate_words"`
+	// Defaults to the active version
+	Version string `json:"version,omitempty" example:"v2"`
+	// Name of the language learners already speak
+	SourceLanguage string `json:"source_language,omitempty" example:"English"`
+	// Name of the language being learned
+	TargetLanguage string `json:"target_language,omitempty" example:"Italian"`
+	Category       string `json:"category,omitempty" example:"family members"`
+	Count          int    `json:"count,omitempty" binding:"omitempty,min=1,max=50" example:"10"`
+	Level          string `json:"level,omitempty" binding:"omitempty,oneof=A1 A2 B1 B2 C1 C2" example:"A1"`
+	PartOfSpeech   string `json:"part_of_speech,omitempty" example:"noun"`
+	Word           string `json:"word,omitempty" example:"casa"`
+	Translation    string `json:"translation,omitempty" example:"house"`
+}
+
+// PreviewPromptResponse is a rendered prompt template
+type PreviewPromptResponse struct {
+	// The version identifier recorded with generated content
+	PromptVersion string `json:"prompt_version" example:"generate_words@v2"`
+	Text          string `json:"text"`
+}
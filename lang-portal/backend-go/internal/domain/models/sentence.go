@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Sentence sources
+const (
+	SentenceSourceManual = "manual"
+	SentenceSourceLLM    = "llm"
+	SentenceSourceImport = "import"
+)
+
+// SentenceResponse represents an example sentence with its translation
+// swagger:model
+type SentenceResponse struct {
+	// The unique identifier of the sentence
+	// required: true
+	ID int64 `json:"id" example:"1"`
+	// The sentence in the course's target language
+	// required: true
+	Text string `json:"text" example:"Mia sorella vive a Roma."`
+	// The translation in the course's source language
+	// required: true
+	Translation string `json:"translation" example:"My sister lives in Rome."`
+	// Where the sentence came from (manual, llm, import)
+	// required: true
+	Source    string    `json:"source" example:"manual"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WordSentencesResponse struct {
+	Items      []SentenceResponse `json:"items"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+// CreateSentenceRequest represents a request to add an example sentence to a word
+// swagger:model
+type CreateSentenceRequest struct {
+	Text        string `json:"text" binding:"required" example:"Mia sorella vive a Roma."`
+	Translation string `json:"translation" binding:"required" example:"My sister lives in Rome."`
+	// Defaults to manual when omitted
+	Source string `json:"source" binding:"omitempty,oneof=manual llm import" example:"manual"`
+}
+
+// GenerateSentencesRequest represents a request to generate example sentences for a word
+// swagger:model
+type GenerateSentencesRequest struct {
+	// Number of sentences to generate (defaults to 3)
+	Count int `json:"count" binding:"omitempty,min=1,max=10" example:"3"`
+}
+
+// GenerateSentencesResponse represents the sentences generated and stored for a word
+// swagger:model
+type GenerateSentencesResponse struct {
+	WordID    int64              `json:"word_id" example:"1"`
+	Sentences []SentenceResponse `json:"sentences"`
+}
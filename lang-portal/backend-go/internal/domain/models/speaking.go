@@ -0,0 +1,17 @@
+package models
+
+// SpokenAnswerResponse represents the graded transcript of a spoken answer
+// swagger:model
+type SpokenAnswerResponse struct {
+	WordID int64 `json:"word_id" example:"1"`
+	// What the speech recognizer heard
+	Transcript string `json:"transcript" example:"sorela"`
+	// The word the learner was asked to say
+	Expected string `json:"expected" example:"sorella"`
+	// How closely the transcript matches the word, from 0 to 1, ignoring accents
+	Similarity float64 `json:"similarity" example:"0.86"`
+	// False when the transcript's accents are missing or wrong
+	AccentsMatch bool `json:"accents_match" example:"false"`
+	// Whether the answer was recorded as correct
+	Correct bool `json:"correct" example:"true"`
+}
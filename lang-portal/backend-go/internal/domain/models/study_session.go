@@ -30,5 +30,6 @@ type StudySessionDetailResponse struct {
 	GroupName     string           `json:"group_name"`
 	CreatedAt     time.Time        `json:"created_at"`
 	Stats         StudySessionStats `json:"stats"`
-	ReviewItems   []WordReviewItem  `json:"review_items"`
+	// Listed with include=reviews; omitted for sessions without reviews
+	ReviewItems []WordReviewItem `json:"review_items,omitempty"`
 }
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Entities recorded in the change log
+const (
+	SyncEntityWord         = "word"
+	SyncEntityGroup        = "group"
+	SyncEntityGroupWord    = "group_word"
+	SyncEntityStudySession = "study_session"
+	SyncEntityReview       = "review"
+)
+
+// Operations recorded in the change log
+const (
+	SyncOpInsert = "insert"
+	SyncOpUpdate = "update"
+	SyncOpDelete = "delete"
+)
+
+// Outcomes of pushed sessions and reviews
+const (
+	SyncStatusCreated   = "created"
+	SyncStatusDuplicate = "duplicate"
+	SyncStatusRejected  = "rejected"
+)
+
+// Page sizes of sync pulls, in changes
+const (
+	DefaultSyncLimit = 500
+	MaxSyncLimit     = 5000
+)
+
+// SyncToken is how far a client has synced the change log. Clients only see
+// it encoded as an opaque string.
+type SyncToken struct {
+	// Epoch identifies the change log; restoring a backup starts a new one
+	Epoch string `json:"e"`
+	// Seq is the sequence number of the last change the client has
+	Seq int64 `json:"s"`
+}
+
+// Encode returns the token as an opaque URL-safe string
+func (t SyncToken) Encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeSyncToken parses a token returned by Encode
+func DecodeSyncToken(s string) (*SyncToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sync token")
+	}
+	var token SyncToken
+	if err := json.Unmarshal(data, &token); err != nil || token.Epoch == "" || token.Seq < 0 {
+		return nil, fmt.Errorf("invalid sync token")
+	}
+	return &token, nil
+}
+
+// SyncChange is the latest change of an entity since the token a client
+// pulled with. Earlier changes of the same entity are left out.
+type SyncChange struct {
+	Seq    int64  `json:"seq" example:"1042"`
+	Entity string `json:"entity" example:"word" enums:"word,group,group_word,study_session,review"`
+	ID     int64  `json:"id" example:"17"`
+	Op     string `json:"op" example:"update" enums:"insert,update,delete"`
+	// The entity as it is now: a SyncWord, SyncGroup, SyncGroupWord,
+	// SyncStudySession or SyncReview. Absent for deletes.
+	Data interface{} `json:"data,omitempty"`
+}
+
+type SyncWord struct {
+	ID          int64                  `json:"id" example:"17"`
+	CourseID    int64                  `json:"course_id" example:"1"`
+	Term        string                 `json:"term" example:"sorella"`
+	Translation string                 `json:"translation" example:"sister"`
+	Parts       map[string]interface{} `json:"parts"`
+}
+
+type SyncGroup struct {
+	ID   int64  `json:"id" example:"3"`
+	Name string `json:"name" example:"Family"`
+}
+
+// SyncGroupWord is a word's membership of a group
+type SyncGroupWord struct {
+	ID      int64 `json:"id" example:"88"`
+	GroupID int64 `json:"group_id" example:"3"`
+	WordID  int64 `json:"word_id" example:"17"`
+}
+
+type SyncStudySession struct {
+	ID              int64 `json:"id" example:"41"`
+	GroupID         int64 `json:"group_id" example:"3"`
+	StudyActivityID int64 `json:"study_activity_id" example:"1"`
+	// The UUID of sessions pushed by a client
+	ClientID  *string   `json:"client_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SyncReview struct {
+	ID             int64  `json:"id" example:"905"`
+	StudySessionID int64  `json:"study_session_id" example:"41"`
+	WordID         int64  `json:"word_id" example:"17"`
+	Correct        bool   `json:"correct" example:"true"`
+	ReviewMode     string `json:"review_mode" example:"recognition"`
+	// The UUID of reviews pushed by a client
+	ClientID  *string   `json:"client_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SyncPullResponse is a page of the changes since a sync token
+// swagger:model
+type SyncPullResponse struct {
+	Changes []SyncChange `json:"changes"`
+	// Token to pull the next changes with
+	Token string `json:"token"`
+	// More changes follow; pull again with the token straight away
+	HasMore bool `json:"has_more"`
+	// The token belongs to another change log, e.g. from before a backup was
+	// restored. The client drops what it synced and applies these changes,
+	// which start from the beginning.
+	Reset bool `json:"reset"`
+}
+
+// SyncPushRequest carries study sessions and reviews recorded offline, each
+// identified by a UUID the client generated. UUIDs are compared
+// case-insensitively.
+type SyncPushRequest struct {
+	Sessions []PushedSession `json:"sessions" binding:"max=100,dive"`
+	Reviews  []PushedReview  `json:"reviews" binding:"max=5000,dive"`
+}
+
+type PushedSession struct {
+	ClientID        string    `json:"client_id" binding:"required" example:"0b6f3c3e-8c1a-4d2e-9a51-6f0d7b2f4e11"`
+	GroupID         int64     `json:"group_id" binding:"required" example:"3"`
+	StudyActivityID int64     `json:"study_activity_id" binding:"required" example:"1"`
+	StartedAt       time.Time `json:"started_at" binding:"required"`
+}
+
+// PushedReview is a review recorded offline. Its session is either a session
+// the server knows by ID, or one pushed by the client, now or before.
+type PushedReview struct {
+	ClientID        string `json:"client_id" binding:"required" example:"5e0a1d9c-7b55-4a8f-b1d4-2c7e9f03a6b2"`
+	StudySessionID  int64  `json:"study_session_id,omitempty" example:"41"`
+	SessionClientID string `json:"session_client_id,omitempty" example:"0b6f3c3e-8c1a-4d2e-9a51-6f0d7b2f4e11"`
+	WordID          int64  `json:"word_id" binding:"required" example:"17"`
+	Correct         bool   `json:"correct" example:"true"`
+	// Defaults to recognition
+	ReviewMode string    `json:"review_mode" binding:"omitempty,oneof=recognition recall cloze speaking" example:"recognition"`
+	ReviewedAt time.Time `json:"reviewed_at" binding:"required"`
+}
+
+// SyncPushResult is the outcome of one pushed session or review
+type SyncPushResult struct {
+	ClientID string `json:"client_id"`
+	Status   string `json:"status" enums:"created,duplicate,rejected"`
+	// Server ID of the created session or review, or of the one pushed
+	// before with the same client ID
+	ID int64 `json:"id,omitempty"`
+	// Why the item was rejected, e.g. word_not_found
+	Reason string `json:"reason,omitempty"`
+}
+
+// SyncPushResponse lists the outcome of each pushed item, in the order they
+// were applied
+// swagger:model
+type SyncPushResponse struct {
+	Sessions []SyncPushResult `json:"sessions"`
+	Reviews  []SyncPushResult `json:"reviews"`
+}
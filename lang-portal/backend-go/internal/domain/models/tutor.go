@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// Tutor personas, adapted from the sentence-constructor prompts
+const (
+	TutorPersonaLuca    = "luca"
+	TutorPersonaMaria   = "maria"
+	TutorPersonaMaestro = "maestro"
+)
+
+// TutorConversation represents a sentence constructor tutoring conversation
+type TutorConversation struct {
+	ID        int64     `json:"id" example:"1"`
+	CourseID  int64     `json:"course_id" example:"1"`
+	GroupID   *int64    `json:"group_id,omitempty" example:"1"`
+	Persona   string    `json:"persona" example:"luca"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TutorMessage is a stored turn of a tutoring conversation
+type TutorMessage struct {
+	ID        int64     `json:"id" example:"1"`
+	Role      string    `json:"role" example:"assistant"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TutorVocabularyEntry is a row of the vocabulary table the tutor builds for
+// the learner, with the term in the course's target language
+type TutorVocabularyEntry struct {
+	WordID        *int64 `json:"word_id,omitempty" example:"12"`
+	Term          string `json:"term" example:"mangiare"`
+	Translation   string `json:"translation" example:"to eat"`
+	PartOfSpeech  string `json:"part_of_speech" example:"verb"`
+	Pronunciation string `json:"pronunciation" example:"mahn-JAH-reh"`
+}
+
+// CreateTutorConversationRequest represents a request to start a tutoring conversation
+// swagger:model
+type CreateTutorConversationRequest struct {
+	// Course to practise, defaults to the Italian course
+	CourseID int64 `json:"course_id" example:"1"`
+	// Group whose words the tutor should prefer
+	GroupID *int64 `json:"group_id" example:"1"`
+	// Tutor persona (luca, maria, maestro), defaults to luca
+	Persona string `json:"persona" binding:"omitempty,oneof=luca maria maestro" example:"luca"`
+}
+
+// TutorTurnRequest represents a learner turn in a tutoring conversation
+// swagger:model
+type TutorTurnRequest struct {
+	// Sentence in the course's source language to translate, or an attempted
+	// translation into its target language
+	Content string `json:"content" binding:"required" example:"I eat an apple"`
+	// Ask the tutor to reveal the answer before all attempts are used
+	Reveal bool `json:"reveal" example:"false"`
+}
+
+// TutorTurnResponse represents the tutor's guided reply to a learner turn
+// swagger:model
+type TutorTurnResponse struct {
+	ConversationID int64                  `json:"conversation_id" example:"1"`
+	Message        string                 `json:"message"`
+	Hints          []string               `json:"hints"`
+	Vocabulary     []TutorVocabularyEntry `json:"vocabulary"`
+	Attempt        int                    `json:"attempt" example:"1"`
+	Score          *int                   `json:"score,omitempty" example:"8"`
+	// Only present once the attempts are used up or the learner asked for it
+	Answer *string `json:"answer,omitempty" example:"Mangio una mela."`
+}
+
+// TutorConversationResponse represents a tutoring conversation with its history
+// swagger:model
+type TutorConversationResponse struct {
+	TutorConversation
+	Messages   []TutorMessage         `json:"messages"`
+	Vocabulary []TutorVocabularyEntry `json:"vocabulary"`
+}
@@ -6,12 +6,15 @@ type WordResponse struct {
 	// The unique identifier of the word
 	// required: true
 	ID int64 `json:"id" example:"1"`
-	// The Italian word
+	// The word in the course's target language
 	// required: true
-	Italian string `json:"italian" example:"sorella"`
-	// The English translation
+	Term string `json:"term" example:"sorella"`
+	// The translation in the course's source language
 	// required: true
-	English string `json:"english" example:"sister"`
+	Translation string `json:"translation" example:"sister"`
+	// The course the word belongs to
+	// required: true
+	CourseID int64 `json:"course_id" example:"1"`
 	// Grammatical details like type, gender, plural form
 	// required: true
 	Parts map[string]interface{} `json:"parts"`
@@ -21,6 +24,8 @@ type WordResponse struct {
 	// Number of times the word was incorrectly answered
 	// required: true
 	WrongCount int `json:"wrong_count" example:"2"`
+	// The prompt template version that generated the word, if it came from the LLM
+	PromptVersion *string `json:"prompt_version,omitempty" example:"generate_words@v2"`
 }
 
 type WordListResponse struct {
@@ -28,9 +33,22 @@ type WordListResponse struct {
 	Pagination PaginationResponse `json:"pagination"`
 }
 
+// WordSortFields are the fields a word list can be sorted by
+var WordSortFields = []string{"id", "term", "translation", "correct_count", "wrong_count"}
+
+// Review modes recorded on word review items
+const (
+	ReviewModeRecognition = "recognition"
+	ReviewModeRecall      = "recall"
+	ReviewModeCloze       = "cloze"
+	ReviewModeSpeaking    = "speaking"
+)
+
 // WordReviewRequest represents a request to review a word in a study session
 type WordReviewRequest struct {
-	Correct bool `json:"correct" binding:"required"`
+	Correct bool `json:"correct"`
+	// Review mode, defaults to recognition. Cloze and speaking reviews are recorded through their own endpoints.
+	Mode string `json:"mode" binding:"omitempty,oneof=recognition recall" example:"recognition"`
 }
 
 // WordReviewResponse represents a response to a word review request
@@ -40,10 +58,31 @@ type WordReviewResponse struct {
 }
 
 type ImportWordsRequest struct {
-	GroupID int64          `json:"group_id" binding:"required" example:"123"`
-	Words   []WordResponse `json:"words" binding:"required,dive"`
+	GroupID int64 `json:"group_id" binding:"required" example:"123"`
+	// Course the words belong to, defaults to the Italian course
+	CourseID int64          `json:"course_id,omitempty" example:"1"`
+	Words    []WordResponse `json:"words" binding:"required,dive"`
 }
 
 type ImportWordsResponse struct {
 	ImportedCount int `json:"imported_count"`
+	// Words rejected because they are not written in the course's languages
+	SkippedCount int `json:"skipped_count"`
+	// Words that could not be stored or added to the group
+	FailedCount int `json:"failed_count"`
+}
+
+// ReviewModeStats represents review accuracy for a single review mode
+type ReviewModeStats struct {
+	Mode         string  `json:"mode" example:"cloze"`
+	TotalReviews int     `json:"total_reviews" example:"10"`
+	CorrectCount int     `json:"correct_count" example:"7"`
+	WrongCount   int     `json:"wrong_count" example:"3"`
+	SuccessRate  float64 `json:"success_rate" example:"70"`
+}
+
+// WordReviewStatsResponse represents per review mode accuracy for a word
+type WordReviewStatsResponse struct {
+	WordID int64             `json:"word_id" example:"1"`
+	Modes  []ReviewModeStats `json:"modes"`
 }
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type AnalyzerServiceInterface interface {
+	AnalyzeText(ctx context.Context, req *models.TextAnalysisRequest) (*models.TextAnalysisResponse, error)
+	AddUnknownWords(ctx context.Context, req *models.AddUnknownWordsRequest) (*models.AddUnknownWordsResponse, error)
+}
+
+// vocabularyPageSize is how many words are loaded at a time when indexing a
+// course's vocabulary
+const vocabularyPageSize = 500
+
+type AnalyzerService struct {
+	repo  repository.Repository
+	words WordServiceInterface
+}
+
+func NewAnalyzerService(repo repository.Repository, words WordServiceInterface) *AnalyzerService {
+	return &AnalyzerService{repo: repo, words: words}
+}
+
+// AnalyzeText finds which words of a text are forms of the course's vocabulary
+func (s *AnalyzerService) AnalyzeText(ctx context.Context, req *models.TextAnalysisRequest) (*models.TextAnalysisResponse, error) {
+	course, index, err := s.vocabulary(ctx, req.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	return analyzeText(req.Text, course.TargetLanguage.Code, index), nil
+}
+
+// AddUnknownWords adds words picked from a text analysis to the vocabulary and
+// a group. Words that are forms of existing entries are reported instead of
+// being added again.
+func (s *AnalyzerService) AddUnknownWords(ctx context.Context, req *models.AddUnknownWordsRequest) (*models.AddUnknownWordsResponse, error) {
+	if _, err := s.repo.GetGroupByID(ctx, req.GroupID); err != nil {
+		return nil, err
+	}
+
+	course, index, err := s.vocabulary(ctx, req.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.AddUnknownWordsResponse{AlreadyKnown: []string{}}
+	importReq := &models.ImportWordsRequest{GroupID: req.GroupID, CourseID: course.ID}
+	seen := make(map[string]bool)
+	for _, selection := range req.Words {
+		term := strings.TrimSpace(selection.Term)
+		key := strings.ToLower(term)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if knownTerm(index, term, course.TargetLanguage.Code) {
+			response.AlreadyKnown = append(response.AlreadyKnown, term)
+			continue
+		}
+
+		parts := selection.Parts
+		if parts == nil {
+			parts = map[string]interface{}{}
+		}
+		importReq.Words = append(importReq.Words, models.WordResponse{
+			Term:        term,
+			Translation: strings.TrimSpace(selection.Translation),
+			Parts:       parts,
+		})
+	}
+
+	if len(importReq.Words) == 0 {
+		return response, nil
+	}
+
+	imported, err := s.words.ImportWords(ctx, importReq)
+	if err != nil {
+		return nil, err
+	}
+	response.AddedCount = imported.ImportedCount
+	response.SkippedCount = imported.SkippedCount
+	return response, nil
+}
+
+// knownTerm reports whether term as a whole is a form of a vocabulary entry
+func knownTerm(index *vocabularyIndex, term, language string) bool {
+	tokens := tokenizeText(term, language)
+	switch len(tokens) {
+	case 0:
+		return false
+	case 1:
+		return index.lookup(tokens[0]) != nil
+	default:
+		_, length := index.lookupPhrase(tokens)
+		return length == len(tokens)
+	}
+}
+
+// vocabulary loads and indexes every word of a course
+func (s *AnalyzerService) vocabulary(ctx context.Context, courseID int64) (*models.CourseResponse, *vocabularyIndex, error) {
+	course, err := getCourse(ctx, s.repo, courseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var words []models.WordResponse
+	for offset := 0; ; offset += vocabularyPageSize {
+		page, err := s.repo.GetWords(ctx, course.ID, models.ListParams{Limit: vocabularyPageSize, Offset: offset})
+		if err != nil {
+			return nil, nil, err
+		}
+		words = append(words, page.Items...)
+		if len(page.Items) < vocabularyPageSize {
+			break
+		}
+	}
+
+	return course, newVocabularyIndex(words, course.TargetLanguage.Code), nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// defaultAudioCacheDir is where audio is cached unless AUDIO_CACHE_DIR
+// overrides it
+const defaultAudioCacheDir = "audio_cache"
+
+// audioExtensions maps the audio types the cache stores to file extensions
+var audioExtensions = map[string]string{
+	"audio/wav":  ".wav",
+	"audio/mpeg": ".mp3",
+	"audio/ogg":  ".ogg",
+	"audio/webm": ".webm",
+}
+
+// voicePattern limits voice names to characters that are safe in a path
+var voicePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// ValidVoice reports whether voice can be used as a TTS voice name
+func ValidVoice(voice string) bool {
+	return voicePattern.MatchString(voice) && voice != "." && voice != ".."
+}
+
+// AudioCache stores synthesized audio on disk as <dir>/<voice>/<word id><ext>
+type AudioCache struct {
+	dir string
+}
+
+func NewAudioCache(dir string) *AudioCache {
+	return &AudioCache{dir: dir}
+}
+
+// audioCacheDir reads the cache directory from AUDIO_CACHE_DIR
+func audioCacheDir() string {
+	if dir := os.Getenv("AUDIO_CACHE_DIR"); dir != "" {
+		return dir
+	}
+	return defaultAudioCacheDir
+}
+
+// Get returns the cached audio of a word, or nil when there is none
+func (c *AudioCache) Get(wordID int64, voice string) (*models.WordAudio, error) {
+	for contentType, ext := range audioExtensions {
+		path := c.path(wordID, voice, ext)
+		if _, err := os.Stat(path); err == nil {
+			return &models.WordAudio{Path: path, ContentType: contentType}, nil
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// Put stores the audio of a word. The file is written to a temporary name
+// and renamed so readers never see a partial file.
+func (c *AudioCache) Put(wordID int64, voice, contentType string, audio []byte) (*models.WordAudio, error) {
+	ext, ok := audioExtensions[contentType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported audio type %q", contentType)
+	}
+
+	dir := filepath.Join(c.dir, voice)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp(dir, strconv.FormatInt(wordID, 10)+"-*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(audio); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+
+	path := c.path(wordID, voice, ext)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+	return &models.WordAudio{Path: path, ContentType: contentType}, nil
+}
+
+func (c *AudioCache) path(wordID int64, voice, ext string) string {
+	return filepath.Join(c.dir, voice, strconv.FormatInt(wordID, 10)+ext)
+}
+
+// Delete removes the cached audio of a word in every voice
+func (c *AudioCache) Delete(wordID int64) error {
+	paths, err := filepath.Glob(filepath.Join(c.dir, "*", strconv.FormatInt(wordID, 10)+".*"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
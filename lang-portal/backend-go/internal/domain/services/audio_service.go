@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type AudioServiceInterface interface {
+	GetWordAudio(ctx context.Context, wordID int64, voice string) (*models.WordAudio, error)
+	GenerateGroupAudio(ctx context.Context, groupID int64, voice string, progress func(done, total int)) (*models.GenerateAudioJobResult, error)
+}
+
+// defaultTTSVoice is used when neither the request nor TTS_VOICE names a voice
+const defaultTTSVoice = "default"
+
+// audioPageSize is how many group words are loaded at a time when
+// generating audio for a group
+const audioPageSize = 100
+
+type AudioService struct {
+	repo     repository.Repository
+	provider TTSProvider
+	cache    *AudioCache
+	voice    string
+
+	// mu guards inflight, which serializes synthesis of the same word and
+	// voice so concurrent requests do not call the provider twice
+	mu       sync.Mutex
+	inflight map[string]*sync.Mutex
+}
+
+// NewAudioService creates an audio service. A nil provider disables
+// synthesis; audio that is already cached is still served.
+func NewAudioService(repo repository.Repository, provider TTSProvider, cache *AudioCache) *AudioService {
+	voice := os.Getenv("TTS_VOICE")
+	if voice == "" || !ValidVoice(voice) {
+		voice = defaultTTSVoice
+	}
+	return &AudioService{
+		repo:     repo,
+		provider: provider,
+		cache:    cache,
+		voice:    voice,
+		inflight: make(map[string]*sync.Mutex),
+	}
+}
+
+// NewAudioServiceFromEnv creates an audio service using the TTS provider and
+// cache directory configured in the environment
+func NewAudioServiceFromEnv(repo repository.Repository) *AudioService {
+	return NewAudioService(repo, newTTSProvider(), NewAudioCache(audioCacheDir()))
+}
+
+// GetWordAudio returns the pronunciation audio of a word, synthesizing and
+// caching it on first use
+func (s *AudioService) GetWordAudio(ctx context.Context, wordID int64, voice string) (*models.WordAudio, error) {
+	voice, err := s.resolveVoice(voice)
+	if err != nil {
+		return nil, err
+	}
+
+	word, err := s.repo.GetWordByID(ctx, wordID)
+	if err != nil {
+		return nil, err
+	}
+
+	audio, _, err := s.wordAudio(ctx, word, voice, nil)
+	return audio, err
+}
+
+// GenerateGroupAudio synthesizes audio for every word in a group that does not
+// have any yet. Words that fail are counted and skipped.
+func (s *AudioService) GenerateGroupAudio(ctx context.Context, groupID int64, voice string, progress func(done, total int)) (*models.GenerateAudioJobResult, error) {
+	voice, err := s.resolveVoice(voice)
+	if err != nil {
+		return nil, err
+	}
+	if s.provider == nil {
+		return nil, models.NewError(models.ErrUnavailable, "tts_not_configured", "text-to-speech is not configured")
+	}
+
+	if _, err := s.repo.GetGroupByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	result := &models.GenerateAudioJobResult{}
+	courses := make(map[int64]*models.CourseResponse)
+	done := 0
+	for offset := 0; ; offset += audioPageSize {
+		page, err := s.repo.GetGroupWords(ctx, groupID, audioPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range page.Items {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			_, cached, err := s.wordAudio(ctx, &page.Items[i], voice, courses)
+			switch {
+			case err != nil:
+				log.Warn().Err(err).Int64("word_id", page.Items[i].ID).Msg("Failed to generate word audio")
+				result.Failed++
+			case cached:
+				result.Cached++
+			default:
+				result.Generated++
+			}
+
+			done++
+			if progress != nil {
+				progress(done, page.Pagination.TotalItems)
+			}
+		}
+
+		if len(page.Items) < audioPageSize {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// wordAudio returns the cached audio of a word, synthesizing it when missing.
+// The boolean reports whether the audio was already cached. courses, when
+// set, memoizes course lookups across calls.
+func (s *AudioService) wordAudio(ctx context.Context, word *models.WordResponse, voice string, courses map[int64]*models.CourseResponse) (*models.WordAudio, bool, error) {
+	if audio, err := s.cache.Get(word.ID, voice); err != nil || audio != nil {
+		return audio, audio != nil, err
+	}
+	if s.provider == nil {
+		return nil, false, models.NewError(models.ErrUnavailable, "tts_not_configured", "text-to-speech is not configured")
+	}
+
+	lock := s.lock(fmt.Sprintf("%d/%s", word.ID, voice))
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Another request may have synthesized the audio while we waited
+	if audio, err := s.cache.Get(word.ID, voice); err != nil || audio != nil {
+		return audio, audio != nil, err
+	}
+
+	course := courses[word.CourseID]
+	if course == nil {
+		var err error
+		if course, err = getCourse(ctx, s.repo, word.CourseID); err != nil {
+			return nil, false, err
+		}
+		if courses != nil {
+			courses[word.CourseID] = course
+		}
+	}
+
+	data, contentType, err := s.provider.Synthesize(ctx, word.Term, course.TargetLanguage.Code, voice)
+	if err != nil {
+		return nil, false, models.WrapError(models.ErrUpstream, "tts_failed", "failed to synthesize audio", err)
+	}
+
+	audio, err := s.cache.Put(word.ID, voice, contentType, data)
+	return audio, false, err
+}
+
+// StoreWordAudio caches audio of a word supplied with it, e.g. by a content
+// pack, as the default voice's
+func (s *AudioService) StoreWordAudio(wordID int64, contentType string, audio []byte) error {
+	_, err := s.cache.Put(wordID, s.voice, contentType, audio)
+	return err
+}
+
+// DeleteWordAudio discards the cached audio of removed words
+func (s *AudioService) DeleteWordAudio(wordIDs []int64) error {
+	for _, id := range wordIDs {
+		if err := s.cache.Delete(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AudioService) resolveVoice(voice string) (string, error) {
+	if voice == "" {
+		return s.voice, nil
+	}
+	if !ValidVoice(voice) {
+		return "", models.ValidationError("invalid_voice", fmt.Sprintf("invalid voice %q", voice))
+	}
+	return voice, nil
+}
+
+func (s *AudioService) lock(key string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, ok := s.inflight[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.inflight[key] = lock
+	}
+	return lock
+}
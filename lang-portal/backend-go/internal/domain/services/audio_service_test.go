@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+)
+
+// countingTTSProvider wraps the fake provider, recording what it was asked
+// to say and optionally failing for some text
+type countingTTSProvider struct {
+	calls    []string
+	language string
+	failFor  string
+}
+
+func (p *countingTTSProvider) Synthesize(ctx context.Context, text, language, voice string) ([]byte, string, error) {
+	p.calls = append(p.calls, text)
+	p.language = language
+	if text == p.failFor {
+		return nil, "", errors.New("service unavailable")
+	}
+	return FakeTTSProvider{}.Synthesize(ctx, text, language, voice)
+}
+
+func TestSilentWAV(t *testing.T) {
+	wav := silentWAV(500 * time.Millisecond)
+
+	assert.Equal(t, "RIFF", string(wav[0:4]))
+	assert.Equal(t, "WAVE", string(wav[8:12]))
+	assert.Equal(t, uint32(len(wav)-8), binary.LittleEndian.Uint32(wav[4:8]))
+	assert.Equal(t, "data", string(wav[36:40]))
+	assert.Equal(t, uint32(16000), binary.LittleEndian.Uint32(wav[40:44]))
+	assert.Len(t, wav, 44+16000)
+}
+
+func TestHTTPTTSProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"language":"it","text":"ciao","voice":"anna"}` {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write([]byte("mp3"))
+	}))
+	defer server.Close()
+
+	provider := NewHTTPTTSProvider(server.URL)
+
+	audio, contentType, err := provider.Synthesize(context.Background(), "ciao", "it", "anna")
+	require.NoError(t, err)
+	assert.Equal(t, "audio/mpeg", contentType)
+	assert.Equal(t, "mp3", string(audio))
+
+	_, _, err = provider.Synthesize(context.Background(), "buongiorno", "it", "anna")
+	assert.ErrorContains(t, err, "TTS service returned 400")
+}
+
+func TestAudioService_GetWordAudio(t *testing.T) {
+	ciao := &models.WordResponse{ID: 1, Term: "ciao", Translation: "hello", CourseID: models.DefaultCourseID}
+
+	t.Run("synthesizes once and then serves the cache", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		provider := &countingTTSProvider{}
+		service := NewAudioService(mockRepo, provider, NewAudioCache(t.TempDir()))
+
+		mockRepo.On("GetWordByID", int64(1)).Return(ciao, nil)
+		mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+
+		audio, err := service.GetWordAudio(context.Background(), 1, "")
+		require.NoError(t, err)
+		assert.Equal(t, "audio/wav", audio.ContentType)
+		data, err := os.ReadFile(audio.Path)
+		require.NoError(t, err)
+		assert.Equal(t, silentWAV(500*time.Millisecond), data)
+
+		again, err := service.GetWordAudio(context.Background(), 1, "")
+		require.NoError(t, err)
+		assert.Equal(t, audio, again)
+
+		assert.Equal(t, []string{"ciao"}, provider.calls)
+		assert.Equal(t, "it", provider.language)
+	})
+
+	t.Run("voices are cached separately", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		provider := &countingTTSProvider{}
+		service := NewAudioService(mockRepo, provider, NewAudioCache(t.TempDir()))
+
+		mockRepo.On("GetWordByID", int64(1)).Return(ciao, nil)
+		mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+
+		first, err := service.GetWordAudio(context.Background(), 1, "anna")
+		require.NoError(t, err)
+		second, err := service.GetWordAudio(context.Background(), 1, "marco")
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first.Path, second.Path)
+		assert.Len(t, provider.calls, 2)
+	})
+
+	t.Run("invalid voice", func(t *testing.T) {
+		service := NewAudioService(new(mocks.MockRepository), &countingTTSProvider{}, NewAudioCache(t.TempDir()))
+
+		_, err := service.GetWordAudio(context.Background(), 1, "../etc")
+		assert.ErrorContains(t, err, "invalid voice")
+	})
+
+	t.Run("word not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewAudioService(mockRepo, &countingTTSProvider{}, NewAudioCache(t.TempDir()))
+
+		mockRepo.On("GetWordByID", int64(99)).Return(nil, models.NotFoundError("word"))
+
+		_, err := service.GetWordAudio(context.Background(), 99, "")
+		assert.ErrorIs(t, err, models.ErrNotFound)
+	})
+
+	t.Run("no provider configured", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewAudioService(mockRepo, nil, NewAudioCache(t.TempDir()))
+
+		mockRepo.On("GetWordByID", int64(1)).Return(ciao, nil)
+
+		_, err := service.GetWordAudio(context.Background(), 1, "")
+		assert.ErrorIs(t, err, models.ErrUnavailable)
+	})
+}
+
+func TestAudioService_GenerateGroupAudio(t *testing.T) {
+	mockRepo := new(mocks.MockRepository)
+	provider := &countingTTSProvider{failFor: "grazie"}
+	cache := NewAudioCache(t.TempDir())
+	service := NewAudioService(mockRepo, provider, cache)
+
+	_, err := cache.Put(1, defaultTTSVoice, "audio/wav", silentWAV(time.Millisecond))
+	require.NoError(t, err)
+
+	words := []models.WordResponse{
+		{ID: 1, Term: "ciao", CourseID: models.DefaultCourseID},
+		{ID: 2, Term: "grazie", CourseID: models.DefaultCourseID},
+		{ID: 3, Term: "prego", CourseID: models.DefaultCourseID},
+	}
+	mockRepo.On("GetGroupByID", int64(1)).Return(&models.GroupDetailResponse{ID: 1}, nil)
+	mockRepo.On("GetGroupWords", int64(1), audioPageSize, 0).
+		Return(&models.GroupWordsResponse{Items: words, Pagination: models.PaginationResponse{TotalItems: 3}}, nil)
+	mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil).Once()
+
+	var progress []int
+	result, err := service.GenerateGroupAudio(context.Background(), 1, "", func(done, total int) {
+		progress = append(progress, done*100/total)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &models.GenerateAudioJobResult{Generated: 1, Cached: 1, Failed: 1}, result)
+	assert.Equal(t, []string{"grazie", "prego"}, provider.calls)
+	assert.Equal(t, []int{33, 66, 100}, progress)
+	mockRepo.AssertExpectations(t)
+}
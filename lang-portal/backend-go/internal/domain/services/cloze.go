@@ -0,0 +1,127 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// clozeBlank replaces the target word inside a cloze prompt
+const clozeBlank = "____"
+
+// maxInflectionSuffix is how many characters an inflected form may add to a stem
+const maxInflectionSuffix = 4
+
+var letterRun = regexp.MustCompile(`\p{L}+`)
+
+// blankWord finds the occurrence of word (or one of its inflected forms) in
+// sentence and returns the sentence with that occurrence blanked out together
+// with the form that was removed. ok is false when no form of the word is found.
+func blankWord(sentence, word string, parts map[string]interface{}) (prompt, answer string, ok bool) {
+	word = strings.TrimSpace(word)
+	if word == "" {
+		return "", "", false
+	}
+
+	// Multi-word entries such as "per favore" are only blanked when the whole
+	// phrase appears in the sentence
+	if strings.Contains(word, " ") {
+		idx := strings.Index(strings.ToLower(sentence), strings.ToLower(word))
+		if idx == -1 {
+			return "", "", false
+		}
+		answer = sentence[idx : idx+len(word)]
+		return sentence[:idx] + clozeBlank + sentence[idx+len(word):], answer, true
+	}
+
+	forms := knownForms(word, parts)
+	stems := wordStems(word)
+
+	bestScore := -1
+	var bestStart, bestEnd int
+	for _, loc := range letterRun.FindAllStringIndex(sentence, -1) {
+		token := strings.ToLower(sentence[loc[0]:loc[1]])
+		score := matchScore(token, forms, stems)
+		if score < 0 {
+			continue
+		}
+		if bestScore == -1 || score < bestScore {
+			bestScore = score
+			bestStart, bestEnd = loc[0], loc[1]
+		}
+	}
+	if bestScore == -1 {
+		return "", "", false
+	}
+
+	return sentence[:bestStart] + clozeBlank + sentence[bestEnd:], sentence[bestStart:bestEnd], true
+}
+
+// knownForms returns the lemma plus any explicit forms recorded in the word's
+// grammatical parts (plural, feminine, ...), all lower-cased
+func knownForms(word string, parts map[string]interface{}) []string {
+	forms := []string{strings.ToLower(word)}
+	for key, value := range parts {
+		if key == "type" || key == "gender" || key == "category" || key == "level" {
+			continue
+		}
+		if form, ok := value.(string); ok && form != "" && !strings.Contains(form, " ") {
+			forms = append(forms, strings.ToLower(form))
+		}
+	}
+	return forms
+}
+
+// wordStems derives the stems inflected forms are built on: infinitives lose
+// their -are/-ere/-ire ending, other words their final vowel
+func wordStems(word string) []string {
+	word = strings.ToLower(word)
+	if utf8.RuneCountInString(word) <= 3 {
+		return nil
+	}
+
+	for _, ending := range []string{"are", "ere", "ire", "rre"} {
+		if strings.HasSuffix(word, ending) {
+			return []string{strings.TrimSuffix(word, ending)}
+		}
+	}
+
+	last, size := utf8.DecodeLastRuneInString(word)
+	if strings.ContainsRune("aeiouàèéìòù", last) {
+		return []string{word[:len(word)-size]}
+	}
+	return nil
+}
+
+// matchScore ranks how closely token matches the word: 0 for the lemma, 1 for
+// an explicit form and 2+ for stem matches, growing with the suffix length.
+// It returns -1 when the token is not a form of the word.
+func matchScore(token string, forms, stems []string) int {
+	for i, form := range forms {
+		if token == form {
+			if i == 0 {
+				return 0
+			}
+			return 1
+		}
+	}
+
+	for _, stem := range stems {
+		if !strings.HasPrefix(token, stem) {
+			continue
+		}
+		suffix := utf8.RuneCountInString(token) - utf8.RuneCountInString(stem)
+		if suffix > 0 && suffix <= maxInflectionSuffix {
+			return 1 + suffix
+		}
+	}
+
+	return -1
+}
+
+// normalizeAnswer prepares a typed answer for comparison with the blanked form
+func normalizeAnswer(s string) string {
+	s = strings.ReplaceAll(s, "’", "'")
+	s = strings.Trim(strings.TrimSpace(s), ".,;:!?\"'")
+	return strings.ToLower(s)
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlankWord(t *testing.T) {
+	tests := []struct {
+		name       string
+		sentence   string
+		word       string
+		parts      map[string]interface{}
+		wantPrompt string
+		wantAnswer string
+		wantOK     bool
+	}{
+		{
+			name:       "exact match",
+			sentence:   "Mia sorella vive a Roma.",
+			word:       "sorella",
+			wantPrompt: "Mia ____ vive a Roma.",
+			wantAnswer: "sorella",
+			wantOK:     true,
+		},
+		{
+			name:       "plural from parts",
+			sentence:   "Le mie sorelle sono alte.",
+			word:       "sorella",
+			parts:      map[string]interface{}{"type": "noun", "plural": "sorelle"},
+			wantPrompt: "Le mie ____ sono alte.",
+			wantAnswer: "sorelle",
+			wantOK:     true,
+		},
+		{
+			name:       "conjugated verb",
+			sentence:   "Noi mangiamo la pizza.",
+			word:       "mangiare",
+			wantPrompt: "Noi ____ la pizza.",
+			wantAnswer: "mangiamo",
+			wantOK:     true,
+		},
+		{
+			name:       "elided article is kept",
+			sentence:   "L'amico di Marco.",
+			word:       "amico",
+			wantPrompt: "L'____ di Marco.",
+			wantAnswer: "amico",
+			wantOK:     true,
+		},
+		{
+			name:       "capitalised form",
+			sentence:   "Acqua, per favore.",
+			word:       "acqua",
+			wantPrompt: "____, per favore.",
+			wantAnswer: "Acqua",
+			wantOK:     true,
+		},
+		{
+			name:       "multi-word entry",
+			sentence:   "Un caffè, per favore.",
+			word:       "per favore",
+			wantPrompt: "Un caffè, ____.",
+			wantAnswer: "per favore",
+			wantOK:     true,
+		},
+		{
+			name:     "word not present",
+			sentence: "Il gatto dorme.",
+			word:     "cane",
+			wantOK:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt, answer, ok := blankWord(tt.sentence, tt.word, tt.parts)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.wantPrompt, prompt)
+				assert.Equal(t, tt.wantAnswer, answer)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type CourseServiceInterface interface {
+	GetLanguages(ctx context.Context) (*models.LanguageListResponse, error)
+	CreateLanguage(ctx context.Context, req *models.CreateLanguageRequest) (*models.LanguageResponse, error)
+	GetCourses(ctx context.Context) (*models.CourseListResponse, error)
+	GetCourse(ctx context.Context, id int64) (*models.CourseResponse, error)
+	CreateCourse(ctx context.Context, req *models.CreateCourseRequest) (*models.CourseResponse, error)
+}
+
+type CourseService struct {
+	repo repository.Repository
+}
+
+func NewCourseService(repo repository.Repository) *CourseService {
+	return &CourseService{repo: repo}
+}
+
+func (s *CourseService) GetLanguages(ctx context.Context) (*models.LanguageListResponse, error) {
+	languages, err := s.repo.GetLanguages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.LanguageListResponse{Items: languages}, nil
+}
+
+// CreateLanguage adds a language that courses can then be created for
+func (s *CourseService) CreateLanguage(ctx context.Context, req *models.CreateLanguageRequest) (*models.LanguageResponse, error) {
+	language := &models.LanguageResponse{
+		Code:   strings.ToLower(req.Code),
+		Name:   req.Name,
+		Script: req.Script,
+	}
+
+	_, err := s.repo.GetLanguage(ctx, language.Code)
+	if err == nil {
+		return nil, models.ConflictError("language_exists", fmt.Sprintf("language %s already exists", language.Code))
+	}
+	if !errors.Is(err, models.ErrNotFound) {
+		return nil, err
+	}
+
+	if err := s.repo.CreateLanguage(ctx, language); err != nil {
+		return nil, err
+	}
+	return language, nil
+}
+
+func (s *CourseService) GetCourses(ctx context.Context) (*models.CourseListResponse, error) {
+	courses, err := s.repo.GetCourses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CourseListResponse{Items: courses}, nil
+}
+
+func (s *CourseService) GetCourse(ctx context.Context, id int64) (*models.CourseResponse, error) {
+	return s.repo.GetCourse(ctx, id)
+}
+
+// CreateCourse adds a course for a pair of known languages. There is at most
+// one course per language pair.
+func (s *CourseService) CreateCourse(ctx context.Context, req *models.CreateCourseRequest) (*models.CourseResponse, error) {
+	source := strings.ToLower(req.SourceLanguage)
+	target := strings.ToLower(req.TargetLanguage)
+	if source == target {
+		return nil, models.ValidationError("invalid_course", "invalid course: source and target language must differ")
+	}
+
+	for _, code := range []string{source, target} {
+		_, err := s.repo.GetLanguage(ctx, code)
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, models.ValidationError("invalid_course", fmt.Sprintf("invalid course: unknown language %s", code))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := s.repo.GetCourseByLanguages(ctx, source, target)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, models.ConflictError("course_exists", fmt.Sprintf("course for %s to %s already exists", source, target))
+	}
+
+	id, err := s.repo.CreateCourse(ctx, req.Name, source, target)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetCourse(ctx, id)
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+)
+
+func TestCourseService_CreateCourse(t *testing.T) {
+	t.Run("creates course", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewCourseService(mockRepo)
+
+		mockRepo.On("GetLanguage", "en").Return(&english, nil)
+		mockRepo.On("GetLanguage", "ja").Return(&japanese, nil)
+		mockRepo.On("GetCourseByLanguages", "en", "ja").Return(nil, nil)
+		mockRepo.On("CreateCourse", "Japanese for English speakers", "en", "ja").Return(int64(2), nil)
+		mockRepo.On("GetCourse", int64(2)).Return(japaneseCourse, nil)
+
+		course, err := service.CreateCourse(context.Background(), &models.CreateCourseRequest{
+			Name:           "Japanese for English speakers",
+			SourceLanguage: "EN",
+			TargetLanguage: "ja",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, japaneseCourse, course)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("same source and target", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewCourseService(mockRepo)
+
+		_, err := service.CreateCourse(context.Background(), &models.CreateCourseRequest{Name: "Italian", SourceLanguage: "it", TargetLanguage: "it"})
+		assert.ErrorContains(t, err, "invalid course")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown language", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewCourseService(mockRepo)
+
+		mockRepo.On("GetLanguage", "en").Return(&english, nil)
+		mockRepo.On("GetLanguage", "xx").Return(nil, models.NotFoundError("language"))
+
+		_, err := service.CreateCourse(context.Background(), &models.CreateCourseRequest{Name: "Unknown", SourceLanguage: "en", TargetLanguage: "xx"})
+		assert.ErrorIs(t, err, models.ErrValidation)
+		assert.ErrorContains(t, err, "invalid course: unknown language xx")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("course already exists", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewCourseService(mockRepo)
+
+		mockRepo.On("GetLanguage", "en").Return(&english, nil)
+		mockRepo.On("GetLanguage", "it").Return(&italian, nil)
+		mockRepo.On("GetCourseByLanguages", "en", "it").Return(italianCourse, nil)
+
+		_, err := service.CreateCourse(context.Background(), &models.CreateCourseRequest{Name: "Italian again", SourceLanguage: "en", TargetLanguage: "it"})
+		assert.ErrorContains(t, err, "already exists")
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+
 	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 )
@@ -13,14 +15,14 @@ func NewDashboardService(repo repository.Repository) *DashboardService {
 	return &DashboardService{repo: repo}
 }
 
-func (s *DashboardService) GetLastStudySession() (*models.DashboardLastStudySession, error) {
-	return s.repo.GetLastStudySession()
+func (s *DashboardService) GetLastStudySession(ctx context.Context) (*models.DashboardLastStudySession, error) {
+	return s.repo.GetLastStudySession(ctx)
 }
 
-func (s *DashboardService) GetStudyProgress() (*models.DashboardStudyProgress, error) {
-	return s.repo.GetStudyProgress()
+func (s *DashboardService) GetStudyProgress(ctx context.Context) (*models.DashboardStudyProgress, error) {
+	return s.repo.GetStudyProgress(ctx)
 }
 
-func (s *DashboardService) GetQuickStats() (*models.DashboardQuickStats, error) {
-	return s.repo.GetQuickStats()
+func (s *DashboardService) GetQuickStats(ctx context.Context) (*models.DashboardQuickStats, error) {
+	return s.repo.GetQuickStats(ctx)
 }
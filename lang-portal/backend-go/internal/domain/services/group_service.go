@@ -1,16 +1,19 @@
 package services
 
 import (
+	"context"
+
 	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 )
 
 type GroupServiceInterface interface {
-	GetGroups(limit, offset int) (*models.GroupListResponse, error)
-	GetGroupByID(id int64) (*models.GroupDetailResponse, error)
-	GetGroupWords(groupID int64, limit, offset int) (*models.GroupWordsResponse, error)
-	GetGroupStudySessions(groupID int64, limit, offset int) (*models.GroupStudySessionsResponse, error)
-	CreateGroup(name string) (*models.GroupResponse, error)
+	GetGroups(ctx context.Context, params models.ListParams) (*models.GroupListResponse, error)
+	GetGroupByID(ctx context.Context, id int64) (*models.GroupDetailResponse, error)
+	GetGroupWords(ctx context.Context, groupID int64, limit, offset int) (*models.GroupWordsResponse, error)
+	GetGroupStudySessions(ctx context.Context, groupID int64, limit, offset int) (*models.GroupStudySessionsResponse, error)
+	CreateGroup(ctx context.Context, name string) (*models.GroupResponse, error)
+	UpdateGroup(ctx context.Context, id int64, req *models.UpdateGroupRequest, ifVersion int64) (*models.GroupDetailResponse, int64, error)
 }
 
 type GroupService struct {
@@ -21,24 +24,24 @@ func NewGroupService(repo repository.Repository) *GroupService {
 	return &GroupService{repo: repo}
 }
 
-func (s *GroupService) GetGroups(limit, offset int) (*models.GroupListResponse, error) {
-	return s.repo.GetGroups(limit, offset)
+func (s *GroupService) GetGroups(ctx context.Context, params models.ListParams) (*models.GroupListResponse, error) {
+	return s.repo.GetGroups(ctx, params)
 }
 
-func (s *GroupService) GetGroupByID(id int64) (*models.GroupDetailResponse, error) {
-	return s.repo.GetGroupByID(id)
+func (s *GroupService) GetGroupByID(ctx context.Context, id int64) (*models.GroupDetailResponse, error) {
+	return s.repo.GetGroupByID(ctx, id)
 }
 
-func (s *GroupService) GetGroupWords(groupID int64, limit, offset int) (*models.GroupWordsResponse, error) {
-	return s.repo.GetGroupWords(groupID, limit, offset)
+func (s *GroupService) GetGroupWords(ctx context.Context, groupID int64, limit, offset int) (*models.GroupWordsResponse, error) {
+	return s.repo.GetGroupWords(ctx, groupID, limit, offset)
 }
 
-func (s *GroupService) GetGroupStudySessions(groupID int64, limit, offset int) (*models.GroupStudySessionsResponse, error) {
-	return s.repo.GetGroupStudySessions(groupID, limit, offset)
+func (s *GroupService) GetGroupStudySessions(ctx context.Context, groupID int64, limit, offset int) (*models.GroupStudySessionsResponse, error) {
+	return s.repo.GetGroupStudySessions(ctx, groupID, limit, offset)
 }
 
-func (s *GroupService) CreateGroup(name string) (*models.GroupResponse, error) {
-	id, err := s.repo.CreateGroup(name)
+func (s *GroupService) CreateGroup(ctx context.Context, name string) (*models.GroupResponse, error) {
+	id, err := s.repo.CreateGroup(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -47,3 +50,18 @@ func (s *GroupService) CreateGroup(name string) (*models.GroupResponse, error) {
 		Name: name,
 	}, nil
 }
+
+// UpdateGroup renames a group, unless ifVersion is set and the group's
+// version has moved on from it. It returns the group and its version after
+// the update.
+func (s *GroupService) UpdateGroup(ctx context.Context, id int64, req *models.UpdateGroupRequest, ifVersion int64) (*models.GroupDetailResponse, int64, error) {
+	version, err := s.repo.UpdateGroup(ctx, id, req.Name, ifVersion)
+	if err != nil {
+		return nil, 0, err
+	}
+	group, err := s.repo.GetGroupByID(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return group, version, nil
+}
@@ -1,10 +1,14 @@
 package services
 
-import "github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+import (
+	"context"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
 
 // DashboardServiceInterface defines the interface for dashboard service
 type DashboardServiceInterface interface {
-	GetLastStudySession() (*models.DashboardLastStudySession, error)
-	GetStudyProgress() (*models.DashboardStudyProgress, error)
-	GetQuickStats() (*models.DashboardQuickStats, error)
+	GetLastStudySession(ctx context.Context) (*models.DashboardLastStudySession, error)
+	GetStudyProgress(ctx context.Context) (*models.DashboardStudyProgress, error)
+	GetQuickStats(ctx context.Context) (*models.DashboardQuickStats, error)
 }
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// NewJobRunners returns the runners for every job type
+func NewJobRunners(llm LLMServiceInterface, words WordServiceInterface, audio AudioServiceInterface) map[string]JobRunner {
+	return map[string]JobRunner{
+		models.JobTypeGenerateWords:     &generateWordsRunner{llm: llm},
+		models.JobTypeGenerateSentences: &generateSentencesRunner{llm: llm},
+		models.JobTypeImportWords:       &importWordsRunner{words: words},
+		models.JobTypeGenerateAudio:     &generateAudioRunner{audio: audio},
+	}
+}
+
+// decodeJobPayload decodes a job payload into its typed form
+func decodeJobPayload(payload json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(payload, v); err != nil {
+		return fmt.Errorf("malformed payload: %v", err)
+	}
+	return nil
+}
+
+type generateWordsRunner struct {
+	llm LLMServiceInterface
+}
+
+func (r *generateWordsRunner) Validate(payload json.RawMessage) error {
+	var p models.GenerateWordsJobPayload
+	if err := decodeJobPayload(payload, &p); err != nil {
+		return err
+	}
+	if p.Category == "" {
+		return fmt.Errorf("category is required")
+	}
+	if p.Count < 0 || p.Count > 50 {
+		return fmt.Errorf("count must be between 1 and 50 when set")
+	}
+	if _, ok := ParseCacheMode(string(p.Cache)); !ok {
+		return fmt.Errorf("invalid cache option %q", p.Cache)
+	}
+	return nil
+}
+
+// Run streams the generated words, optionally adding them to a group
+func (r *generateWordsRunner) Run(ctx context.Context, payload json.RawMessage, progress func(int)) (interface{}, error) {
+	var p models.GenerateWordsJobPayload
+	if err := decodeJobPayload(payload, &p); err != nil {
+		return nil, err
+	}
+
+	if p.GroupID != nil {
+		if _, err := r.llm.GetGroupByID(ctx, *p.GroupID); err != nil {
+			return nil, err
+		}
+	}
+
+	// The requested count is used to estimate progress while the words stream in
+	expected := p.Count
+	if expected <= 0 {
+		expected = defaultWordCount
+	}
+
+	generated := 0
+	response, err := r.llm.StreamWords(ctx, &p.GenerateWordsRequest, p.Cache, func(word models.WordResponse) error {
+		generated++
+		if generated < expected {
+			progress(generated * 90 / expected)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	progress(90)
+
+	result := &models.GenerateWordsJobResult{Words: response.Words}
+	if p.GroupID == nil {
+		return result, nil
+	}
+
+	// Words stored before a restart are found again rather than duplicated
+	failed := 0
+	for i := range response.Words {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		wordID, err := r.llm.FindOrCreateWord(ctx, &response.Words[i])
+		if err != nil {
+			log.Error().Err(err).Str("term", response.Words[i].Term).Msg("Failed to store generated word")
+			failed++
+			continue
+		}
+		if err := r.llm.AddWordToGroup(ctx, wordID, *p.GroupID); err != nil {
+			log.Error().Err(err).Int64("word_id", wordID).Int64("group_id", *p.GroupID).Msg("Failed to add generated word to group")
+			failed++
+			continue
+		}
+		result.WordsAdded++
+	}
+	if err := r.llm.UpdateGroupWordsCount(ctx, *p.GroupID); err != nil {
+		return nil, err
+	}
+
+	if failed > 0 {
+		return nil, fmt.Errorf("%d of %d generated words could not be added to the group", failed, len(response.Words))
+	}
+	return result, nil
+}
+
+type generateSentencesRunner struct {
+	llm LLMServiceInterface
+}
+
+func (r *generateSentencesRunner) Validate(payload json.RawMessage) error {
+	var p models.GenerateSentencesJobPayload
+	if err := decodeJobPayload(payload, &p); err != nil {
+		return err
+	}
+	if p.WordID <= 0 {
+		return fmt.Errorf("word_id is required")
+	}
+	if p.Count < 0 || p.Count > 10 {
+		return fmt.Errorf("count must be between 1 and 10 when set")
+	}
+	if _, ok := ParseCacheMode(string(p.Cache)); !ok {
+		return fmt.Errorf("invalid cache option %q", p.Cache)
+	}
+	return nil
+}
+
+func (r *generateSentencesRunner) Run(ctx context.Context, payload json.RawMessage, progress func(int)) (interface{}, error) {
+	var p models.GenerateSentencesJobPayload
+	if err := decodeJobPayload(payload, &p); err != nil {
+		return nil, err
+	}
+
+	return r.llm.GenerateSentences(ctx, p.WordID, p.Count, p.Cache)
+}
+
+type importWordsRunner struct {
+	words WordServiceInterface
+}
+
+func (r *importWordsRunner) Validate(payload json.RawMessage) error {
+	var p models.ImportWordsRequest
+	if err := decodeJobPayload(payload, &p); err != nil {
+		return err
+	}
+	if p.GroupID <= 0 {
+		return fmt.Errorf("group_id is required")
+	}
+	if len(p.Words) == 0 {
+		return fmt.Errorf("words are required")
+	}
+	return nil
+}
+
+func (r *importWordsRunner) Run(ctx context.Context, payload json.RawMessage, progress func(int)) (interface{}, error) {
+	var p models.ImportWordsRequest
+	if err := decodeJobPayload(payload, &p); err != nil {
+		return nil, err
+	}
+
+	result, err := r.words.ImportWordsWithProgress(ctx, &p, func(done, total int) {
+		progress(done * 100 / total)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.FailedCount > 0 {
+		return nil, fmt.Errorf("%d of %d words could not be imported", result.FailedCount, len(p.Words))
+	}
+	return result, nil
+}
+
+type generateAudioRunner struct {
+	audio AudioServiceInterface
+}
+
+func (r *generateAudioRunner) Validate(payload json.RawMessage) error {
+	var p models.GenerateAudioJobPayload
+	if err := decodeJobPayload(payload, &p); err != nil {
+		return err
+	}
+	if p.GroupID <= 0 {
+		return fmt.Errorf("group_id is required")
+	}
+	if p.Voice != "" && !ValidVoice(p.Voice) {
+		return fmt.Errorf("invalid voice %q", p.Voice)
+	}
+	return nil
+}
+
+// Run pre-generates pronunciation audio for every word in a group
+func (r *generateAudioRunner) Run(ctx context.Context, payload json.RawMessage, progress func(int)) (interface{}, error) {
+	var p models.GenerateAudioJobPayload
+	if err := decodeJobPayload(payload, &p); err != nil {
+		return nil, err
+	}
+
+	return r.audio.GenerateGroupAudio(ctx, p.GroupID, p.Voice, func(done, total int) {
+		if total > 0 {
+			progress(done * 100 / total)
+		}
+	})
+}
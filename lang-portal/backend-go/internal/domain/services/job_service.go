@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type JobServiceInterface interface {
+	CreateJob(ctx context.Context, req *models.CreateJobRequest) (*models.Job, error)
+	GetJob(ctx context.Context, id int64) (*models.Job, error)
+	GetJobs(ctx context.Context, status string, params models.ListParams) (*models.JobListResponse, error)
+	CancelJob(ctx context.Context, id int64) (*models.Job, error)
+}
+
+// JobRunner executes one type of job. It reports progress from 0 to 100 and
+// must stop when ctx is cancelled. The returned result is stored as JSON.
+type JobRunner interface {
+	Validate(payload json.RawMessage) error
+	Run(ctx context.Context, payload json.RawMessage, progress func(int)) (interface{}, error)
+}
+
+const (
+	// defaultJobWorkers is how many jobs run at once unless JOB_WORKERS overrides it
+	defaultJobWorkers = 2
+	// jobPollInterval is how often idle workers look for jobs they were not woken for
+	jobPollInterval = 5 * time.Second
+)
+
+type JobService struct {
+	repo    repository.Repository
+	runners map[string]JobRunner
+	wake    chan struct{}
+
+	mu      sync.Mutex
+	running map[int64]context.CancelFunc
+}
+
+func NewJobService(repo repository.Repository, runners map[string]JobRunner) *JobService {
+	return &JobService{
+		repo:    repo,
+		runners: runners,
+		wake:    make(chan struct{}, 1),
+		running: make(map[int64]context.CancelFunc),
+	}
+}
+
+// jobWorkers reads the worker count from JOB_WORKERS, falling back to the
+// default when unset or invalid
+func jobWorkers() int {
+	value := os.Getenv("JOB_WORKERS")
+	if value == "" {
+		return defaultJobWorkers
+	}
+
+	workers, err := strconv.Atoi(value)
+	if err != nil || workers < 1 {
+		log.Warn().Str("JOB_WORKERS", value).Msg("Invalid job worker count, using default")
+		return defaultJobWorkers
+	}
+	return workers
+}
+
+// Start requeues jobs interrupted by a previous shutdown and starts the
+// workers. Workers stop when ctx is cancelled; jobs they were running are
+// left as running and resumed on the next start.
+func (s *JobService) Start(ctx context.Context, workers int) error {
+	if workers < 1 {
+		workers = jobWorkers()
+	}
+
+	requeued, err := s.repo.RequeueRunningJobs(ctx)
+	if err != nil {
+		return err
+	}
+	if requeued > 0 {
+		log.Info().Int64("jobs", requeued).Msg("Resuming interrupted jobs")
+	}
+
+	for i := 0; i < workers; i++ {
+		go s.work(ctx)
+	}
+	return nil
+}
+
+// CreateJob validates and queues a job for the workers
+func (s *JobService) CreateJob(ctx context.Context, req *models.CreateJobRequest) (*models.Job, error) {
+	runner, ok := s.runners[req.Type]
+	if !ok {
+		return nil, models.ValidationError("invalid_job_payload", fmt.Sprintf("invalid job payload: unsupported job type %q", req.Type))
+	}
+	if err := runner.Validate(req.Payload); err != nil {
+		return nil, models.ValidationError("invalid_job_payload", "invalid job payload: "+err.Error())
+	}
+
+	id, err := s.repo.CreateJob(ctx, req.Type, req.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wake an idle worker without blocking when all of them are busy
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return s.GetJob(ctx, id)
+}
+
+func (s *JobService) GetJob(ctx context.Context, id int64) (*models.Job, error) {
+	return s.repo.GetJob(ctx, id)
+}
+
+func (s *JobService) GetJobs(ctx context.Context, status string, params models.ListParams) (*models.JobListResponse, error) {
+	return s.repo.GetJobs(ctx, status, params)
+}
+
+// CancelJob cancels a pending job, or stops a running one
+func (s *JobService) CancelJob(ctx context.Context, id int64) (*models.Job, error) {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.repo.CancelJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, models.ConflictError("job_not_cancellable", fmt.Sprintf("job cannot be cancelled: job is already %s", job.Status))
+	}
+
+	s.mu.Lock()
+	if cancel, ok := s.running[id]; ok {
+		cancel()
+	}
+	s.mu.Unlock()
+
+	return s.GetJob(ctx, id)
+}
+
+// work claims and runs jobs until ctx is cancelled
+func (s *JobService) work(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := s.repo.ClaimNextJob(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("Failed to claim job")
+		}
+		if job != nil {
+			s.run(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-s.wake:
+		case <-time.After(jobPollInterval):
+		}
+	}
+}
+
+// run executes a claimed job and records its outcome
+func (s *JobService) run(ctx context.Context, job *models.Job) {
+	jobCtx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	s.running[job.ID] = cancel
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, job.ID)
+		s.mu.Unlock()
+		cancel()
+	}()
+
+	logger := log.With().Int64("job_id", job.ID).Str("type", job.Type).Logger()
+	result, err := s.execute(jobCtx, job)
+
+	switch {
+	case ctx.Err() != nil:
+		// Shutting down: leave the job running so it is requeued on restart
+		logger.Info().Msg("Job interrupted by shutdown")
+	case jobCtx.Err() != nil:
+		logger.Info().Msg("Job cancelled")
+	case err != nil:
+		logger.Error().Err(err).Msg("Job failed")
+		if err := s.repo.FailJob(ctx, job.ID, err.Error()); err != nil {
+			logger.Error().Err(err).Msg("Failed to record job failure")
+		}
+	default:
+		output, err := json.Marshal(result)
+		if err == nil {
+			err = s.repo.CompleteJob(ctx, job.ID, output)
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to record job result")
+		}
+	}
+}
+
+// execute runs the job's runner, turning panics into job failures
+func (s *JobService) execute(ctx context.Context, job *models.Job) (result interface{}, err error) {
+	runner, ok := s.runners[job.Type]
+	if !ok {
+		return nil, fmt.Errorf("unsupported job type %q", job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	last := job.Progress
+	return runner.Run(ctx, job.Payload, func(progress int) {
+		if progress <= last || progress > 100 {
+			return
+		}
+		last = progress
+		if err := s.repo.UpdateJobProgress(ctx, job.ID, progress); err != nil {
+			log.Error().Err(err).Int64("job_id", job.ID).Msg("Failed to update job progress")
+		}
+	})
+}
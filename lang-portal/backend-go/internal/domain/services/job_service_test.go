@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeJobRunner runs a function in place of a real job
+type fakeJobRunner struct {
+	run func(ctx context.Context, progress func(int)) (interface{}, error)
+}
+
+func (r *fakeJobRunner) Validate(payload json.RawMessage) error {
+	var p map[string]interface{}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return err
+	}
+	if p["ok"] != true {
+		return errors.New("ok must be true")
+	}
+	return nil
+}
+
+func (r *fakeJobRunner) Run(ctx context.Context, payload json.RawMessage, progress func(int)) (interface{}, error) {
+	return r.run(ctx, progress)
+}
+
+func TestJobService_CreateJob(t *testing.T) {
+	runners := map[string]JobRunner{models.JobTypeImportWords: &fakeJobRunner{}}
+
+	t.Run("queues a valid job", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewJobService(mockRepo, runners)
+
+		payload := json.RawMessage(`{"ok": true}`)
+		mockRepo.On("CreateJob", models.JobTypeImportWords, []byte(payload)).Return(int64(1), nil)
+		mockRepo.On("GetJob", int64(1)).Return(&models.Job{ID: 1, Type: models.JobTypeImportWords, Status: models.JobStatusPending}, nil)
+
+		job, err := service.CreateJob(context.Background(), &models.CreateJobRequest{Type: models.JobTypeImportWords, Payload: payload})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.JobStatusPending, job.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an invalid payload", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewJobService(mockRepo, runners)
+
+		_, err := service.CreateJob(context.Background(), &models.CreateJobRequest{Type: models.JobTypeImportWords, Payload: json.RawMessage(`{"ok": false}`)})
+
+		assert.ErrorContains(t, err, "invalid job payload")
+		mockRepo.AssertNotCalled(t, "CreateJob", mock.Anything, mock.Anything)
+	})
+}
+
+func TestJobService_Run(t *testing.T) {
+	job := &models.Job{ID: 7, Type: models.JobTypeImportWords, Status: models.JobStatusRunning, Payload: json.RawMessage(`{"ok": true}`)}
+
+	t.Run("records progress and result", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewJobService(mockRepo, map[string]JobRunner{
+			models.JobTypeImportWords: &fakeJobRunner{run: func(ctx context.Context, progress func(int)) (interface{}, error) {
+				progress(50)
+				progress(50)
+				return map[string]int{"imported_count": 2}, nil
+			}},
+		})
+
+		mockRepo.On("UpdateJobProgress", int64(7), 50).Return(nil).Once()
+		mockRepo.On("CompleteJob", int64(7), []byte(`{"imported_count":2}`)).Return(nil)
+
+		service.run(context.Background(), job)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("records failures and panics", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewJobService(mockRepo, map[string]JobRunner{
+			models.JobTypeImportWords: &fakeJobRunner{run: func(ctx context.Context, progress func(int)) (interface{}, error) {
+				panic("boom")
+			}},
+		})
+
+		mockRepo.On("FailJob", int64(7), "job panicked: boom").Return(nil)
+
+		service.run(context.Background(), job)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("cancel stops a running job", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		started := make(chan struct{})
+		finished := make(chan struct{})
+		service := NewJobService(mockRepo, map[string]JobRunner{
+			models.JobTypeImportWords: &fakeJobRunner{run: func(ctx context.Context, progress func(int)) (interface{}, error) {
+				close(started)
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}},
+		})
+
+		mockRepo.On("GetJob", int64(7)).Return(job, nil)
+		mockRepo.On("CancelJob", int64(7)).Return(true, nil)
+
+		go func() {
+			service.run(context.Background(), job)
+			close(finished)
+		}()
+		<-started
+
+		_, err := service.CancelJob(context.Background(), 7)
+		assert.NoError(t, err)
+
+		select {
+		case <-finished:
+		case <-time.After(time.Second):
+			t.Fatal("job was not stopped")
+		}
+		mockRepo.AssertNotCalled(t, "FailJob", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "CompleteJob", mock.Anything, mock.Anything)
+	})
+
+	t.Run("finished jobs cannot be cancelled", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewJobService(mockRepo, nil)
+
+		mockRepo.On("GetJob", int64(7)).Return(&models.Job{ID: 7, Status: models.JobStatusCompleted}, nil)
+		mockRepo.On("CancelJob", int64(7)).Return(false, nil)
+
+		_, err := service.CancelJob(context.Background(), 7)
+
+		assert.EqualError(t, err, "job cannot be cancelled: job is already completed")
+	})
+}
+
+func TestJobService_StartRequeuesInterruptedJobs(t *testing.T) {
+	mockRepo := new(mocks.MockRepository)
+	service := NewJobService(mockRepo, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mockRepo.On("RequeueRunningJobs").Return(int64(2), nil)
+	mockRepo.On("ClaimNextJob").Return(nil, nil).Maybe()
+
+	assert.NoError(t, service.Start(ctx, 1))
+	mockRepo.AssertExpectations(t)
+}
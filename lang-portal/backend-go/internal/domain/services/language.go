@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// scriptTables lists the Unicode scripts each writing system is made of
+var scriptTables = map[string][]*unicode.RangeTable{
+	models.ScriptLatin:    {unicode.Latin},
+	models.ScriptCyrillic: {unicode.Cyrillic},
+	models.ScriptGreek:    {unicode.Greek},
+	models.ScriptArabic:   {unicode.Arabic},
+	models.ScriptHan:      {unicode.Han},
+	models.ScriptHangul:   {unicode.Hangul, unicode.Han},
+	models.ScriptJapanese: {unicode.Han, unicode.Hiragana, unicode.Katakana},
+}
+
+// matchesScript reports whether text is written in a script. At least one
+// letter must belong to the script. Latin letters are accepted alongside any
+// script, for loanwords and abbreviations such as "Tシャツ", and letters shared
+// between scripts (like the Japanese long vowel mark) are ignored.
+func matchesScript(script, text string) bool {
+	tables, ok := scriptTables[script]
+	if !ok {
+		return true
+	}
+
+	found := false
+	for _, r := range text {
+		switch {
+		case !unicode.IsLetter(r), unicode.Is(unicode.Common, r):
+		case unicode.In(r, tables...):
+			found = true
+		case !unicode.Is(unicode.Latin, r):
+			return false
+		}
+	}
+	return found
+}
+
+// validateCourseText checks that text is written in the course's target
+// language and its translation in the source language
+func validateCourseText(course *models.CourseResponse, text, translation string) error {
+	if strings.TrimSpace(text) == "" || strings.TrimSpace(translation) == "" {
+		return fmt.Errorf("text and translation are required")
+	}
+	if !matchesScript(course.TargetLanguage.Script, text) {
+		return fmt.Errorf("%q is not written in %s", text, course.TargetLanguage.Name)
+	}
+	if !matchesScript(course.SourceLanguage.Script, translation) {
+		return fmt.Errorf("%q is not written in %s", translation, course.SourceLanguage.Name)
+	}
+	return nil
+}
+
+// getCourse loads a course, falling back to the default course for id 0
+func getCourse(ctx context.Context, repo repository.Repository, id int64) (*models.CourseResponse, error) {
+	if id == 0 {
+		id = models.DefaultCourseID
+	}
+
+	return repo.GetCourse(ctx, id)
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+var (
+	english  = models.LanguageResponse{Code: "en", Name: "English", Script: models.ScriptLatin}
+	italian  = models.LanguageResponse{Code: "it", Name: "Italian", Script: models.ScriptLatin}
+	japanese = models.LanguageResponse{Code: "ja", Name: "Japanese", Script: models.ScriptJapanese}
+
+	italianCourse  = &models.CourseResponse{ID: models.DefaultCourseID, Name: "Italian for English speakers", SourceLanguage: english, TargetLanguage: italian}
+	japaneseCourse = &models.CourseResponse{ID: 2, Name: "Japanese for English speakers", SourceLanguage: english, TargetLanguage: japanese}
+)
+
+func TestMatchesScript(t *testing.T) {
+	tests := []struct {
+		script string
+		text   string
+		want   bool
+	}{
+		{models.ScriptLatin, "perché", true},
+		{models.ScriptLatin, "l'acqua", true},
+		{models.ScriptLatin, "猫", false},
+		{models.ScriptLatin, "123", false},
+		{models.ScriptJapanese, "ねこ", true},
+		{models.ScriptJapanese, "コーヒー", true},
+		{models.ScriptJapanese, "Tシャツ", true},
+		{models.ScriptJapanese, "cat", false},
+		{models.ScriptJapanese, "고양이", false},
+		{models.ScriptCyrillic, "кошка", true},
+		{"unknown", "anything", true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, matchesScript(tt.script, tt.text), "%s %q", tt.script, tt.text)
+	}
+}
+
+func TestValidateCourseText(t *testing.T) {
+	assert.NoError(t, validateCourseText(japaneseCourse, "猫", "cat"))
+	assert.EqualError(t, validateCourseText(japaneseCourse, "neko", "cat"), `"neko" is not written in Japanese`)
+	assert.EqualError(t, validateCourseText(japaneseCourse, "猫", "ねこ"), `"ねこ" is not written in English`)
+	assert.Error(t, validateCourseText(italianCourse, "casa", " "))
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// maxLearnerNameLength is the longest learner name, in characters
+const maxLearnerNameLength = 100
+
+type LearnerServiceInterface interface {
+	CreateLearner(ctx context.Context, req *models.CreateLearnerRequest) (*models.Learner, error)
+}
+
+// LearnerService manages the profile of the portal's single learner. The
+// profile holds a name and an optional email for the learner's own records;
+// there are no passwords or logins.
+type LearnerService struct {
+	repo repository.Repository
+}
+
+func NewLearnerService(repo repository.Repository) *LearnerService {
+	return &LearnerService{repo: repo}
+}
+
+// CreateLearner creates the learner's profile, which can only be done once
+func (s *LearnerService) CreateLearner(ctx context.Context, req *models.CreateLearnerRequest) (*models.Learner, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, models.ValidationError("invalid_learner", "invalid learner: name is required")
+	}
+	if utf8.RuneCountInString(name) > maxLearnerNameLength {
+		return nil, models.ValidationError("invalid_learner", fmt.Sprintf("invalid learner: name is longer than %d characters", maxLearnerNameLength))
+	}
+
+	learner := &models.Learner{Name: name}
+	if email := strings.TrimSpace(req.Email); email != "" {
+		address, err := mail.ParseAddress(email)
+		if err != nil || address.Address != email {
+			return nil, models.ValidationError("invalid_learner", fmt.Sprintf("invalid learner: invalid email %q", email))
+		}
+		learner.Email = &email
+	}
+
+	if err := s.repo.CreateLearner(ctx, learner); err != nil {
+		return nil, err
+	}
+	return s.repo.GetLearner(ctx)
+}
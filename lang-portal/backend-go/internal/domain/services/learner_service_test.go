@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+)
+
+func TestLearnerService_CreateLearner(t *testing.T) {
+	t.Run("creates learner", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewLearnerService(mockRepo)
+
+		email := "giulia@example.com"
+		mockRepo.On("CreateLearner", &models.Learner{Name: "Giulia", Email: &email}).Return(nil)
+		mockRepo.On("GetLearner").Return(&models.Learner{Name: "Giulia", Email: &email}, nil)
+
+		learner, err := service.CreateLearner(context.Background(), &models.CreateLearnerRequest{Name: " Giulia ", Email: email})
+		require.NoError(t, err)
+		assert.Equal(t, "Giulia", learner.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("email is optional", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewLearnerService(mockRepo)
+
+		mockRepo.On("CreateLearner", &models.Learner{Name: "Giulia"}).Return(nil)
+		mockRepo.On("GetLearner").Return(&models.Learner{Name: "Giulia"}, nil)
+
+		learner, err := service.CreateLearner(context.Background(), &models.CreateLearnerRequest{Name: "Giulia"})
+		require.NoError(t, err)
+		assert.Nil(t, learner.Email)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid profile", func(t *testing.T) {
+		for name, req := range map[string]models.CreateLearnerRequest{
+			"no name":       {Name: "  "},
+			"long name":     {Name: strings.Repeat("a", maxLearnerNameLength+1)},
+			"invalid email": {Name: "Giulia", Email: "giulia"},
+			"display name":  {Name: "Giulia", Email: "Giulia <giulia@example.com>"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				mockRepo := new(mocks.MockRepository)
+				_, err := NewLearnerService(mockRepo).CreateLearner(context.Background(), &req)
+				assert.ErrorIs(t, err, models.ErrValidation)
+				mockRepo.AssertNotCalled(t, "CreateLearner", mock.Anything)
+			})
+		}
+	})
+
+	t.Run("already created", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("CreateLearner", &models.Learner{Name: "Giulia"}).Return(models.ConflictError("learner_exists", "the learner has already been created"))
+
+		_, err := NewLearnerService(mockRepo).CreateLearner(context.Background(), &models.CreateLearnerRequest{Name: "Giulia"})
+		assert.ErrorIs(t, err, models.ErrConflict)
+		mockRepo.AssertExpectations(t)
+	})
+}
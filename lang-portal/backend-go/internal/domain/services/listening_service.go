@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type ListeningServiceInterface interface {
+	GetExercises(ctx context.Context, courseID int64, limit, offset int) (*models.ListeningExerciseListResponse, error)
+	GetExercise(ctx context.Context, id int64) (*models.ListeningExerciseResponse, error)
+	ImportPipelineOutput(ctx context.Context, output *models.ListeningPipelineOutput, opts models.ListeningImportOptions) (*models.ListeningImportResponse, error)
+	SubmitAnswer(ctx context.Context, sessionID int64, req *models.ListeningAnswerRequest) (*models.ListeningAnswerResponse, error)
+}
+
+type ListeningService struct {
+	repo       repository.Repository
+	vocabulary AnalyzerServiceInterface
+}
+
+// NewListeningService creates a listening service. Vocabulary from imported
+// exercises is added through the analyzer so words already known in one of
+// their forms are not added again.
+func NewListeningService(repo repository.Repository, vocabulary AnalyzerServiceInterface) *ListeningService {
+	return &ListeningService{repo: repo, vocabulary: vocabulary}
+}
+
+// GetExercises lists exercises, only those of one course when courseID is not 0
+func (s *ListeningService) GetExercises(ctx context.Context, courseID int64, limit, offset int) (*models.ListeningExerciseListResponse, error) {
+	return s.repo.GetListeningExercises(ctx, courseID, limit, offset)
+}
+
+// GetExercise returns an exercise with its transcript and questions
+func (s *ListeningService) GetExercise(ctx context.Context, id int64) (*models.ListeningExerciseResponse, error) {
+	exercise, err := s.repo.GetListeningExercise(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := s.repo.GetListeningSegments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.repo.GetListeningQuestions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// A segment lasts until the next one starts
+	for i := range segments {
+		if i+1 < len(segments) {
+			segments[i].EndMs = segments[i+1].StartMs
+		} else {
+			segments[i].EndMs = exercise.EndMs
+		}
+	}
+
+	return &models.ListeningExerciseResponse{
+		ListeningExercise: *exercise,
+		Segments:          segments,
+		Questions:         questions,
+	}, nil
+}
+
+// ImportPipelineOutput stores the structured output of the listening-comp
+// pipeline as an exercise. Importing the same output again returns the
+// exercise imported the first time. When a group is given, the output's
+// vocabulary is added to it.
+func (s *ListeningService) ImportPipelineOutput(ctx context.Context, output *models.ListeningPipelineOutput, opts models.ListeningImportOptions) (*models.ListeningImportResponse, error) {
+	course, err := getCourse(ctx, s.repo, opts.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	exercise, segments, questions, err := listeningExerciseFromPipeline(output)
+	if err != nil {
+		return nil, models.ValidationError("invalid_listening_exercise", "invalid listening exercise: "+err.Error())
+	}
+	exercise.CourseID = course.ID
+	if opts.SourceURL != "" {
+		exercise.SourceURL = &opts.SourceURL
+	}
+
+	response := &models.ListeningImportResponse{
+		SegmentCount:  len(segments),
+		QuestionCount: len(questions),
+	}
+
+	// Vocabulary is added first so an unknown group fails the import
+	// before anything is stored
+	if opts.GroupID != 0 && len(output.Vocabulary) > 0 {
+		req := &models.AddUnknownWordsRequest{GroupID: opts.GroupID, CourseID: course.ID}
+		for _, entry := range output.Vocabulary {
+			parts := map[string]interface{}{}
+			if entry.PartOfSpeech != "" {
+				parts["type"] = entry.PartOfSpeech
+			}
+			req.Words = append(req.Words, models.UnknownWordSelection{
+				Term:        entry.ItalianTerm,
+				Translation: entry.EnglishTranslation,
+				Parts:       parts,
+			})
+		}
+		response.Vocabulary, err = s.vocabulary.AddUnknownWords(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hash, err := listeningContentHash(output)
+	if err != nil {
+		return nil, err
+	}
+	response.ExerciseID, response.Created, err = s.repo.CreateListeningExercise(ctx, exercise, hash, segments, questions)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// SubmitAnswer grades an answer to a listening question and records it in a
+// study session
+func (s *ListeningService) SubmitAnswer(ctx context.Context, sessionID int64, req *models.ListeningAnswerRequest) (*models.ListeningAnswerResponse, error) {
+	question, err := s.repo.GetListeningQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := *req.SelectedOption
+	if selected >= len(question.Options) {
+		return nil, models.ValidationError("invalid_option", fmt.Sprintf("invalid option %d: the question has %d options", selected, len(question.Options)))
+	}
+
+	correct := selected == question.CorrectOption
+	if err := s.repo.CreateListeningAnswer(ctx, question.ID, sessionID, selected, correct); err != nil {
+		return nil, err
+	}
+
+	return &models.ListeningAnswerResponse{
+		QuestionID:     question.ID,
+		SelectedOption: selected,
+		CorrectOption:  question.CorrectOption,
+		Correct:        correct,
+	}, nil
+}
+
+// listeningExerciseFromPipeline maps pipeline output to an exercise, its
+// transcript segments and its multiple-choice questions
+func listeningExerciseFromPipeline(output *models.ListeningPipelineOutput) (*models.ListeningExercise, []models.ListeningSegment, []models.ListeningQuestion, error) {
+	metadata := output.Metadata
+	exercise := &models.ListeningExercise{
+		Title:   strings.TrimSpace(metadata.VideoTitle),
+		Level:   metadata.LanguageLevel,
+		Topic:   metadata.Topic,
+		Context: metadata.Context,
+	}
+	if exercise.Title == "" {
+		return nil, nil, nil, fmt.Errorf("the video title is missing")
+	}
+
+	if metadata.VideoTimestamp != "" {
+		start, end, _ := strings.Cut(metadata.VideoTimestamp, "-")
+		var err error
+		if exercise.StartMs, err = parseListeningTimestamp(start); err != nil {
+			return nil, nil, nil, err
+		}
+		if exercise.EndMs, err = parseListeningTimestamp(end); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	segments := []models.ListeningSegment{}
+	for _, line := range output.Dialogue {
+		startMs, err := parseListeningTimestamp(line.AudioTimestamp)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		keyPhrases := line.KeyPhrases
+		if keyPhrases == nil {
+			keyPhrases = []string{}
+		}
+		segments = append(segments, models.ListeningSegment{
+			Position:    len(segments) + 1,
+			Speaker:     line.Speaker,
+			Text:        line.ItalianText,
+			Translation: line.EnglishTranslation,
+			StartMs:     startMs,
+			KeyPhrases:  keyPhrases,
+		})
+	}
+
+	questions := []models.ListeningQuestion{}
+	for i, item := range output.Exercises {
+		if item.Type != "" && item.Type != "multiple_choice" {
+			log.Warn().Str("type", item.Type).Msg("Skipping unsupported listening exercise type")
+			continue
+		}
+		if len(item.Options) < 2 {
+			return nil, nil, nil, fmt.Errorf("question %d has fewer than two options", i+1)
+		}
+		correct := correctOptionIndex(item.Options, item.CorrectAnswer)
+		if correct < 0 {
+			return nil, nil, nil, fmt.Errorf("the answer to question %d is not one of its options", i+1)
+		}
+		startMs, err := parseListeningTimestamp(item.AudioTimestamp)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		questions = append(questions, models.ListeningQuestion{
+			Position:      len(questions) + 1,
+			Question:      item.Question,
+			Options:       item.Options,
+			CorrectOption: correct,
+			StartMs:       startMs,
+		})
+	}
+
+	return exercise, segments, questions, nil
+}
+
+// correctOptionIndex finds the option the pipeline gave as the answer, either
+// by its text or by its letter (A, B, C...)
+func correctOptionIndex(options []string, answer string) int {
+	answer = strings.TrimSpace(answer)
+	for i, option := range options {
+		if strings.EqualFold(strings.TrimSpace(option), answer) {
+			return i
+		}
+	}
+	if len(answer) == 1 {
+		if i := int(strings.ToUpper(answer)[0] - 'A'); i >= 0 && i < len(options) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseListeningTimestamp converts a "ss", "mm:ss" or "hh:mm:ss" timestamp to
+// milliseconds. An empty timestamp is no timestamp.
+func parseListeningTimestamp(timestamp string) (*int64, error) {
+	timestamp = strings.TrimSpace(timestamp)
+	if timestamp == "" {
+		return nil, nil
+	}
+
+	fields := strings.Split(timestamp, ":")
+	if len(fields) > 3 {
+		return nil, fmt.Errorf("invalid timestamp %q", timestamp)
+	}
+	var seconds int64
+	for i, field := range fields {
+		value, err := strconv.ParseInt(field, 10, 64)
+		if err != nil || value < 0 || (i > 0 && value >= 60) {
+			return nil, fmt.Errorf("invalid timestamp %q", timestamp)
+		}
+		seconds = seconds*60 + value
+	}
+	ms := seconds * 1000
+	return &ms, nil
+}
+
+// listeningContentHash identifies pipeline output by its content, ignoring how
+// the JSON was formatted
+func listeningContentHash(output *models.ListeningPipelineOutput) (string, error) {
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
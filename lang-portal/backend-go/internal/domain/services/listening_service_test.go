@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+)
+
+// pipelineOutput is trimmed from a structured-data file of the listening-comp pipeline
+func pipelineOutput() *models.ListeningPipelineOutput {
+	return &models.ListeningPipelineOutput{
+		Metadata: models.ListeningPipelineMetadata{
+			VideoTitle:     "Italian for Beginners - Everyday Life: Marco's Story",
+			LanguageLevel:  "A1",
+			Topic:          "Daily Life",
+			VideoTimestamp: "00:00-05:30",
+		},
+		Dialogue: []models.ListeningPipelineLine{
+			{Speaker: "Narrator", ItalianText: "Lui si chiama Marco e ha venticinque anni.", EnglishTranslation: "His name is Marco, and he is 25 years old.", AudioTimestamp: "00:05", KeyPhrases: []string{"si chiama Marco"}},
+			{Speaker: "Narrator", ItalianText: "Vive a Milano.", EnglishTranslation: "He lives in Milan.", AudioTimestamp: "00:10"},
+		},
+		Vocabulary: []models.ListeningPipelineVocabulary{
+			{ItalianTerm: "venticinque", PartOfSpeech: "number", EnglishTranslation: "twenty-five"},
+		},
+		Exercises: []models.ListeningPipelineExercise{
+			{Type: "multiple_choice", Question: "Dove vive Marco?", Options: []string{"Roma", "Milano", "Torino"}, CorrectAnswer: "Milano", AudioTimestamp: "00:10"},
+		},
+	}
+}
+
+func TestParseListeningTimestamp(t *testing.T) {
+	tests := []struct {
+		timestamp string
+		want      *int64
+		wantErr   bool
+	}{
+		{timestamp: "00:05", want: int64Ptr(5000)},
+		{timestamp: "05:30", want: int64Ptr(330000)},
+		{timestamp: "1:02:03", want: int64Ptr(3723000)},
+		{timestamp: "42", want: int64Ptr(42000)},
+		{timestamp: "", want: nil},
+		{timestamp: "00:75", wantErr: true},
+		{timestamp: "ten", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseListeningTimestamp(tt.timestamp)
+		if tt.wantErr {
+			assert.Error(t, err, tt.timestamp)
+			continue
+		}
+		require.NoError(t, err, tt.timestamp)
+		assert.Equal(t, tt.want, got, tt.timestamp)
+	}
+}
+
+func TestListeningExerciseFromPipeline(t *testing.T) {
+	exercise, segments, questions, err := listeningExerciseFromPipeline(pipelineOutput())
+	require.NoError(t, err)
+
+	assert.Equal(t, "A1", exercise.Level)
+	assert.Equal(t, int64Ptr(0), exercise.StartMs)
+	assert.Equal(t, int64Ptr(330000), exercise.EndMs)
+
+	require.Len(t, segments, 2)
+	assert.Equal(t, 2, segments[1].Position)
+	assert.Equal(t, int64Ptr(10000), segments[1].StartMs)
+	assert.Equal(t, []string{}, segments[1].KeyPhrases)
+
+	require.Len(t, questions, 1)
+	assert.Equal(t, 1, questions[0].CorrectOption)
+
+	output := pipelineOutput()
+	output.Exercises[0].CorrectAnswer = "Napoli"
+	_, _, _, err = listeningExerciseFromPipeline(output)
+	assert.Error(t, err)
+
+	assert.Equal(t, 2, correctOptionIndex([]string{"Roma", "Milano", "Torino"}, "c"))
+}
+
+func TestListeningService_ImportPipelineOutput(t *testing.T) {
+	mockRepo := new(mocks.MockRepository)
+	analyzer := new(mockVocabularyAdder)
+	service := NewListeningService(mockRepo, analyzer)
+
+	mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+	analyzer.On("AddUnknownWords", mock.MatchedBy(func(req *models.AddUnknownWordsRequest) bool {
+		return req.GroupID == 3 && len(req.Words) == 1 && req.Words[0].Parts["type"] == "number"
+	})).Return(&models.AddUnknownWordsResponse{AddedCount: 1, AlreadyKnown: []string{}}, nil)
+	mockRepo.On("CreateListeningExercise",
+		mock.MatchedBy(func(e *models.ListeningExercise) bool { return e.CourseID == models.DefaultCourseID }),
+		mock.AnythingOfType("string"),
+		mock.Anything,
+		mock.Anything,
+	).Return(int64(7), true, nil)
+
+	response, err := service.ImportPipelineOutput(context.Background(), pipelineOutput(), models.ListeningImportOptions{GroupID: 3})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), response.ExerciseID)
+	assert.True(t, response.Created)
+	assert.Equal(t, 2, response.SegmentCount)
+	assert.Equal(t, 1, response.Vocabulary.AddedCount)
+	mockRepo.AssertExpectations(t)
+	analyzer.AssertExpectations(t)
+
+	first, err := listeningContentHash(pipelineOutput())
+	require.NoError(t, err)
+	second, err := listeningContentHash(pipelineOutput())
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func TestListeningService_SubmitAnswer(t *testing.T) {
+	question := &models.ListeningQuestion{ID: 4, Options: []string{"Roma", "Milano", "Torino"}, CorrectOption: 1}
+
+	t.Run("graded and recorded", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewListeningService(mockRepo, nil)
+		mockRepo.On("GetListeningQuestion", int64(4)).Return(question, nil)
+		mockRepo.On("CreateListeningAnswer", int64(4), int64(2), 0, false).Return(nil)
+
+		response, err := service.SubmitAnswer(context.Background(), 2, &models.ListeningAnswerRequest{QuestionID: 4, SelectedOption: intPtr(0)})
+		require.NoError(t, err)
+		assert.False(t, response.Correct)
+		assert.Equal(t, 1, response.CorrectOption)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("option out of range", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewListeningService(mockRepo, nil)
+		mockRepo.On("GetListeningQuestion", int64(4)).Return(question, nil)
+
+		_, err := service.SubmitAnswer(context.Background(), 2, &models.ListeningAnswerRequest{QuestionID: 4, SelectedOption: intPtr(3)})
+		assert.ErrorContains(t, err, "invalid option")
+		mockRepo.AssertNotCalled(t, "CreateListeningAnswer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown question", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewListeningService(mockRepo, nil)
+		mockRepo.On("GetListeningQuestion", int64(9)).Return(nil, models.NotFoundError("listening question"))
+
+		_, err := service.SubmitAnswer(context.Background(), 2, &models.ListeningAnswerRequest{QuestionID: 9, SelectedOption: intPtr(0)})
+		assert.ErrorIs(t, err, models.ErrNotFound)
+	})
+}
+
+type mockVocabularyAdder struct {
+	mock.Mock
+}
+
+func (m *mockVocabularyAdder) AnalyzeText(_ context.Context, req *models.TextAnalysisRequest) (*models.TextAnalysisResponse, error) {
+	args := m.Called(req)
+	return args.Get(0).(*models.TextAnalysisResponse), args.Error(1)
+}
+
+func (m *mockVocabularyAdder) AddUnknownWords(_ context.Context, req *models.AddUnknownWordsRequest) (*models.AddUnknownWordsResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AddUnknownWordsResponse), args.Error(1)
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// defaultLLMCacheTTL is how long completions are reused unless LLM_CACHE_TTL
+// overrides it
+const defaultLLMCacheTTL = 7 * 24 * time.Hour
+
+// llmCacheTTL reads the cache TTL from LLM_CACHE_TTL (a Go duration such as
+// "24h"), falling back to the default when unset or invalid
+func llmCacheTTL() time.Duration {
+	value := os.Getenv("LLM_CACHE_TTL")
+	if value == "" {
+		return defaultLLMCacheTTL
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		log.Warn().Str("LLM_CACHE_TTL", value).Msg("Invalid LLM cache TTL, using default")
+		return defaultLLMCacheTTL
+	}
+	return ttl
+}
+
+// ParseCacheMode validates a cache request option
+func ParseCacheMode(value string) (models.CacheMode, bool) {
+	switch mode := models.CacheMode(value); mode {
+	case models.CacheModeDefault, models.CacheModeBypass, models.CacheModeRefresh:
+		return mode, true
+	default:
+		return "", false
+	}
+}
+
+// llmCacheKey hashes everything that influences a completion: the provider,
+// model, messages and sampling parameters
+func llmCacheKey(messages []models.ChatMessage) string {
+	payload, _ := json.Marshal(struct {
+		Provider    string               `json:"provider"`
+		Model       string               `json:"model"`
+		Messages    []models.ChatMessage `json:"messages"`
+		Temperature float64              `json:"temperature"`
+		MaxTokens   int                  `json:"max_tokens"`
+	}{llmProvider, llmModel, messages, llmTemperature, llmMaxTokens})
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// cachedCompletion returns the completion for messages, serving it from the
+// llm_cache table when the mode allows and storing fresh completions. When
+// onDelta is set the completion is streamed; cached completions are replayed
+// to onDelta in one piece. Cache failures are logged and never fail a request.
+func (s *LLMService) cachedCompletion(ctx context.Context, endpoint string, messages []models.ChatMessage, mode models.CacheMode, onDelta func(string) error) (string, error) {
+	key := llmCacheKey(messages)
+
+	if mode == models.CacheModeDefault {
+		entry, err := s.repo.GetLLMCacheEntry(ctx, key)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to read LLM cache")
+		}
+		if entry != nil {
+			s.cacheHits.Add(1)
+			if err := s.repo.RecordLLMCacheHit(ctx, key); err != nil {
+				log.Error().Err(err).Msg("Failed to record LLM cache hit")
+			}
+			if onDelta != nil {
+				if err := onDelta(entry.Response); err != nil {
+					return "", err
+				}
+			}
+			return entry.Response, nil
+		}
+		s.cacheMisses.Add(1)
+	} else {
+		s.cacheSkipped.Add(1)
+	}
+
+	content, err := s.callProvider(ctx, endpoint, messages, onDelta)
+	if err != nil {
+		return "", err
+	}
+
+	// The completion has been paid for, so it is cached even if the request
+	// was cancelled meanwhile
+	if mode != models.CacheModeBypass {
+		entry := &models.LLMCacheEntry{
+			Key:      key,
+			Provider: llmProvider,
+			Model:    llmModel,
+			Response: content,
+		}
+		if err := s.repo.SaveLLMCacheEntry(context.WithoutCancel(ctx), entry, s.cacheTTL); err != nil {
+			log.Error().Err(err).Msg("Failed to store LLM completion in cache")
+		}
+	}
+
+	return content, nil
+}
+
+// GetCacheStats reports the completion cache counters together with what is
+// currently stored
+func (s *LLMService) GetCacheStats(ctx context.Context) (*models.LLMCacheStatsResponse, error) {
+	entries, storedHits, err := s.repo.GetLLMCacheStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.LLMCacheStatsResponse{
+		Hits:       s.cacheHits.Load(),
+		Misses:     s.cacheMisses.Load(),
+		Skipped:    s.cacheSkipped.Load(),
+		Entries:    entries,
+		StoredHits: storedHits,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+	"github.com/jeevanions/lang-portal/backend-go/internal/prompts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLLMService_GenerateWordsCache(t *testing.T) {
+	cached := `[{"term": "madre", "translation": "mother", "parts": {"type": "noun"}}, {"term": "母", "translation": "mother"}]`
+	request := &models.GenerateWordsRequest{Category: "family"}
+	key := wordsCacheKey(t, NewLLMService(nil), request, italianCourse)
+
+	t.Run("hit is served without calling the provider", func(t *testing.T) {
+		t.Setenv("GROQ_API_KEY", "")
+		mockRepo := new(mocks.MockRepository)
+		service := NewLLMService(mockRepo)
+		mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+
+		mockRepo.On("GetLLMCacheEntry", key).Return(&models.LLMCacheEntry{Key: key, Response: cached}, nil)
+		mockRepo.On("RecordLLMCacheHit", key).Return(nil)
+		mockRepo.On("GetLLMCacheStats").Return(1, int64(3), nil)
+
+		response, err := service.GenerateWords(context.Background(), request, models.CacheModeDefault)
+
+		// The word that is not written in Italian is dropped
+		assert.NoError(t, err)
+		assert.Len(t, response.Words, 1)
+		assert.Equal(t, "madre", response.Words[0].Term)
+		assert.Equal(t, models.DefaultCourseID, response.Words[0].CourseID)
+		assert.Equal(t, "generate_words@v2", response.PromptVersion)
+		assert.Equal(t, "generate_words@v2", *response.Words[0].PromptVersion)
+
+		stats, err := service.GetCacheStats(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, &models.LLMCacheStatsResponse{Hits: 1, Entries: 1, StoredHits: 3}, stats)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("miss calls the provider", func(t *testing.T) {
+		t.Setenv("GROQ_API_KEY", "")
+		mockRepo := new(mocks.MockRepository)
+		service := NewLLMService(mockRepo)
+		mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+
+		mockRepo.On("GetLLMCacheEntry", key).Return(nil, nil)
+
+		_, err := service.GenerateWords(context.Background(), request, models.CacheModeDefault)
+
+		assert.EqualError(t, err, "GROQ_API_KEY environment variable not set")
+		assert.Equal(t, int64(1), service.cacheMisses.Load())
+		mockRepo.AssertNotCalled(t, "SaveLLMCacheEntry", mock.Anything, mock.Anything)
+	})
+
+	for _, mode := range []models.CacheMode{models.CacheModeBypass, models.CacheModeRefresh} {
+		t.Run(string(mode)+" skips the lookup", func(t *testing.T) {
+			t.Setenv("GROQ_API_KEY", "")
+			mockRepo := new(mocks.MockRepository)
+			service := NewLLMService(mockRepo)
+			mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+
+			_, err := service.GenerateWords(context.Background(), request, mode)
+
+			assert.Error(t, err)
+			assert.Equal(t, int64(1), service.cacheSkipped.Load())
+			mockRepo.AssertNotCalled(t, "GetLLMCacheEntry", mock.Anything)
+		})
+	}
+
+	t.Run("stream replays a hit", func(t *testing.T) {
+		t.Setenv("GROQ_API_KEY", "")
+		mockRepo := new(mocks.MockRepository)
+		service := NewLLMService(mockRepo)
+		mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+
+		mockRepo.On("GetLLMCacheEntry", key).Return(&models.LLMCacheEntry{Key: key, Response: cached}, nil)
+		mockRepo.On("RecordLLMCacheHit", key).Return(nil)
+
+		var streamed []string
+		response, err := service.StreamWords(context.Background(), request, models.CacheModeDefault, func(word models.WordResponse) error {
+			streamed = append(streamed, word.Term)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"madre"}, streamed)
+		assert.Len(t, response.Words, 1)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+// wordsCacheKey returns the cache key of the prompt rendered for a request
+func wordsCacheKey(t *testing.T, service *LLMService, req *models.GenerateWordsRequest, course *models.CourseResponse) string {
+	vars := wordsPromptVars(req, course)
+	prompt, _, err := service.prompts.Render(prompts.GenerateWords, "", vars)
+	assert.NoError(t, err)
+	messages, err := service.promptMessages(prompt, vars)
+	assert.NoError(t, err)
+	return llmCacheKey(messages)
+}
+
+func TestLLMCacheKey(t *testing.T) {
+	service := NewLLMService(nil)
+	a := wordsCacheKey(t, service, &models.GenerateWordsRequest{Category: "food"}, italianCourse)
+	assert.Equal(t, a, wordsCacheKey(t, service, &models.GenerateWordsRequest{Category: "food"}, italianCourse))
+	assert.NotEqual(t, a, wordsCacheKey(t, service, &models.GenerateWordsRequest{Category: "animals"}, italianCourse))
+	assert.NotEqual(t, a, wordsCacheKey(t, service, &models.GenerateWordsRequest{Category: "food", Level: "B2"}, italianCourse))
+	assert.NotEqual(t, a, wordsCacheKey(t, service, &models.GenerateWordsRequest{Category: "food"}, japaneseCourse))
+}
+
+func TestParseCacheMode(t *testing.T) {
+	for value, want := range map[string]bool{"": true, "bypass": true, "refresh": true, "always": false} {
+		_, ok := ParseCacheMode(value)
+		assert.Equal(t, want, ok, value)
+	}
+}
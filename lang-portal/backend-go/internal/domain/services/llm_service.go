@@ -1,143 +1,503 @@
 package services
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
 
 	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/prompts"
 )
 
 type LLMServiceInterface interface {
-	GenerateWords(category string) (*models.GenerateWordsResponse, error)
-	GetGroupByID(id int64) (*models.GroupResponse, error)
-	CreateWord(word *models.WordResponse) (int64, error)
-	AddWordToGroup(wordID, groupID int64) error
-	UpdateGroupWordsCount(groupID int64) error
+	GenerateWords(ctx context.Context, req *models.GenerateWordsRequest, cache models.CacheMode) (*models.GenerateWordsResponse, error)
+	GenerateSentences(ctx context.Context, wordID int64, count int, cache models.CacheMode) (*models.GenerateSentencesResponse, error)
+	StreamWords(ctx context.Context, req *models.GenerateWordsRequest, cache models.CacheMode, onWord func(models.WordResponse) error) (*models.GenerateWordsResponse, error)
+	GetCacheStats(ctx context.Context) (*models.LLMCacheStatsResponse, error)
+	GetUsageSummary(ctx context.Context, groupBy string, days int) (*models.LLMUsageSummaryResponse, error)
+	GetBudget(ctx context.Context) (*models.LLMBudgetResponse, error)
+	Chat(ctx context.Context, messages []models.ChatMessage) (string, error)
+	ChatStream(ctx context.Context, messages []models.ChatMessage, onDelta func(string) error) (string, error)
+	GetGroupByID(ctx context.Context, id int64) (*models.GroupResponse, error)
+	FindOrCreateWord(ctx context.Context, word *models.WordResponse) (int64, error)
+	AddWordToGroup(ctx context.Context, wordID, groupID int64) error
+	UpdateGroupWordsCount(ctx context.Context, groupID int64) error
 }
 
+// defaultSentenceCount is used when a sentence generation request omits a count
+const defaultSentenceCount = 3
+
+// defaultWordCount is used when a word generation request omits a count
+const defaultWordCount = 10
+
+// llmRequestTimeout bounds a whole completion request, including reading a stream
+const llmRequestTimeout = 2 * time.Minute
+
+// llmClient is shared by all LLM requests so connections are reused
+var llmClient = &http.Client{Timeout: llmRequestTimeout}
+
+// groqChatURL is the Groq chat completions endpoint
+var groqChatURL = "https://api.groq.com/openai/v1/chat/completions"
+
+// Groq chat completion settings, which are also part of the cache key
+const (
+	llmProvider    = "groq"
+	llmModel       = "mixtral-8x7b-32768"
+	llmTemperature = 0.7
+	llmMaxTokens   = 1000
+)
+
 type LLMService struct {
-	repo repository.Repository
+	repo     repository.Repository
+	prompts  *prompts.Store
+	cacheTTL time.Duration
+	prices   map[string]models.LLMPrice
+	budget   llmBudget
+
+	// Completion cache counters since the service was created
+	cacheHits    atomic.Int64
+	cacheMisses  atomic.Int64
+	cacheSkipped atomic.Int64
 }
 
 func NewLLMService(repo repository.Repository) *LLMService {
-	return &LLMService{repo: repo}
+	return &LLMService{
+		repo:     repo,
+		prompts:  loadPrompts(),
+		cacheTTL: llmCacheTTL(),
+		prices:   llmPrices(),
+		budget:   llmMonthlyBudget(),
+	}
 }
 
-func (s *LLMService) GenerateWords(category string) (*models.GenerateWordsResponse, error) {
-	// Groq API endpoint and key
-	apiKey := os.Getenv("GROQ_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("GROQ_API_KEY environment variable not set")
+func (s *LLMService) GenerateWords(ctx context.Context, req *models.GenerateWordsRequest, cache models.CacheMode) (*models.GenerateWordsResponse, error) {
+	course, err := getCourse(ctx, s.repo, req.CourseID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Enhanced prompt for better word generation
-	prompt := fmt.Sprintf(`Generate 10 Italian words for the thematic category: %s.
-	For each word, provide:
-	- The Italian word (with correct spelling and accents)
-	- Accurate English translation
-	- Detailed grammatical information including:
-	  * Part of speech (noun, verb, adjective, etc.)
-	  * Gender for nouns (masculine/feminine)
-	  * Plural form for nouns
-	  * Any irregular forms or important notes
-	
-	Format the response as a JSON array of objects. Each object should have this exact structure:
-	[
-		{
-			"italian": "word",
-			"english": "translation",
-			"parts": {
-				"type": "noun/verb/adjective",
-				"gender": "masculine/feminine",
-				"plural": "plural_form"
+	vars := wordsPromptVars(req, course)
+	prompt, version, err := s.prompts.Render(prompts.GenerateWords, "", vars)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.chatCompletion(ctx, llmEndpointGenerateWords, prompt, vars, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	var generated []models.WordResponse
+	if err := parseJSONArray(content, &generated); err != nil {
+		return nil, fmt.Errorf("failed to parse generated words: %v", err)
+	}
+
+	words := []models.WordResponse{}
+	for _, word := range generated {
+		if generatedWord(&word, course, version) {
+			words = append(words, word)
+		}
+	}
+
+	return &models.GenerateWordsResponse{Words: words, PromptVersion: version}, nil
+}
+
+// StreamWords generates words like GenerateWords but calls onWord for each
+// word as soon as its JSON object is complete in the token stream
+func (s *LLMService) StreamWords(ctx context.Context, req *models.GenerateWordsRequest, cache models.CacheMode, onWord func(models.WordResponse) error) (*models.GenerateWordsResponse, error) {
+	course, err := getCourse(ctx, s.repo, req.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := wordsPromptVars(req, course)
+	prompt, version, err := s.prompts.Render(prompts.GenerateWords, "", vars)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := s.promptMessages(prompt, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.GenerateWordsResponse{Words: []models.WordResponse{}, PromptVersion: version}
+	objects := &jsonObjectStream{}
+	_, err = s.cachedCompletion(ctx, llmEndpointStreamWords, messages, cache, func(delta string) error {
+		for _, raw := range objects.Write(delta) {
+			var word models.WordResponse
+			if err := json.Unmarshal(raw, &word); err != nil {
+				// Skip malformed objects rather than failing the whole stream
+				continue
+			}
+			if !generatedWord(&word, course, version) {
+				continue
+			}
+			response.Words = append(response.Words, word)
+			if err := onWord(word); err != nil {
+				return err
 			}
 		}
-	]
-	Do not include any explanations or additional text, only return the JSON array.`, category)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	// Groq API request configuration
-	reqBody := map[string]interface{}{
-		"model": "mixtral-8x7b-32768",
-		"messages": []map[string]string{
-			{
-				"role":    "system",
-				"content": "You are an expert Italian language teacher specializing in vocabulary.",
-			},
-			{
-				"role":    "user",
-				"content": prompt,
-			},
-		},
-		"temperature": 0.7,
-		"max_tokens":  1000,
+	return response, nil
+}
+
+// generatedWord prepares a word produced by the LLM for the course, reporting
+// false when it is not written in the course's languages
+func generatedWord(word *models.WordResponse, course *models.CourseResponse, version string) bool {
+	if err := validateCourseText(course, word.Term, word.Translation); err != nil {
+		log.Warn().Err(err).Int64("course_id", course.ID).Msg("Skipping generated word")
+		return false
 	}
+	word.CourseID = course.ID
+	word.PromptVersion = &version
+	return true
+}
 
-	jsonBody, err := json.Marshal(reqBody)
+// coursePromptVars returns the template variables describing a course
+func coursePromptVars(course *models.CourseResponse) prompts.Vars {
+	return prompts.Vars{
+		SourceLanguage: course.SourceLanguage.Name,
+		TargetLanguage: course.TargetLanguage.Name,
+	}
+}
+
+// wordsPromptVars maps a word generation request onto the template variables
+func wordsPromptVars(req *models.GenerateWordsRequest, course *models.CourseResponse) prompts.Vars {
+	vars := coursePromptVars(course)
+	vars.Category = req.Category
+	vars.Count = req.Count
+	if vars.Count <= 0 {
+		vars.Count = defaultWordCount
+	}
+	vars.Level = req.Level
+	vars.PartOfSpeech = req.PartOfSpeech
+	return vars
+}
+
+// GenerateSentences asks the LLM for example sentences using a word and stores
+// them against that word with source "llm"
+func (s *LLMService) GenerateSentences(ctx context.Context, wordID int64, count int, cache models.CacheMode) (*models.GenerateSentencesResponse, error) {
+	word, err := s.repo.GetWordByID(ctx, wordID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %v", err)
+		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", "https://api.groq.com/openai/v1/chat/completions", bytes.NewBuffer(jsonBody))
+	course, err := getCourse(ctx, s.repo, word.CourseID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+apiKey)
-	req.Header.Set("Content-Type", "application/json")
+	if count <= 0 {
+		count = defaultSentenceCount
+	}
+
+	vars := coursePromptVars(course)
+	vars.Count = count
+	vars.Word = word.Term
+	vars.Translation = word.Translation
+	prompt, _, err := s.prompts.Render(prompts.GenerateSentences, "", vars)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.chatCompletion(ctx, llmEndpointGenerateSentences, prompt, vars, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	var generated []models.SentenceResponse
+	if err := parseJSONArray(content, &generated); err != nil {
+		return nil, fmt.Errorf("failed to parse generated sentences: %v", err)
+	}
+
+	response := &models.GenerateSentencesResponse{
+		WordID:    wordID,
+		Sentences: []models.SentenceResponse{},
+	}
+	for _, sentence := range generated {
+		if err := validateCourseText(course, sentence.Text, sentence.Translation); err != nil {
+			log.Warn().Err(err).Int64("word_id", wordID).Msg("Skipping generated sentence")
+			continue
+		}
+		sentence.Source = models.SentenceSourceLLM
+
+		id, err := s.repo.CreateSentence(ctx, &sentence)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.AddSentenceToWord(ctx, id, wordID); err != nil {
+			return nil, err
+		}
+
+		sentence.ID = id
+		sentence.CreatedAt = time.Now()
+		response.Sentences = append(response.Sentences, sentence)
+	}
+
+	return response, nil
+}
+
+// chatCompletion sends a single user prompt to the Groq chat completions API
+// and returns the content of the first choice, serving it from the completion
+// cache when allowed
+func (s *LLMService) chatCompletion(ctx context.Context, endpoint, prompt string, vars prompts.Vars, cache models.CacheMode) (string, error) {
+	messages, err := s.promptMessages(prompt, vars)
+	if err != nil {
+		return "", err
+	}
+	return s.cachedCompletion(ctx, endpoint, messages, cache, nil)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// promptMessages wraps a single prompt in the vocabulary teacher conversation
+// for the course languages in vars
+func (s *LLMService) promptMessages(prompt string, vars prompts.Vars) ([]models.ChatMessage, error) {
+	system, _, err := s.prompts.Render(prompts.VocabularyTeacher, "", vars)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %v", err)
+		return nil, err
+	}
+
+	return []models.ChatMessage{
+		{
+			Role:    models.ChatRoleSystem,
+			Content: system,
+		},
+		{
+			Role:    models.ChatRoleUser,
+			Content: prompt,
+		},
+	}, nil
+}
+
+// Prompts returns the prompt templates the service renders
+func (s *LLMService) Prompts() *prompts.Store {
+	return s.prompts
+}
+
+// loadPrompts loads the prompt templates, applying the PROMPTS_DIR overrides
+// and PROMPT_VERSIONS pins. Invalid overrides are logged and the embedded
+// templates are used instead.
+func loadPrompts() *prompts.Store {
+	active, err := prompts.ParseActive(os.Getenv("PROMPT_VERSIONS"))
+	if err == nil {
+		var store *prompts.Store
+		store, err = prompts.Load(os.Getenv("PROMPTS_DIR"), active)
+		if err == nil {
+			return store
+		}
+	}
+	log.Error().Err(err).Msg("Failed to load prompt templates, using the embedded defaults")
+
+	store, err := prompts.Load("", nil)
+	if err != nil {
+		// The embedded templates are part of the build, so this is a programming error
+		panic(err)
+	}
+	return store
+}
+
+// Chat sends a conversation to the Groq chat completions API and returns the
+// content of the first choice
+func (s *LLMService) Chat(ctx context.Context, messages []models.ChatMessage) (string, error) {
+	return s.callProvider(ctx, llmEndpointChat, messages, nil)
+}
+
+// ChatStream sends a conversation to the Groq chat completions API with
+// streaming enabled, calling onDelta for every content token as it arrives.
+// Cancelling ctx aborts the upstream request. The full content is returned
+// once the stream completes.
+func (s *LLMService) ChatStream(ctx context.Context, messages []models.ChatMessage, onDelta func(string) error) (string, error) {
+	return s.callProvider(ctx, llmEndpointChatStream, messages, onDelta)
+}
+
+// chatCompletionResponse is the part of a Groq completion, or of a single
+// stream chunk, that the service reads
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *tokenUsage `json:"usage"`
+	// Groq reports the usage of a stream in its final chunk under x_groq
+	XGroq *struct {
+		Usage *tokenUsage `json:"usage"`
+	} `json:"x_groq"`
+}
+
+// tokenUsage is the usage block of a completion
+type tokenUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// usage returns the usage block wherever the provider put it
+func (r *chatCompletionResponse) usage() *tokenUsage {
+	if r.Usage != nil {
+		return r.Usage
+	}
+	if r.XGroq != nil {
+		return r.XGroq.Usage
+	}
+	return nil
+}
+
+// requestCompletion performs a non-streaming completion request
+func requestCompletion(req *http.Request) (string, *tokenUsage, error) {
+	resp, err := llmClient.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to make request: %v", err)
 	}
 	defer resp.Body.Close()
 
-	var result map[string]interface{}
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("unexpected status from LLM provider: %s", resp.Status)
+	}
+
+	var result chatCompletionResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %v", err)
+		return "", nil, fmt.Errorf("failed to decode response: %v", err)
 	}
 
-	// Extract the generated words from the response
-	choices, ok := result["choices"].([]interface{})
-	if !ok || len(choices) == 0 {
-		return nil, fmt.Errorf("invalid response format")
+	// Extract the generated content from the response
+	if len(result.Choices) == 0 {
+		return "", result.usage(), fmt.Errorf("invalid response format")
 	}
 
-	message, ok := choices[0].(map[string]interface{})["message"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid message format")
+	return result.Choices[0].Message.Content, result.usage(), nil
+}
+
+// requestStream performs a streaming completion request, relaying content
+// tokens to onDelta
+func requestStream(req *http.Request, onDelta func(string) error) (string, *tokenUsage, error) {
+	resp, err := llmClient.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to make request: %v", err)
 	}
+	defer resp.Body.Close()
 
-	content, ok := message["content"].(string)
-	if !ok {
-		return nil, fmt.Errorf("invalid content format")
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("unexpected status from LLM provider: %s", resp.Status)
 	}
 
-	// Clean and parse the JSON content
+	var content strings.Builder
+	var usage *tokenUsage
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk chatCompletionResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return "", usage, fmt.Errorf("failed to decode stream chunk: %v", err)
+		}
+		if u := chunk.usage(); u != nil {
+			usage = u
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		delta := chunk.Choices[0].Delta.Content
+		content.WriteString(delta)
+		if err := onDelta(delta); err != nil {
+			return "", usage, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", usage, fmt.Errorf("failed to read stream: %v", err)
+	}
+
+	return content.String(), usage, nil
+}
+
+// newChatRequest builds an authenticated Groq chat completions request
+func newChatRequest(ctx context.Context, messages []models.ChatMessage, stream bool) (*http.Request, error) {
+	// Groq API endpoint and key
+	apiKey := os.Getenv("GROQ_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("GROQ_API_KEY environment variable not set")
+	}
+
+	// Groq API request configuration
+	reqBody := map[string]interface{}{
+		"model":       llmModel,
+		"messages":    messages,
+		"temperature": llmTemperature,
+		"max_tokens":  llmMaxTokens,
+		"stream":      stream,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", groqChatURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
+
+// parseJSONObject decodes the first JSON object found in LLM output into v
+func parseJSONObject(content string, v interface{}) error {
+	content = strings.ReplaceAll(content, "```json", "")
+	content = strings.ReplaceAll(content, "```", "")
+
+	jsonStart := strings.Index(content, "{")
+	jsonEnd := strings.LastIndex(content, "}")
+	if jsonStart == -1 || jsonEnd <= jsonStart {
+		return fmt.Errorf("no JSON object found in response")
+	}
+
+	return json.Unmarshal([]byte(content[jsonStart:jsonEnd+1]), v)
+}
+
+// parseJSONArray cleans LLM output and decodes the JSON array it contains into v
+func parseJSONArray(content string, v interface{}) error {
 	content = cleanJSONString(content)
 
-	var words []models.WordResponse
-	if err := json.Unmarshal([]byte(content), &words); err != nil {
+	if err := json.Unmarshal([]byte(content), v); err != nil {
 		// Try to extract JSON array if content contains additional text
-		if jsonStart := strings.Index(content, "["); jsonStart != -1 {
-			if jsonEnd := strings.LastIndex(content, "]"); jsonEnd != -1 && jsonEnd > jsonStart {
-				content = content[jsonStart : jsonEnd+1]
-				if err := json.Unmarshal([]byte(content), &words); err != nil {
-					return nil, fmt.Errorf("failed to parse extracted JSON array: %v", err)
-				}
-			}
-		}
-		if len(words) == 0 {
-			return nil, fmt.Errorf("failed to parse generated words: %v", err)
+		jsonStart := strings.Index(content, "[")
+		jsonEnd := strings.LastIndex(content, "]")
+		if jsonStart == -1 || jsonEnd <= jsonStart {
+			return err
 		}
+		return json.Unmarshal([]byte(content[jsonStart:jsonEnd+1]), v)
 	}
 
-	return &models.GenerateWordsResponse{Words: words}, nil
+	return nil
 }
 
 // cleanJSONString removes common issues in LLM-generated JSON
@@ -159,8 +519,8 @@ func cleanJSONString(s string) string {
 	return strings.TrimSpace(s)
 }
 
-func (s *LLMService) GetGroupByID(id int64) (*models.GroupResponse, error) {
-	group, err := s.repo.GetGroupByID(id)
+func (s *LLMService) GetGroupByID(ctx context.Context, id int64) (*models.GroupResponse, error) {
+	group, err := s.repo.GetGroupByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -171,14 +531,26 @@ func (s *LLMService) GetGroupByID(id int64) (*models.GroupResponse, error) {
 	}, nil
 }
 
-func (s *LLMService) CreateWord(word *models.WordResponse) (int64, error) {
-	return s.repo.CreateWord(word)
+// FindOrCreateWord stores a word in its course, the default course when it
+// has none, after checking it is written in the course's languages. A word
+// the course already has with the same translation is reused.
+func (s *LLMService) FindOrCreateWord(ctx context.Context, word *models.WordResponse) (int64, error) {
+	course, err := getCourse(ctx, s.repo, word.CourseID)
+	if err != nil {
+		return 0, err
+	}
+	if err := validateCourseText(course, word.Term, word.Translation); err != nil {
+		return 0, models.ValidationError("invalid_word", "invalid word: "+err.Error())
+	}
+
+	word.CourseID = course.ID
+	return s.repo.FindOrCreateWord(ctx, word)
 }
 
-func (s *LLMService) AddWordToGroup(wordID, groupID int64) error {
-	return s.repo.AddWordToGroup(wordID, groupID)
+func (s *LLMService) AddWordToGroup(ctx context.Context, wordID, groupID int64) error {
+	return s.repo.AddWordToGroup(ctx, wordID, groupID)
 }
 
-func (s *LLMService) UpdateGroupWordsCount(groupID int64) error {
-	return s.repo.UpdateGroupWordsCount(groupID)
+func (s *LLMService) UpdateGroupWordsCount(ctx context.Context, groupID int64) error {
+	return s.repo.UpdateGroupWordsCount(ctx, groupID)
 }
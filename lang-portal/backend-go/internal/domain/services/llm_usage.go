@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// Endpoint labels recorded in the usage ledger for each kind of LLM call
+const (
+	llmEndpointGenerateWords     = "generate_words"
+	llmEndpointStreamWords       = "generate_words_stream"
+	llmEndpointGenerateSentences = "generate_sentences"
+	llmEndpointChat              = "chat"
+	llmEndpointChatStream        = "chat_stream"
+)
+
+// defaultLLMPrices are the published Groq prices in USD per million tokens.
+// LLM_PRICES can override or extend them with a JSON object keyed by model.
+var defaultLLMPrices = map[string]models.LLMPrice{
+	"mixtral-8x7b-32768":      {PromptPerMillion: 0.24, CompletionPerMillion: 0.24},
+	"llama-3.1-8b-instant":    {PromptPerMillion: 0.05, CompletionPerMillion: 0.08},
+	"llama-3.3-70b-versatile": {PromptPerMillion: 0.59, CompletionPerMillion: 0.79},
+}
+
+// llmBudget holds the monthly limits; zero means unlimited
+type llmBudget struct {
+	costUSD float64
+	tokens  int64
+}
+
+// llmPrices builds the price table from the defaults and LLM_PRICES
+func llmPrices() map[string]models.LLMPrice {
+	prices := make(map[string]models.LLMPrice, len(defaultLLMPrices))
+	for model, price := range defaultLLMPrices {
+		prices[model] = price
+	}
+
+	value := os.Getenv("LLM_PRICES")
+	if value == "" {
+		return prices
+	}
+
+	var overrides map[string]models.LLMPrice
+	if err := json.Unmarshal([]byte(value), &overrides); err != nil {
+		log.Warn().Err(err).Msg("Invalid LLM_PRICES, using default prices")
+		return prices
+	}
+	for model, price := range overrides {
+		prices[model] = price
+	}
+	return prices
+}
+
+// llmMonthlyBudget reads LLM_MONTHLY_BUDGET_USD and LLM_MONTHLY_TOKEN_BUDGET
+func llmMonthlyBudget() llmBudget {
+	var budget llmBudget
+	if value := os.Getenv("LLM_MONTHLY_BUDGET_USD"); value != "" {
+		cost, err := strconv.ParseFloat(value, 64)
+		if err != nil || cost < 0 {
+			log.Warn().Str("LLM_MONTHLY_BUDGET_USD", value).Msg("Invalid LLM cost budget, not enforcing it")
+		} else {
+			budget.costUSD = cost
+		}
+	}
+	if value := os.Getenv("LLM_MONTHLY_TOKEN_BUDGET"); value != "" {
+		tokens, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || tokens < 0 {
+			log.Warn().Str("LLM_MONTHLY_TOKEN_BUDGET", value).Msg("Invalid LLM token budget, not enforcing it")
+		} else {
+			budget.tokens = tokens
+		}
+	}
+	return budget
+}
+
+// estimateCost prices a call from the price table; unknown models cost nothing
+func (s *LLMService) estimateCost(model string, usage *tokenUsage) float64 {
+	price, ok := s.prices[model]
+	if !ok || usage == nil {
+		return 0
+	}
+	return float64(usage.PromptTokens)*price.PromptPerMillion/1e6 +
+		float64(usage.CompletionTokens)*price.CompletionPerMillion/1e6
+}
+
+// checkBudget rejects new calls once a monthly budget has been used up
+func (s *LLMService) checkBudget(ctx context.Context) error {
+	if s.budget.costUSD == 0 && s.budget.tokens == 0 {
+		return nil
+	}
+
+	cost, tokens, err := s.repo.GetLLMMonthlyUsage(ctx)
+	if err != nil {
+		return err
+	}
+	if s.budget.costUSD > 0 && cost >= s.budget.costUSD {
+		return models.NewError(models.ErrLimitExceeded, "llm_budget_exceeded", fmt.Sprintf("llm budget exceeded: spent $%.4f of the $%.2f monthly budget", cost, s.budget.costUSD))
+	}
+	if s.budget.tokens > 0 && tokens >= s.budget.tokens {
+		return models.NewError(models.ErrLimitExceeded, "llm_budget_exceeded", fmt.Sprintf("llm budget exceeded: used %d of the %d monthly tokens", tokens, s.budget.tokens))
+	}
+	return nil
+}
+
+// callProvider sends a completion request to the provider, streaming it when
+// onDelta is set, and records the call in the usage ledger
+func (s *LLMService) callProvider(ctx context.Context, endpoint string, messages []models.ChatMessage, onDelta func(string) error) (string, error) {
+	if err := s.checkBudget(ctx); err != nil {
+		return "", err
+	}
+
+	req, err := newChatRequest(ctx, messages, onDelta != nil)
+	if err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	var content string
+	var usage *tokenUsage
+	if onDelta != nil {
+		content, usage, err = requestStream(req, onDelta)
+	} else {
+		content, usage, err = requestCompletion(req)
+	}
+
+	s.recordUsage(ctx, endpoint, usage, time.Since(start), err)
+	return content, err
+}
+
+// recordUsage writes a call to the usage ledger. Ledger failures are logged
+// rather than failing the call that has already been paid for, and calls are
+// recorded even when ctx has been cancelled.
+func (s *LLMService) recordUsage(ctx context.Context, endpoint string, usage *tokenUsage, latency time.Duration, callErr error) {
+	record := &models.LLMUsageRecord{
+		Provider:  llmProvider,
+		Model:     llmModel,
+		Endpoint:  endpoint,
+		LatencyMs: latency.Milliseconds(),
+		Status:    models.LLMCallStatusSuccess,
+		CostUSD:   s.estimateCost(llmModel, usage),
+	}
+	if usage != nil {
+		record.PromptTokens = usage.PromptTokens
+		record.CompletionTokens = usage.CompletionTokens
+		record.TotalTokens = usage.TotalTokens
+	}
+	if callErr != nil {
+		message := callErr.Error()
+		record.Error = &message
+		record.Status = models.LLMCallStatusError
+		if ctx.Err() != nil {
+			record.Status = models.LLMCallStatusCancelled
+		}
+	}
+
+	if err := s.repo.RecordLLMUsage(context.WithoutCancel(ctx), record); err != nil {
+		log.Error().Err(err).Msg("Failed to record LLM usage")
+	}
+}
+
+// GetUsageSummary aggregates the usage ledger over the last days, grouped by
+// day or endpoint
+func (s *LLMService) GetUsageSummary(ctx context.Context, groupBy string, days int) (*models.LLMUsageSummaryResponse, error) {
+	items, err := s.repo.GetLLMUsageSummary(ctx, groupBy, days)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := models.LLMUsageSummaryItem{Key: "total"}
+	var latency float64
+	for _, item := range items {
+		totals.Calls += item.Calls
+		totals.Errors += item.Errors
+		totals.PromptTokens += item.PromptTokens
+		totals.CompletionTokens += item.CompletionTokens
+		totals.TotalTokens += item.TotalTokens
+		totals.CostUSD += item.CostUSD
+		latency += item.AvgLatencyMs * float64(item.Calls)
+	}
+	if totals.Calls > 0 {
+		totals.AvgLatencyMs = latency / float64(totals.Calls)
+	}
+
+	return &models.LLMUsageSummaryResponse{
+		GroupBy: groupBy,
+		Days:    days,
+		Items:   items,
+		Totals:  totals,
+	}, nil
+}
+
+// GetBudget reports this month's spending against the configured budgets
+func (s *LLMService) GetBudget(ctx context.Context) (*models.LLMBudgetResponse, error) {
+	cost, tokens, err := s.repo.GetLLMMonthlyUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.LLMBudgetResponse{
+		Month:      time.Now().UTC().Format("2006-01"),
+		SpentUSD:   cost,
+		TokensUsed: tokens,
+	}
+	if s.budget.costUSD > 0 {
+		budget := s.budget.costUSD
+		response.BudgetUSD = &budget
+		response.Exceeded = cost >= budget
+	}
+	if s.budget.tokens > 0 {
+		budget := s.budget.tokens
+		response.TokenBudget = &budget
+		response.Exceeded = response.Exceeded || tokens >= budget
+	}
+
+	return response, nil
+}
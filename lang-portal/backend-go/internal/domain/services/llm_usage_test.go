@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeGroq points the service at a test server for the duration of a test
+func fakeGroq(t *testing.T, handler http.HandlerFunc) *int {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	original := groqChatURL
+	groqChatURL = server.URL
+	t.Cleanup(func() { groqChatURL = original })
+	t.Setenv("GROQ_API_KEY", "test-key")
+	t.Setenv("LLM_MONTHLY_BUDGET_USD", "")
+	t.Setenv("LLM_MONTHLY_TOKEN_BUDGET", "")
+	return &calls
+}
+
+func TestLLMService_RecordsUsage(t *testing.T) {
+	t.Run("completion", func(t *testing.T) {
+		fakeGroq(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"choices": [{"message": {"content": "Ciao"}}], "usage": {"prompt_tokens": 1000000, "completion_tokens": 500000, "total_tokens": 1500000}}`)
+		})
+		mockRepo := new(mocks.MockRepository)
+		service := NewLLMService(mockRepo)
+
+		mockRepo.On("RecordLLMUsage", mock.MatchedBy(func(record *models.LLMUsageRecord) bool {
+			return record.Endpoint == "chat" &&
+				record.Status == models.LLMCallStatusSuccess &&
+				record.PromptTokens == 1000000 &&
+				record.TotalTokens == 1500000 &&
+				record.CostUSD == 0.36
+		})).Return(nil)
+
+		content, err := service.Chat(context.Background(), []models.ChatMessage{{Role: "user", Content: "Hi"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Ciao", content)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		fakeGroq(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "data: {\"choices\": [{\"delta\": {\"content\": \"Ci\"}}]}\n\n")
+			fmt.Fprint(w, "data: {\"choices\": [{\"delta\": {\"content\": \"ao\"}}], \"x_groq\": {\"usage\": {\"prompt_tokens\": 10, \"completion_tokens\": 2, \"total_tokens\": 12}}}\n\n")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		})
+		mockRepo := new(mocks.MockRepository)
+		service := NewLLMService(mockRepo)
+
+		mockRepo.On("RecordLLMUsage", mock.MatchedBy(func(record *models.LLMUsageRecord) bool {
+			return record.Endpoint == "chat_stream" && record.TotalTokens == 12
+		})).Return(nil)
+
+		var deltas []string
+		content, err := service.ChatStream(context.Background(), []models.ChatMessage{{Role: "user", Content: "Hi"}}, func(delta string) error {
+			deltas = append(deltas, delta)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Ciao", content)
+		assert.Equal(t, []string{"Ci", "ao"}, deltas)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		fakeGroq(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+		mockRepo := new(mocks.MockRepository)
+		service := NewLLMService(mockRepo)
+
+		mockRepo.On("RecordLLMUsage", mock.MatchedBy(func(record *models.LLMUsageRecord) bool {
+			return record.Status == models.LLMCallStatusError && record.Error != nil
+		})).Return(nil)
+
+		_, err := service.Chat(context.Background(), []models.ChatMessage{{Role: "user", Content: "Hi"}})
+
+		assert.Error(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestLLMService_Budget(t *testing.T) {
+	t.Run("rejects calls once exceeded", func(t *testing.T) {
+		calls := fakeGroq(t, func(w http.ResponseWriter, r *http.Request) {})
+		t.Setenv("LLM_MONTHLY_BUDGET_USD", "5")
+		mockRepo := new(mocks.MockRepository)
+		service := NewLLMService(mockRepo)
+
+		mockRepo.On("GetLLMMonthlyUsage").Return(5.01, int64(100), nil)
+
+		_, err := service.Chat(context.Background(), []models.ChatMessage{{Role: "user", Content: "Hi"}})
+
+		assert.ErrorContains(t, err, "llm budget exceeded")
+		assert.Equal(t, 0, *calls)
+		mockRepo.AssertNotCalled(t, "RecordLLMUsage", mock.Anything)
+	})
+
+	t.Run("reports status", func(t *testing.T) {
+		fakeGroq(t, func(w http.ResponseWriter, r *http.Request) {})
+		t.Setenv("LLM_MONTHLY_TOKEN_BUDGET", "1000")
+		mockRepo := new(mocks.MockRepository)
+		service := NewLLMService(mockRepo)
+
+		mockRepo.On("GetLLMMonthlyUsage").Return(0.5, int64(1200), nil)
+
+		budget, err := service.GetBudget(context.Background())
+
+		assert.NoError(t, err)
+		assert.Nil(t, budget.BudgetUSD)
+		assert.Equal(t, int64(1000), *budget.TokenBudget)
+		assert.True(t, budget.Exceeded)
+	})
+}
+
+func TestLLMService_GetUsageSummary(t *testing.T) {
+	mockRepo := new(mocks.MockRepository)
+	service := NewLLMService(mockRepo)
+
+	mockRepo.On("GetLLMUsageSummary", "endpoint", 7).Return([]models.LLMUsageSummaryItem{
+		{Key: "generate_words", Calls: 3, TotalTokens: 300, CostUSD: 0.25, AvgLatencyMs: 1000},
+		{Key: "chat", Calls: 1, Errors: 1, TotalTokens: 100, CostUSD: 0.25, AvgLatencyMs: 2000},
+	}, nil)
+
+	summary, err := service.GetUsageSummary(context.Background(), "endpoint", 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, summary.Totals.Calls)
+	assert.Equal(t, 1, summary.Totals.Errors)
+	assert.Equal(t, int64(400), summary.Totals.TotalTokens)
+	assert.Equal(t, 0.5, summary.Totals.CostUSD)
+	assert.Equal(t, 1250.0, summary.Totals.AvgLatencyMs)
+}
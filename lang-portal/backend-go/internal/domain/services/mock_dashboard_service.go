@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 	"github.com/stretchr/testify/mock"
 )
@@ -9,7 +11,7 @@ type MockDashboardService struct {
 	mock.Mock
 }
 
-func (m *MockDashboardService) GetLastStudySession() (*models.DashboardLastStudySession, error) {
+func (m *MockDashboardService) GetLastStudySession(_ context.Context) (*models.DashboardLastStudySession, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -17,7 +19,7 @@ func (m *MockDashboardService) GetLastStudySession() (*models.DashboardLastStudy
 	return args.Get(0).(*models.DashboardLastStudySession), args.Error(1)
 }
 
-func (m *MockDashboardService) GetStudyProgress() (*models.DashboardStudyProgress, error) {
+func (m *MockDashboardService) GetStudyProgress(_ context.Context) (*models.DashboardStudyProgress, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -25,7 +27,7 @@ func (m *MockDashboardService) GetStudyProgress() (*models.DashboardStudyProgres
 	return args.Get(0).(*models.DashboardStudyProgress), args.Error(1)
 }
 
-func (m *MockDashboardService) GetQuickStats() (*models.DashboardQuickStats, error) {
+func (m *MockDashboardService) GetQuickStats(_ context.Context) (*models.DashboardQuickStats, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// maxPackAudioSize caps each audio file of a content pack
+const maxPackAudioSize = 5 << 20
+
+// PackInstaller applies content packs to the database
+type PackInstaller interface {
+	InstallPack(ctx context.Context, fsys fs.FS) (*models.InstalledPack, error)
+}
+
+// WordAudioStore keeps the audio content packs supply for their words
+type WordAudioStore interface {
+	StoreWordAudio(wordID int64, contentType string, audio []byte) error
+	DeleteWordAudio(wordIDs []int64) error
+}
+
+type PackServiceInterface interface {
+	InstallPack(ctx context.Context, r io.ReaderAt, size int64) (*models.InstallPackResponse, bool, error)
+	GetPacks(ctx context.Context) (*models.ContentPackListResponse, error)
+	UninstallPack(ctx context.Context, name string) (*models.UninstallPackResponse, error)
+}
+
+type PackService struct {
+	repo      repository.Repository
+	installer PackInstaller
+	audio     WordAudioStore
+}
+
+func NewPackService(repo repository.Repository, installer PackInstaller, audio WordAudioStore) *PackService {
+	return &PackService{repo: repo, installer: installer, audio: audio}
+}
+
+// packAudio is an audio file of a content pack, audio/<n><ext>, where n
+// numbers the word it pronounces from 1 in the order words.json lists them
+type packAudio struct {
+	file        *zip.File
+	word        int
+	contentType string
+}
+
+// InstallPack installs the content pack zip read from r, then stores the
+// audio it supplies for its words. Audio that cannot be stored is logged and
+// counted; the words are installed regardless, and uploading the pack again
+// stores its audio again. The boolean reports whether the pack was installed
+// or updated, rather than already installed.
+func (s *PackService) InstallPack(ctx context.Context, r io.ReaderAt, size int64) (*models.InstallPackResponse, bool, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, false, models.WrapError(models.ErrValidation, "invalid_pack", "the pack is not a zip file", err)
+	}
+
+	audio, err := packAudioFiles(archive)
+	if err != nil {
+		return nil, false, err
+	}
+
+	installed, err := s.installer.InstallPack(ctx, archive)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The installer has checked that the audio belongs to words it lists
+	result := &models.InstallPackResponse{Pack: installed.Pack}
+	for _, a := range audio {
+		wordID := installed.WordIDs[a.word-1]
+		if wordID == 0 {
+			continue
+		}
+		if err := s.storeAudio(wordID, a); err != nil {
+			log.Warn().Err(err).Str("pack", installed.Pack.Name).Int64("word_id", wordID).Msg("Failed to store pack audio")
+			result.AudioFailed++
+			continue
+		}
+		result.AudioStored++
+	}
+	return result, installed.Updated, nil
+}
+
+// packAudioFiles checks the names and sizes of the audio files in a pack
+// before it is installed
+func packAudioFiles(archive *zip.Reader) ([]packAudio, error) {
+	contentTypes := make(map[string]string, len(audioExtensions))
+	for contentType, ext := range audioExtensions {
+		contentTypes[ext] = contentType
+	}
+
+	var audio []packAudio
+	for _, file := range archive.File {
+		if path.Dir(file.Name) != "audio" || file.FileInfo().IsDir() {
+			continue
+		}
+		ext := path.Ext(file.Name)
+		word, err := strconv.Atoi(strings.TrimSuffix(path.Base(file.Name), ext))
+		contentType, ok := contentTypes[strings.ToLower(ext)]
+		if err != nil || word < 1 || !ok {
+			return nil, models.ValidationError("invalid_pack", fmt.Sprintf("audio file %s is not named after a word number with a .wav, .mp3, .ogg or .webm extension", file.Name))
+		}
+		if file.UncompressedSize64 > maxPackAudioSize {
+			return nil, models.ValidationError("invalid_pack", fmt.Sprintf("audio file %s is larger than %d MB", file.Name, maxPackAudioSize>>20))
+		}
+		audio = append(audio, packAudio{file: file, word: word, contentType: contentType})
+	}
+	return audio, nil
+}
+
+func (s *PackService) storeAudio(wordID int64, a packAudio) error {
+	f, err := a.file.Open()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// The size in the zip header is not to be trusted
+	data, err := io.ReadAll(io.LimitReader(f, maxPackAudioSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxPackAudioSize {
+		return fmt.Errorf("audio file %s is larger than %d MB", a.file.Name, maxPackAudioSize>>20)
+	}
+	return s.audio.StoreWordAudio(wordID, a.contentType, data)
+}
+
+func (s *PackService) GetPacks(ctx context.Context) (*models.ContentPackListResponse, error) {
+	packs, err := s.repo.GetContentPacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ContentPackListResponse{Items: packs}, nil
+}
+
+// UninstallPack removes a content pack and the words it added that the
+// learner has not reviewed, with their audio
+func (s *PackService) UninstallPack(ctx context.Context, name string) (*models.UninstallPackResponse, error) {
+	result, err := s.repo.DeleteContentPack(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.audio.DeleteWordAudio(result.RemovedWordIDs); err != nil {
+		log.Warn().Err(err).Str("pack", name).Msg("Failed to delete audio of removed words")
+	}
+	return result, nil
+}
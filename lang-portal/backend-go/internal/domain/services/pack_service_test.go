@@ -0,0 +1,139 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+)
+
+// fakePackInstaller installs every pack as words 10, 11 and 12, recording
+// the manifest it read
+type fakePackInstaller struct {
+	manifest string
+	updated  bool
+}
+
+func (f *fakePackInstaller) InstallPack(ctx context.Context, fsys fs.FS) (*models.InstalledPack, error) {
+	data, err := fs.ReadFile(fsys, "pack.json")
+	if err != nil {
+		return nil, models.ValidationError("invalid_pack", err.Error())
+	}
+	f.manifest = string(data)
+	return &models.InstalledPack{Pack: models.ContentPackResponse{Name: "a1-travel"}, WordIDs: []int64{10, 11, 12}, Updated: f.updated}, nil
+}
+
+// fakeWordAudioStore keeps audio in memory, failing for words it is told to
+type fakeWordAudioStore struct {
+	audio   map[int64]string
+	deleted []int64
+	failFor int64
+}
+
+func (f *fakeWordAudioStore) StoreWordAudio(wordID int64, contentType string, audio []byte) error {
+	if wordID == f.failFor {
+		return errors.New("disk full")
+	}
+	f.audio[wordID] = contentType + ":" + string(audio)
+	return nil
+}
+
+func (f *fakeWordAudioStore) DeleteWordAudio(wordIDs []int64) error {
+	f.deleted = append(f.deleted, wordIDs...)
+	return nil
+}
+
+func packZip(t *testing.T, files map[string]string) *bytes.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestPackService_InstallPack(t *testing.T) {
+	ctx := context.Background()
+	manifest := `{"name": "a1-travel", "version": 1}`
+
+	t.Run("stores the audio of installed words", func(t *testing.T) {
+		installer := &fakePackInstaller{updated: true}
+		store := &fakeWordAudioStore{audio: map[int64]string{}, failFor: 12}
+		service := NewPackService(new(mocks.MockRepository), installer, store)
+
+		zip := packZip(t, map[string]string{
+			"pack.json":   manifest,
+			"audio/1.mp3": "treno",
+			"audio/3.wav": "ciao",
+		})
+		result, installed, err := service.InstallPack(ctx, zip, zip.Size())
+		require.NoError(t, err)
+		assert.True(t, installed)
+		assert.Equal(t, manifest, installer.manifest)
+		assert.Equal(t, 1, result.AudioStored)
+		assert.Equal(t, 1, result.AudioFailed)
+		assert.Equal(t, map[int64]string{10: "audio/mpeg:treno"}, store.audio)
+	})
+
+	t.Run("stores the audio again when already installed", func(t *testing.T) {
+		store := &fakeWordAudioStore{audio: map[int64]string{}}
+		service := NewPackService(new(mocks.MockRepository), &fakePackInstaller{}, store)
+
+		zip := packZip(t, map[string]string{"pack.json": manifest, "audio/1.mp3": "treno"})
+		result, installed, err := service.InstallPack(ctx, zip, zip.Size())
+		require.NoError(t, err)
+		assert.False(t, installed)
+		assert.Equal(t, 1, result.AudioStored)
+		assert.Equal(t, map[int64]string{10: "audio/mpeg:treno"}, store.audio)
+	})
+
+	invalid := map[string]map[string]string{
+		"audio not named after a word": {"pack.json": manifest, "audio/treno.mp3": "treno"},
+		"unsupported audio type":       {"pack.json": manifest, "audio/1.flac": "treno"},
+	}
+	for name, files := range invalid {
+		t.Run(name, func(t *testing.T) {
+			store := &fakeWordAudioStore{audio: map[int64]string{}}
+			service := NewPackService(new(mocks.MockRepository), &fakePackInstaller{updated: true}, store)
+
+			zip := packZip(t, files)
+			_, _, err := service.InstallPack(ctx, zip, zip.Size())
+			assert.True(t, errors.Is(err, models.ErrValidation), err)
+			assert.Empty(t, store.audio)
+		})
+	}
+
+	t.Run("not a zip", func(t *testing.T) {
+		service := NewPackService(new(mocks.MockRepository), &fakePackInstaller{}, &fakeWordAudioStore{})
+		data := bytes.NewReader([]byte("pack.json"))
+		_, _, err := service.InstallPack(ctx, data, data.Size())
+		assert.True(t, errors.Is(err, models.ErrValidation))
+	})
+}
+
+func TestPackService_UninstallPack(t *testing.T) {
+	mockRepo := new(mocks.MockRepository)
+	store := &fakeWordAudioStore{}
+	service := NewPackService(mockRepo, &fakePackInstaller{}, store)
+
+	mockRepo.On("DeleteContentPack", "a1-travel").Return(&models.UninstallPackResponse{RemovedWords: 2, RemovedWordIDs: []int64{10, 11}}, nil)
+
+	result, err := service.UninstallPack(context.Background(), "a1-travel")
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.RemovedWords)
+	assert.Equal(t, []int64{10, 11}, store.deleted)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// learnerDataNotStored lists what a learner might expect in their export but
+// the portal does not keep: words are studied by group with no review
+// schedule, and there are no notes, settings, passwords or logins
+var learnerDataNotStored = []string{"spaced repetition schedules", "notes", "settings", "passwords or logins"}
+
+type PrivacyServiceInterface interface {
+	Export(ctx context.Context, w io.Writer) error
+	Erase(ctx context.Context) (*models.EraseLearnerDataResponse, error)
+}
+
+// PrivacyService exports and erases the profile and study history of the
+// learner. The portal has a single learner and no logins, so that is
+// everything it stores about a person; shared vocabulary and content are not
+// part of it.
+type PrivacyService struct {
+	repo repository.Repository
+}
+
+func NewPrivacyService(repo repository.Repository) *PrivacyService {
+	return &PrivacyService{repo: repo}
+}
+
+// learnerExportFile is a file of the export zip with the records it holds
+type learnerExportFile struct {
+	models.LearnerExportFile
+	content interface{}
+}
+
+// Export writes the learner's data to w as a zip of JSON files, described by
+// manifest.json. The data is read first, so nothing is written when it
+// cannot be.
+func (s *PrivacyService) Export(ctx context.Context, w io.Writer) error {
+	data, err := s.repo.GetLearnerData(ctx)
+	if err != nil {
+		return err
+	}
+	progress := wordProgress(data.Reviews)
+	learnerRecords := 0
+	if data.Learner != nil {
+		learnerRecords = 1
+	}
+
+	files := []learnerExportFile{
+		{models.LearnerExportFile{Name: "study_sessions.json", Description: "Study sessions, with the group and activity studied", Records: len(data.StudySessions)}, data.StudySessions},
+		{models.LearnerExportFile{Name: "reviews.json", Description: "Every answer given to a word in a study session", Records: len(data.Reviews)}, data.Reviews},
+		{models.LearnerExportFile{Name: "word_progress.json", Description: "Right and wrong answers per word, summed up from the reviews", Records: len(progress)}, progress},
+		{models.LearnerExportFile{Name: "listening_answers.json", Description: "Answers to the questions of listening exercises", Records: len(data.ListeningAnswers)}, data.ListeningAnswers},
+		{models.LearnerExportFile{Name: "tutor_conversations.json", Description: "Conversations with the tutor, with their messages and vocabulary", Records: len(data.TutorConversations)}, data.TutorConversations},
+		{models.LearnerExportFile{Name: "learner.json", Description: "The learner's profile, null if none was created", Records: learnerRecords}, data.Learner},
+	}
+	manifest := models.LearnerExportManifest{
+		Format:     models.LearnerExportFormat,
+		ExportedAt: time.Now().UTC(),
+		NotStored:  learnerDataNotStored,
+	}
+	for _, file := range files {
+		manifest.Files = append(manifest.Files, file.LearnerExportFile)
+	}
+
+	archive := zip.NewWriter(w)
+	if err := writeJSONFile(archive, "manifest.json", manifest); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := writeJSONFile(archive, file.Name, file.content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// Erase deletes the learner's profile and study history in one transaction,
+// leaving the vocabulary, groups and content in place
+func (s *PrivacyService) Erase(ctx context.Context) (*models.EraseLearnerDataResponse, error) {
+	return s.repo.EraseLearnerData(ctx)
+}
+
+// wordProgress sums up reviews per word, ordered by word
+func wordProgress(reviews []models.ExportedReview) []models.ExportedWordProgress {
+	byWord := map[int64]*models.ExportedWordProgress{}
+	for _, review := range reviews {
+		progress, ok := byWord[review.WordID]
+		if !ok {
+			progress = &models.ExportedWordProgress{WordID: review.WordID, Term: review.Term, Translation: review.Translation}
+			byWord[review.WordID] = progress
+		}
+		if review.Correct {
+			progress.CorrectCount++
+		} else {
+			progress.WrongCount++
+		}
+		if review.CreatedAt.After(progress.LastReviewedAt) {
+			progress.LastReviewedAt = review.CreatedAt
+		}
+	}
+
+	result := make([]models.ExportedWordProgress, 0, len(byWord))
+	for _, progress := range byWord {
+		result = append(result, *progress)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].WordID < result[j].WordID })
+	return result
+}
+
+func writeJSONFile(archive *zip.Writer, name string, content interface{}) error {
+	f, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(content)
+}
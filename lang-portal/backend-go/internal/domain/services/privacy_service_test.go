@@ -0,0 +1,97 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+)
+
+func TestPrivacyService_Export(t *testing.T) {
+	at := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("zip of JSON files", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetLearnerData").Return(&models.LearnerData{
+			Learner:       &models.Learner{Name: "Giulia", CreatedAt: at},
+			StudySessions: []models.ExportedStudySession{{ID: 41, GroupID: 3, GroupName: "Family", CreatedAt: at}},
+			Reviews: []models.ExportedReview{
+				{ID: 1, StudySessionID: 41, WordID: 17, Term: "madre", Correct: true, CreatedAt: at},
+				{ID: 2, StudySessionID: 41, WordID: 9, Term: "padre", CreatedAt: at},
+				{ID: 3, StudySessionID: 41, WordID: 17, Term: "madre", CreatedAt: at.Add(time.Minute)},
+			},
+			ListeningAnswers:   []models.ExportedListeningAnswer{},
+			TutorConversations: []models.ExportedTutorConversation{},
+		}, nil)
+
+		var buf bytes.Buffer
+		require.NoError(t, NewPrivacyService(mockRepo).Export(context.Background(), &buf))
+
+		archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		files := map[string][]byte{}
+		for _, f := range archive.File {
+			r, err := f.Open()
+			require.NoError(t, err)
+			files[f.Name], err = io.ReadAll(r)
+			require.NoError(t, err)
+		}
+
+		var manifest models.LearnerExportManifest
+		require.NoError(t, json.Unmarshal(files["manifest.json"], &manifest))
+		assert.Equal(t, models.LearnerExportFormat, manifest.Format)
+		assert.Contains(t, manifest.NotStored, "notes")
+		require.Len(t, manifest.Files, 6)
+		for _, file := range manifest.Files {
+			assert.Contains(t, files, file.Name)
+		}
+		assert.Equal(t, "reviews.json", manifest.Files[1].Name)
+		assert.Equal(t, 3, manifest.Files[1].Records)
+		assert.Equal(t, "learner.json", manifest.Files[5].Name)
+		assert.Equal(t, 1, manifest.Files[5].Records)
+
+		var learner models.Learner
+		require.NoError(t, json.Unmarshal(files["learner.json"], &learner))
+		assert.Equal(t, "Giulia", learner.Name)
+
+		var progress []models.ExportedWordProgress
+		require.NoError(t, json.Unmarshal(files["word_progress.json"], &progress))
+		require.Len(t, progress, 2)
+		assert.Equal(t, int64(9), progress[0].WordID)
+		assert.Equal(t, models.ExportedWordProgress{WordID: 17, Term: "madre", CorrectCount: 1, WrongCount: 1, LastReviewedAt: at.Add(time.Minute)}, progress[1])
+
+		assert.JSONEq(t, "[]", string(files["listening_answers.json"]))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error writes nothing", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetLearnerData").Return(nil, errors.New("database is locked"))
+
+		var buf bytes.Buffer
+		err := NewPrivacyService(mockRepo).Export(context.Background(), &buf)
+		assert.Error(t, err)
+		assert.Zero(t, buf.Len())
+	})
+}
+
+func TestPrivacyService_Erase(t *testing.T) {
+	mockRepo := new(mocks.MockRepository)
+	erased := &models.EraseLearnerDataResponse{StudySessions: 12, Reviews: 120}
+	mockRepo.On("EraseLearnerData").Return(erased, nil)
+
+	response, err := NewPrivacyService(mockRepo).Erase(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, erased, response)
+	mockRepo.AssertExpectations(t)
+}
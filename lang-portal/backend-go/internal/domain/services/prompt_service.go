@@ -0,0 +1,59 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/prompts"
+)
+
+type PromptServiceInterface interface {
+	ListPrompts() *models.PromptTemplateListResponse
+	PreviewPrompt(req *models.PreviewPromptRequest) (*models.PreviewPromptResponse, error)
+}
+
+type PromptService struct {
+	store *prompts.Store
+}
+
+func NewPromptService(store *prompts.Store) *PromptService {
+	return &PromptService{store: store}
+}
+
+// ListPrompts returns every loaded template version and which one is active
+func (s *PromptService) ListPrompts() *models.PromptTemplateListResponse {
+	response := &models.PromptTemplateListResponse{Items: []models.PromptTemplateResponse{}}
+	for _, info := range s.store.List() {
+		response.Items = append(response.Items, models.PromptTemplateResponse{
+			Name:    info.Name,
+			Version: info.Version,
+			Source:  info.Source,
+			Active:  info.Active,
+			Text:    info.Text,
+		})
+	}
+	return response
+}
+
+// PreviewPrompt renders a template version with the given variables
+func (s *PromptService) PreviewPrompt(req *models.PreviewPromptRequest) (*models.PreviewPromptResponse, error) {
+	text, version, err := s.store.Render(req.Name, req.Version, prompts.Vars{
+		SourceLanguage: req.SourceLanguage,
+		TargetLanguage: req.TargetLanguage,
+		Category:       req.Category,
+		Count:          req.Count,
+		Level:          req.Level,
+		PartOfSpeech:   req.PartOfSpeech,
+		Word:           req.Word,
+		Translation:    req.Translation,
+	})
+	if errors.Is(err, prompts.ErrNotFound) {
+		return nil, models.NewError(models.ErrNotFound, "prompt_not_found", err.Error())
+	}
+	if err != nil {
+		// Rendering fails when the template needs a variable that was not given
+		return nil, models.ValidationError("invalid_prompt_variables", err.Error())
+	}
+
+	return &models.PreviewPromptResponse{PromptVersion: version, Text: text}, nil
+}
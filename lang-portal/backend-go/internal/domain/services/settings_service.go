@@ -1,16 +1,18 @@
 package services
 
 import (
+	"context"
+
 	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
 )
 
 type Seeder interface {
-	SeedFromJSON(seedDir string) error
+	Seed(ctx context.Context) error
 }
 
 type SettingsServiceInterface interface {
-	ResetHistory() error
-	FullReset() error
+	ResetHistory(ctx context.Context) error
+	FullReset(ctx context.Context) error
 }
 
 type SettingsService struct {
@@ -25,22 +27,22 @@ func NewSettingsService(repo repository.Repository, seeder Seeder) *SettingsServ
 	}
 }
 
-func (s *SettingsService) ResetHistory() error {
-	return s.repo.ResetHistory()
+func (s *SettingsService) ResetHistory(ctx context.Context) error {
+	return s.repo.ResetHistory(ctx)
 }
 
-func (s *SettingsService) FullReset() error {
+func (s *SettingsService) FullReset(ctx context.Context) error {
 	// First drop all tables
-	if err := s.repo.DropAllTables(); err != nil {
+	if err := s.repo.DropAllTables(ctx); err != nil {
 		return err
 	}
 
 	// Then recreate tables and seed data
-	if err := s.repo.CreateTables(); err != nil {
+	if err := s.repo.CreateTables(ctx); err != nil {
 		return err
 	}
 
-	return s.seeder.SeedFromJSON("internal/db/seeds")
+	return s.seeder.Seed(ctx)
 }
 
 
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -14,8 +15,8 @@ type MockSeeder struct {
 	mock.Mock
 }
 
-func (m *MockSeeder) SeedFromJSON(seedDir string) error {
-	args := m.Called(seedDir)
+func (m *MockSeeder) Seed(_ context.Context) error {
+	args := m.Called()
 	return args.Error(0)
 }
 
@@ -26,7 +27,7 @@ func TestSettingsService_ResetHistory(t *testing.T) {
 		service := NewSettingsService(mockRepo, mockSeeder)
 
 		mockRepo.On("ResetHistory").Return(nil)
-		err := service.ResetHistory()
+		err := service.ResetHistory(context.Background())
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
 	})
@@ -39,7 +40,7 @@ func TestSettingsService_ResetHistory(t *testing.T) {
 		expectedErr := errors.New("database error")
 		mockRepo.On("ResetHistory").Return(expectedErr)
 
-		err := service.ResetHistory()
+		err := service.ResetHistory(context.Background())
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
 		mockRepo.AssertExpectations(t)
@@ -54,9 +55,9 @@ func TestSettingsService_FullReset(t *testing.T) {
 
 		mockRepo.On("DropAllTables").Return(nil)
 		mockRepo.On("CreateTables").Return(nil)
-		mockSeeder.On("SeedFromJSON", "internal/db/seeds").Return(nil)
+		mockSeeder.On("Seed").Return(nil)
 
-		err := service.FullReset()
+		err := service.FullReset(context.Background())
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
@@ -70,7 +71,7 @@ func TestSettingsService_FullReset(t *testing.T) {
 
 		mockRepo.On("DropAllTables").Return(errors.New("drop error"))
 
-		err := service.FullReset()
+		err := service.FullReset(context.Background())
 
 		assert.Error(t, err)
 		assert.Equal(t, "drop error", err.Error())
@@ -85,7 +86,7 @@ func TestSettingsService_FullReset(t *testing.T) {
 		mockRepo.On("DropAllTables").Return(nil)
 		mockRepo.On("CreateTables").Return(errors.New("create error"))
 
-		err := service.FullReset()
+		err := service.FullReset(context.Background())
 
 		assert.Error(t, err)
 		assert.Equal(t, "create error", err.Error())
@@ -99,9 +100,9 @@ func TestSettingsService_FullReset(t *testing.T) {
 
 		mockRepo.On("DropAllTables").Return(nil)
 		mockRepo.On("CreateTables").Return(nil)
-		mockSeeder.On("SeedFromJSON", "internal/db/seeds").Return(errors.New("seed error"))
+		mockSeeder.On("Seed").Return(errors.New("seed error"))
 
-		err := service.FullReset()
+		err := service.FullReset(context.Background())
 
 		assert.Error(t, err)
 		assert.Equal(t, "seed error", err.Error())
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type SpeakingServiceInterface interface {
+	SubmitSpokenAnswer(ctx context.Context, sessionID, wordID int64, audio io.Reader, filename string) (*models.SpokenAnswerResponse, error)
+}
+
+// spokenAnswerThreshold is the similarity from which a spoken answer counts
+// as correct. It tolerates a transcription slip or two in longer words.
+const spokenAnswerThreshold = 0.8
+
+type SpeakingService struct {
+	repo       repository.Repository
+	recognizer SpeechRecognizer
+}
+
+// NewSpeakingService creates a speaking service. A nil recognizer disables
+// spoken answers.
+func NewSpeakingService(repo repository.Repository, recognizer SpeechRecognizer) *SpeakingService {
+	return &SpeakingService{repo: repo, recognizer: recognizer}
+}
+
+// NewSpeakingServiceFromEnv creates a speaking service using the speech
+// recognizer configured in the environment
+func NewSpeakingServiceFromEnv(repo repository.Repository) *SpeakingService {
+	return NewSpeakingService(repo, newSpeechRecognizer())
+}
+
+// SubmitSpokenAnswer transcribes a recorded answer, grades it against the
+// word and records it as a speaking review
+func (s *SpeakingService) SubmitSpokenAnswer(ctx context.Context, sessionID, wordID int64, audio io.Reader, filename string) (*models.SpokenAnswerResponse, error) {
+	if s.recognizer == nil {
+		return nil, models.NewError(models.ErrUnavailable, "speech_recognition_not_configured", "speech recognition is not configured")
+	}
+
+	word, err := s.repo.GetWordByID(ctx, wordID)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := getCourse(ctx, s.repo, word.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	transcript, err := s.recognizer.Transcribe(ctx, audio, filename, course.TargetLanguage.Code)
+	if err != nil {
+		return nil, models.WrapError(models.ErrUpstream, "transcription_failed", "failed to transcribe audio", err)
+	}
+
+	similarity, accentsMatch := gradeSpokenAnswer(transcript, word.Term)
+	correct := similarity >= spokenAnswerThreshold
+	if err := s.repo.CreateWordReview(ctx, sessionID, wordID, correct, models.ReviewModeSpeaking); err != nil {
+		return nil, err
+	}
+
+	return &models.SpokenAnswerResponse{
+		WordID:       wordID,
+		Transcript:   transcript,
+		Expected:     word.Term,
+		Similarity:   math.Round(similarity*100) / 100,
+		AccentsMatch: accentsMatch,
+		Correct:      correct,
+	}, nil
+}
+
+// gradeSpokenAnswer scores how closely a transcript matches the expected term,
+// from 0 to 1. Transcripts often wrap the answer in other words, split or
+// join words differently, or drop accents, so the best matching run of words
+// is scored with spaces and accents removed. accentsMatch reports whether
+// that run also matches as well with its accents.
+func gradeSpokenAnswer(transcript, term string) (similarity float64, accentsMatch bool) {
+	want := spokenWords(term)
+	got := spokenWords(transcript)
+	if len(want) == 0 || len(got) == 0 {
+		return 0, false
+	}
+	expected := strings.Join(want, "")
+
+	// Scripts written without spaces, such as Japanese, transcribe as one
+	// run of text, so the answer is looked for anywhere inside it
+	if unspaced(expected) && strings.Contains(strings.Join(got, ""), expected) {
+		return 1, true
+	}
+
+	folded := foldAccents(expected)
+	best := 0.0
+	for size := len(want) - 1; size <= len(want)+1; size++ {
+		for i := 0; size > 0 && i+size <= len(got); i++ {
+			candidate := strings.Join(got[i:i+size], "")
+			score := stringSimilarity(foldAccents(candidate), folded)
+			if score > best {
+				best = score
+				accentsMatch = stringSimilarity(candidate, expected) == score
+			}
+		}
+	}
+	return best, accentsMatch
+}
+
+// spokenWords lowercases and NFC-normalizes text and splits it into words,
+// treating punctuation and apostrophes as separators
+func spokenWords(text string) []string {
+	return strings.FieldsFunc(norm.NFC.String(strings.ToLower(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.Mn, r)
+	})
+}
+
+// unspacedScripts are writing systems that do not separate words with spaces
+var unspacedScripts = []*unicode.RangeTable{unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai, unicode.Lao, unicode.Khmer, unicode.Myanmar}
+
+// unspaced reports whether text contains a script written without spaces
+func unspaced(text string) bool {
+	for _, r := range text {
+		if unicode.In(r, unspacedScripts...) {
+			return true
+		}
+	}
+	return false
+}
+
+// foldAccents strips diacritics, e.g. "perché" becomes "perche"
+func foldAccents(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return norm.NFC.String(b.String())
+}
+
+// stringSimilarity is 1 minus the edit distance between a and b relative to
+// the longer of the two
+func stringSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein counts the single rune insertions, deletions and substitutions
+// needed to turn a into b
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
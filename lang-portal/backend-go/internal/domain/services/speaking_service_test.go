@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+)
+
+type failingSpeechRecognizer struct{}
+
+func (failingSpeechRecognizer) Transcribe(ctx context.Context, audio io.Reader, filename, language string) (string, error) {
+	return "", errors.New("connection refused")
+}
+
+func TestGradeSpokenAnswer(t *testing.T) {
+	tests := []struct {
+		name         string
+		transcript   string
+		term         string
+		wantCorrect  bool
+		wantAccents  bool
+		wantMinScore float64
+	}{
+		{"exact", "Sorella.", "sorella", true, true, 1},
+		{"missing accent", "perche", "perché", true, false, 1},
+		{"wrapped in filler", "ehm, la sorella", "sorella", true, true, 1},
+		{"one slip", "sorela", "sorella", true, true, 0.85},
+		{"elision", "l'acqua", "l'acqua", true, true, 1},
+		{"split word", "buona sera", "buonasera", true, true, 1},
+		{"multi-word answer", "per favore grazie", "per favore", true, true, 1},
+		{"wrong word", "fratello", "sorella", false, true, 0},
+		{"empty transcript", "", "sorella", false, false, 0},
+		{"japanese in sentence", "猫です", "猫", true, true, 1},
+		{"substring is not a latin match", "sorella", "re", false, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, accents := gradeSpokenAnswer(tt.transcript, tt.term)
+			assert.Equal(t, tt.wantCorrect, score >= spokenAnswerThreshold, "similarity %.2f", score)
+			assert.Equal(t, tt.wantAccents, accents)
+			assert.GreaterOrEqual(t, score, tt.wantMinScore)
+		})
+	}
+}
+
+func TestWhisperSpeechRecognizer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		audio, _ := io.ReadAll(file)
+		if r.FormValue("model") != "whisper-1" || r.FormValue("language") != "it" ||
+			header.Filename != "answer.webm" || string(audio) != "audio" ||
+			r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"text": " sorella "}`))
+	}))
+	defer server.Close()
+
+	recognizer := NewWhisperSpeechRecognizer(server.URL, "", "secret")
+	transcript, err := recognizer.Transcribe(context.Background(), strings.NewReader("audio"), "answer.webm", "it")
+	require.NoError(t, err)
+	assert.Equal(t, "sorella", transcript)
+
+	_, err = recognizer.Transcribe(context.Background(), strings.NewReader("audio"), "answer.webm", "en")
+	assert.ErrorContains(t, err, "transcription service returned 400")
+}
+
+func TestSpeakingService_SubmitSpokenAnswer(t *testing.T) {
+	sorella := &models.WordResponse{ID: 1, Term: "sorella", Translation: "sister", CourseID: models.DefaultCourseID}
+
+	t.Run("records a speaking review", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewSpeakingService(mockRepo, FakeSpeechRecognizer{})
+
+		mockRepo.On("GetWordByID", int64(1)).Return(sorella, nil)
+		mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+		mockRepo.On("CreateWordReview", int64(5), int64(1), true, models.ReviewModeSpeaking).Return(nil)
+
+		response, err := service.SubmitSpokenAnswer(context.Background(), 5, 1, strings.NewReader("Sorella!"), "answer.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "Sorella!", response.Transcript)
+		assert.Equal(t, "sorella", response.Expected)
+		assert.True(t, response.Correct)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("records a wrong answer", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewSpeakingService(mockRepo, FakeSpeechRecognizer{})
+
+		mockRepo.On("GetWordByID", int64(1)).Return(sorella, nil)
+		mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+		mockRepo.On("CreateWordReview", int64(5), int64(1), false, models.ReviewModeSpeaking).Return(nil)
+
+		response, err := service.SubmitSpokenAnswer(context.Background(), 5, 1, strings.NewReader("fratello"), "answer.txt")
+		require.NoError(t, err)
+		assert.False(t, response.Correct)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("transcription failure is not recorded", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewSpeakingService(mockRepo, failingSpeechRecognizer{})
+
+		mockRepo.On("GetWordByID", int64(1)).Return(sorella, nil)
+		mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+
+		_, err := service.SubmitSpokenAnswer(context.Background(), 5, 1, strings.NewReader(""), "answer.wav")
+		assert.ErrorContains(t, err, "failed to transcribe audio")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		service := NewSpeakingService(new(mocks.MockRepository), nil)
+
+		_, err := service.SubmitSpokenAnswer(context.Background(), 5, 1, strings.NewReader(""), "answer.wav")
+		assert.ErrorContains(t, err, "not configured")
+	})
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SpeechRecognizer transcribes recorded speech
+type SpeechRecognizer interface {
+	// Transcribe returns the text spoken in audio. language is an ISO 639-1
+	// code hinting which language to expect.
+	Transcribe(ctx context.Context, audio io.Reader, filename, language string) (string, error)
+}
+
+// sttRequestTimeout bounds a single transcription request
+const sttRequestTimeout = time.Minute
+
+// defaultWhisperModel is sent to Whisper servers unless STT_MODEL overrides it
+const defaultWhisperModel = "whisper-1"
+
+// WhisperSpeechRecognizer calls a Whisper-compatible transcription endpoint,
+// such as the OpenAI /v1/audio/transcriptions API or a local server
+// implementing it. The audio is sent as a multipart upload and the transcript
+// read from the "text" field of the JSON response.
+type WhisperSpeechRecognizer struct {
+	url    string
+	model  string
+	apiKey string
+	client *http.Client
+}
+
+func NewWhisperSpeechRecognizer(url, model, apiKey string) *WhisperSpeechRecognizer {
+	if model == "" {
+		model = defaultWhisperModel
+	}
+	return &WhisperSpeechRecognizer{
+		url:    url,
+		model:  model,
+		apiKey: apiKey,
+		client: &http.Client{Timeout: sttRequestTimeout},
+	}
+}
+
+func (r *WhisperSpeechRecognizer) Transcribe(ctx context.Context, audio io.Reader, filename, language string) (string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if err := form.WriteField("model", r.model); err != nil {
+		return "", err
+	}
+	if err := form.WriteField("response_format", "json"); err != nil {
+		return "", err
+	}
+	if language != "" {
+		if err := form.WriteField("language", language); err != nil {
+			return "", err
+		}
+	}
+	file, err := form.CreateFormFile("file", filename)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, audio); err != nil {
+		return "", err
+	}
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	if r.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+r.apiKey)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("transcription request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("transcription service returned %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+
+	var result struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode transcription: %v", err)
+	}
+	return strings.TrimSpace(result.Text), nil
+}
+
+// FakeSpeechRecognizer treats the uploaded "audio" as its own transcript, so
+// spoken answers can be exercised in tests and local development by
+// uploading a text file
+type FakeSpeechRecognizer struct{}
+
+func (FakeSpeechRecognizer) Transcribe(ctx context.Context, audio io.Reader, filename, language string) (string, error) {
+	data, err := io.ReadAll(audio)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(data) {
+		return "", nil
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// newSpeechRecognizer picks the recognizer named by STT_PROVIDER: "whisper"
+// calls the server at STT_URL and "fake" echoes the upload. When STT_PROVIDER
+// is unset Whisper is used if STT_URL is set. It returns nil when no
+// recognizer is configured.
+func newSpeechRecognizer() SpeechRecognizer {
+	provider := os.Getenv("STT_PROVIDER")
+	url := os.Getenv("STT_URL")
+
+	switch provider {
+	case "fake":
+		return FakeSpeechRecognizer{}
+	case "whisper", "":
+		if url == "" {
+			if provider != "" {
+				log.Warn().Msg("STT_PROVIDER is whisper but STT_URL is not set, speech recognition is disabled")
+			}
+			return nil
+		}
+		return NewWhisperSpeechRecognizer(url, os.Getenv("STT_MODEL"), os.Getenv("STT_API_KEY"))
+	default:
+		log.Warn().Str("STT_PROVIDER", provider).Msg("Unknown speech recognition provider, speech recognition is disabled")
+		return nil
+	}
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// jsonObjectStream extracts the objects of a top-level JSON array from text
+// that arrives in arbitrary chunks. Any text before the opening bracket (such
+// as a preamble from the model) is ignored.
+type jsonObjectStream struct {
+	started  bool
+	done     bool
+	depth    int
+	inString bool
+	escaped  bool
+	current  []byte
+}
+
+// Write consumes the next chunk and returns every object completed by it
+func (s *jsonObjectStream) Write(chunk string) [][]byte {
+	var objects [][]byte
+	for i := 0; i < len(chunk) && !s.done; i++ {
+		c := chunk[i]
+
+		if !s.started {
+			s.started = c == '['
+			continue
+		}
+
+		if s.depth == 0 {
+			switch c {
+			case '{':
+				s.depth = 1
+				s.current = append(s.current[:0], c)
+			case ']':
+				s.done = true
+			}
+			continue
+		}
+
+		s.current = append(s.current, c)
+		if s.inString {
+			switch {
+			case s.escaped:
+				s.escaped = false
+			case c == '\\':
+				s.escaped = true
+			case c == '"':
+				s.inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			s.inString = true
+		case '{', '[':
+			s.depth++
+		case '}', ']':
+			s.depth--
+			if s.depth == 0 {
+				objects = append(objects, append([]byte(nil), s.current...))
+			}
+		}
+	}
+	return objects
+}
+
+// jsonStringFieldStream decodes the value of a single string field of a JSON
+// object while the object is still being streamed, so the text can be shown
+// to the user before the rest of the object arrives
+type jsonStringFieldStream struct {
+	key   *regexp.Regexp
+	raw   string
+	pos   int
+	found bool
+	done  bool
+}
+
+func newJSONStringFieldStream(field string) *jsonStringFieldStream {
+	return &jsonStringFieldStream{
+		key: regexp.MustCompile(`"` + regexp.QuoteMeta(field) + `"\s*:\s*"`),
+	}
+}
+
+// Write consumes the next chunk and returns the newly decoded part of the
+// field value. Escape sequences and multi-byte characters split across chunks
+// are held back until they are complete.
+func (s *jsonStringFieldStream) Write(chunk string) string {
+	s.raw += chunk
+	if s.done {
+		return ""
+	}
+
+	if !s.found {
+		loc := s.key.FindStringIndex(s.raw)
+		if loc == nil {
+			return ""
+		}
+		s.found = true
+		s.pos = loc[1]
+	}
+
+	var out strings.Builder
+	for s.pos < len(s.raw) {
+		c := s.raw[s.pos]
+		if c == '"' {
+			s.done = true
+			break
+		}
+
+		if c != '\\' {
+			if c >= utf8.RuneSelf && !utf8.FullRuneInString(s.raw[s.pos:]) {
+				break
+			}
+			_, size := utf8.DecodeRuneInString(s.raw[s.pos:])
+			out.WriteString(s.raw[s.pos : s.pos+size])
+			s.pos += size
+			continue
+		}
+
+		decoded, size := decodeJSONEscape(s.raw[s.pos:])
+		if size == 0 {
+			break
+		}
+		out.WriteString(decoded)
+		s.pos += size
+	}
+
+	return out.String()
+}
+
+// decodeJSONEscape decodes the escape sequence at the start of s, returning the
+// decoded text and the number of bytes consumed. size is 0 when the sequence
+// is not complete yet.
+func decodeJSONEscape(s string) (decoded string, size int) {
+	if len(s) < 2 {
+		return "", 0
+	}
+
+	switch s[1] {
+	case 'n':
+		return "\n", 2
+	case 't':
+		return "\t", 2
+	case 'r':
+		return "\r", 2
+	case 'b':
+		return "\b", 2
+	case 'f':
+		return "\f", 2
+	case 'u':
+		r, ok := parseUnicodeEscape(s)
+		if !ok {
+			return "", 0
+		}
+		if !utf16.IsSurrogate(r) {
+			return string(r), 6
+		}
+		// Characters outside the BMP are sent as a surrogate pair
+		if len(s) < 12 {
+			return "", 0
+		}
+		low, ok := parseUnicodeEscape(s[6:])
+		if !ok {
+			return string(utf8.RuneError), 6
+		}
+		return string(utf16.DecodeRune(r, low)), 12
+	default:
+		// \" \\ \/ and anything unexpected decode to the escaped character
+		return s[1:2], 2
+	}
+}
+
+// parseUnicodeEscape parses a \uXXXX sequence at the start of s
+func parseUnicodeEscape(s string) (rune, bool) {
+	if len(s) < 6 || s[0] != '\\' || s[1] != 'u' {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(s[2:6], 16, 32)
+	if err != nil {
+		return utf8.RuneError, true
+	}
+	return rune(n), true
+}
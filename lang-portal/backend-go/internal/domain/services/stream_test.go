@@ -0,0 +1,71 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONObjectStream(t *testing.T) {
+	chunks := []string{
+		"Here are the words:\n[\n  {\"italian\": \"pa",
+		"ne\", \"parts\": {\"type\": \"noun\"}},",
+		" {\"italian\": \"l'a}cqua\\\"\"}",
+		"\n]\n{\"ignored\": true}",
+	}
+
+	stream := &jsonObjectStream{}
+	var objects []string
+	for _, chunk := range chunks {
+		for _, object := range stream.Write(chunk) {
+			objects = append(objects, string(object))
+		}
+	}
+
+	assert.Equal(t, []string{
+		`{"italian": "pane", "parts": {"type": "noun"}}`,
+		`{"italian": "l'a}cqua\""}`,
+	}, objects)
+}
+
+func TestJSONStringFieldStream(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+		want   []string
+	}{
+		{
+			name:   "split key and value",
+			chunks: []string{`{"hints": ["x"], "mes`, `sage": "Ciao`, ` a tutti", "answer": "secret"}`},
+			want:   []string{"Ciao", " a tutti"},
+		},
+		{
+			name:   "escapes split across chunks",
+			chunks: []string{`{"message": "a\`, `nb \u00`, `e8 \"c\""}`},
+			want:   []string{"a", "\nb ", "è \"c\""},
+		},
+		{
+			name:   "multi-byte characters split across chunks",
+			chunks: []string{"{\"message\": \"perch\xc3", "\xa9 \\ud83d\\ude00\"}"},
+			want:   []string{"perch", "é 😀"},
+		},
+		{
+			name:   "field missing",
+			chunks: []string{`{"hints": []}`},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := newJSONStringFieldStream("message")
+			var got []string
+			for _, chunk := range tt.chunks {
+				if text := stream.Write(chunk); text != "" {
+					got = append(got, text)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,17 +1,17 @@
 package services
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 )
 
 type StudyActivityServiceInterface interface {
-	GetStudyActivities(limit, offset int) (*models.StudyActivityListResponse, error)
-	GetStudyActivity(id int64) (*models.StudyActivityResponse, error)
-	GetStudyActivitySessions(activityID int64) (*models.StudySessionsListResponse, error)
-	LaunchStudyActivity(activityID, groupID int64) (*models.LaunchStudyActivityResponse, error)
+	GetStudyActivities(ctx context.Context, limit, offset int) (*models.StudyActivityListResponse, error)
+	GetStudyActivity(ctx context.Context, id int64) (*models.StudyActivityResponse, error)
+	GetStudyActivitySessions(ctx context.Context, activityID int64) (*models.StudySessionsListResponse, error)
+	LaunchStudyActivity(ctx context.Context, activityID, groupID int64) (*models.LaunchStudyActivityResponse, error)
 }
 
 type StudyActivityService struct {
@@ -22,12 +22,12 @@ func NewStudyActivityService(repo repository.Repository) StudyActivityServiceInt
 	return &StudyActivityService{repo: repo}
 }
 
-func (s *StudyActivityService) GetStudyActivities(limit, offset int) (*models.StudyActivityListResponse, error) {
-	return s.repo.GetStudyActivities(limit, offset)
+func (s *StudyActivityService) GetStudyActivities(ctx context.Context, limit, offset int) (*models.StudyActivityListResponse, error) {
+	return s.repo.GetStudyActivities(ctx, limit, offset)
 }
 
-func (s *StudyActivityService) GetStudyActivity(id int64) (*models.StudyActivityResponse, error) {
-	activity, err := s.repo.GetStudyActivity(id)
+func (s *StudyActivityService) GetStudyActivity(ctx context.Context, id int64) (*models.StudyActivityResponse, error) {
+	activity, err := s.repo.GetStudyActivity(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -45,22 +45,18 @@ func (s *StudyActivityService) GetStudyActivity(id int64) (*models.StudyActivity
 	}, nil
 }
 
-func (s *StudyActivityService) LaunchStudyActivity(activityID, groupID int64) (*models.LaunchStudyActivityResponse, error) {
+func (s *StudyActivityService) LaunchStudyActivity(ctx context.Context, activityID, groupID int64) (*models.LaunchStudyActivityResponse, error) {
 	// Verify activity exists
-	activity, err := s.repo.GetStudyActivity(activityID)
-	if err != nil {
+	if _, err := s.repo.GetStudyActivity(ctx, activityID); err != nil {
 		return nil, err
 	}
-	if activity == nil {
-		return nil, fmt.Errorf("study activity not found")
-	}
 
 	// Create study session
-	return s.repo.CreateStudyActivitySession(activityID, groupID)
+	return s.repo.CreateStudyActivitySession(ctx, activityID, groupID)
 }
 
-func (s *StudyActivityService) GetStudyActivitySessions(activityID int64) (*models.StudySessionsListResponse, error) {
-	sessions, err := s.repo.GetStudyActivitySessions(activityID, 100, 0) // Using limit=100 as per spec
+func (s *StudyActivityService) GetStudyActivitySessions(ctx context.Context, activityID int64) (*models.StudySessionsListResponse, error) {
+	sessions, err := s.repo.GetStudyActivitySessions(ctx, activityID, 100, 0) // Using limit=100 as per spec
 	if err != nil {
 		return nil, err
 	}
@@ -71,19 +67,19 @@ func (s *StudyActivityService) GetStudyActivitySessions(activityID int64) (*mode
 
 	for _, session := range sessions {
 		// Get activity details
-		activity, err := s.repo.GetStudyActivity(session.StudyActivityID)
+		activity, err := s.repo.GetStudyActivity(ctx, session.StudyActivityID)
 		if err != nil {
 			return nil, err
 		}
 
 		// Get group details
-		group, err := s.repo.GetGroupByID(session.GroupID)
+		group, err := s.repo.GetGroupByID(ctx, session.GroupID)
 		if err != nil {
 			return nil, err
 		}
 
 		// Get session stats
-		wordReviews, err := s.repo.GetWordReviewsBySessionID(session.ID)
+		wordReviews, err := s.repo.GetWordReviewsBySessionID(ctx, session.ID)
 		if err != nil {
 			return nil, err
 		}
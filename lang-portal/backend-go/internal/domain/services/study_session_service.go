@@ -1,16 +1,25 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"math/rand"
+
 	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 )
 
 type StudySessionServiceInterface interface {
-	GetAllStudySessions(limit, offset int) (*models.StudySessionListResponse, error)
-	GetStudySessionWords(sessionID int64, limit, offset int) (*models.StudySessionWordsResponse, error)
-	ReviewWord(sessionID, wordID int64, correct bool) (*models.WordReviewResponse, error)
+	GetAllStudySessions(ctx context.Context, params models.ListParams, includeReviews bool) (*models.StudySessionListResponse, error)
+	GetStudySessionWords(ctx context.Context, sessionID int64, limit, offset int) (*models.StudySessionWordsResponse, error)
+	ReviewWord(ctx context.Context, sessionID, wordID int64, correct bool, mode string) (*models.WordReviewResponse, error)
+	GetClozeCard(ctx context.Context, sessionID, wordID int64) (*models.ClozeCardResponse, error)
+	SubmitClozeAnswer(ctx context.Context, sessionID, wordID int64, req *models.ClozeAnswerRequest) (*models.ClozeAnswerResponse, error)
 }
 
+// maxClozeSentences caps how many of a word's sentences are considered for a cloze card
+const maxClozeSentences = 100
+
 type StudySessionService struct {
 	repo repository.Repository
 }
@@ -20,103 +29,78 @@ func NewStudySessionService(repo repository.Repository) *StudySessionService {
 }
 
 // GetStudySessionWords returns a paginated list of words reviewed in a study session
-func (s *StudySessionService) GetStudySessionWords(sessionID int64, limit, offset int) (*models.StudySessionWordsResponse, error) {
-	words, total, err := s.repo.GetStudySessionWords(sessionID, limit, offset)
+func (s *StudySessionService) GetStudySessionWords(ctx context.Context, sessionID int64, limit, offset int) (*models.StudySessionWordsResponse, error) {
+	words, total, err := s.repo.GetStudySessionWords(ctx, sessionID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 
-	totalPages := (total + limit - 1) / limit
 	return &models.StudySessionWordsResponse{
-		Items: words,
-		Pagination: models.PaginationResponse{
-			CurrentPage:   (offset / limit) + 1,
-			TotalPages:    totalPages,
-			TotalItems:    total,
-			ItemsPerPage:  limit,
-		},
+		Items:      words,
+		Pagination: models.NewPagination(limit, offset, total),
 	}, nil
 }
 
-func (s *StudySessionService) GetAllStudySessions(limit, offset int) (*models.StudySessionListResponse, error) {
-	// Get study sessions
-	sessions, err := s.repo.GetAllStudySessions(limit, offset)
+// GetAllStudySessions lists study sessions newest first, with the review
+// items of each session when includeReviews is set. Every page but the last
+// carries the cursor of the next one, whether it was fetched by offset or by
+// cursor.
+func (s *StudySessionService) GetAllStudySessions(ctx context.Context, params models.ListParams, includeReviews bool) (*models.StudySessionListResponse, error) {
+	// One session more than the page tells whether there is a next page
+	limit := params.Limit
+	params.Limit++
+	sessions, err := s.repo.GetAllStudySessions(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
+	var nextCursor string
+	if len(sessions) > limit {
+		sessions = sessions[:limit]
+		last := sessions[len(sessions)-1]
+		nextCursor = models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
 	// Get total count for pagination
-	total, err := s.repo.GetTotalStudySessions()
+	total, err := s.repo.GetTotalStudySessions(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build response with pagination
-	totalPages := (total + limit - 1) / limit
-	response := &models.StudySessionListResponse{
-		Items: []models.StudySessionDetailResponse{},
-		Pagination: models.PaginationResponse{
-			CurrentPage:  (offset / limit) + 1,
-			TotalPages:   totalPages,
-			TotalItems:   total,
-			ItemsPerPage: limit,
-		},
-	}
-
-	// Process each study session
-	for _, session := range sessions {
-		// Get activity details
-		activity, err := s.repo.GetStudyActivity(session.StudyActivityID)
-		if err != nil {
-			return nil, err
+	if includeReviews && len(sessions) > 0 {
+		ids := make([]int64, len(sessions))
+		for i, session := range sessions {
+			ids[i] = session.ID
 		}
-
-		// Get group details
-		group, err := s.repo.GetGroupByID(session.GroupID)
-		if err != nil {
-			return nil, err
-		}
-
-		// Get word reviews
-		reviews, err := s.repo.GetWordReviewsBySessionID(session.ID)
+		reviews, err := s.repo.GetWordReviewsBySessionIDs(ctx, ids)
 		if err != nil {
 			return nil, err
 		}
-
-		// Calculate stats
-		var stats models.StudySessionStats
-		if len(reviews) > 0 {
-			var correctCount int
-			for _, review := range reviews {
-				if review.Correct {
-					correctCount++
-				}
-			}
-			stats.TotalWords = len(reviews)
-			stats.CorrectWords = correctCount
-			stats.SuccessRate = float64(correctCount) / float64(len(reviews)) * 100
-		}
-
-		// Create detailed response
-		detailedSession := models.StudySessionDetailResponse{
-			ID:           session.ID,
-			ActivityName: activity.Name,
-			GroupName:    group.Name,
-			CreatedAt:    session.CreatedAt,
-			Stats:        stats,
-			ReviewItems:  reviews,
+		for i := range sessions {
+			sessions[i].ReviewItems = reviews[sessions[i].ID]
 		}
+	}
 
-		response.Items = append(response.Items, detailedSession)
+	response := &models.StudySessionListResponse{
+		Items:      sessions,
+		Pagination: models.NewPagination(limit, params.Offset, total),
+	}
+	response.Pagination.NextCursor = nextCursor
+	if params.Cursor != nil {
+		response.Pagination.CurrentPage = 0
 	}
 
 	return response, nil
 }
 
 // ReviewWord records a word review in a study session
-func (s *StudySessionService) ReviewWord(sessionID, wordID int64, correct bool) (*models.WordReviewResponse, error) {
+func (s *StudySessionService) ReviewWord(ctx context.Context, sessionID, wordID int64, correct bool, mode string) (*models.WordReviewResponse, error) {
+	if mode == "" {
+		mode = models.ReviewModeRecognition
+	}
+
 	// Create the word review
-	err := s.repo.CreateWordReview(sessionID, wordID, correct)
+	err := s.repo.CreateWordReview(ctx, sessionID, wordID, correct, mode)
 	if err != nil {
 		return nil, err
 	}
@@ -126,3 +110,66 @@ func (s *StudySessionService) ReviewWord(sessionID, wordID int64, correct bool)
 		WordID:  wordID,
 	}, nil
 }
+
+// GetClozeCard builds a fill-in-the-blank card for a word from one of its example sentences
+func (s *StudySessionService) GetClozeCard(ctx context.Context, sessionID, wordID int64) (*models.ClozeCardResponse, error) {
+	word, err := s.repo.GetWordByID(ctx, wordID)
+	if err != nil {
+		return nil, err
+	}
+
+	sentences, err := s.repo.GetWordSentences(ctx, wordID, maxClozeSentences, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pick a random sentence that actually contains a form of the word
+	items := sentences.Items
+	rand.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+	for _, sentence := range items {
+		prompt, _, ok := blankWord(sentence.Text, word.Term, word.Parts)
+		if !ok {
+			continue
+		}
+		return &models.ClozeCardResponse{
+			StudySessionID: sessionID,
+			WordID:         wordID,
+			SentenceID:     sentence.ID,
+			Prompt:         prompt,
+			Translation:    sentence.Translation,
+			Hint:           word.Translation,
+		}, nil
+	}
+
+	return nil, models.NewError(models.ErrNotFound, "cloze_sentence_not_found", fmt.Sprintf("cloze sentence not found for word %d", wordID))
+}
+
+// SubmitClozeAnswer grades an answer to a cloze card and records it as a cloze review
+func (s *StudySessionService) SubmitClozeAnswer(ctx context.Context, sessionID, wordID int64, req *models.ClozeAnswerRequest) (*models.ClozeAnswerResponse, error) {
+	word, err := s.repo.GetWordByID(ctx, wordID)
+	if err != nil {
+		return nil, err
+	}
+
+	sentence, err := s.repo.GetWordSentence(ctx, wordID, req.SentenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, expected, ok := blankWord(sentence.Text, word.Term, word.Parts)
+	if !ok {
+		return nil, models.NewError(models.ErrNotFound, "cloze_sentence_not_found", fmt.Sprintf("cloze sentence not found for word %d", wordID))
+	}
+
+	correct := normalizeAnswer(req.Answer) == normalizeAnswer(expected)
+	if err := s.repo.CreateWordReview(ctx, sessionID, wordID, correct, models.ReviewModeCloze); err != nil {
+		return nil, err
+	}
+
+	return &models.ClozeAnswerResponse{
+		WordID:   wordID,
+		Correct:  correct,
+		Expected: expected,
+		Sentence: sentence.Text,
+	}, nil
+}
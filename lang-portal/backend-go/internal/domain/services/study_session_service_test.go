@@ -1,8 +1,10 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
@@ -10,23 +12,23 @@ import (
 )
 
 func TestStudySessionService_GetStudySessionWords(t *testing.T) {
-	mockRepo := &mocks.MockRepository{}
-	service := NewStudySessionService(mockRepo)
-
 	t.Run("successful retrieval", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewStudySessionService(mockRepo)
+
 		expectedWords := []*models.WordResponse{
 			{
-				ID:      1,
-				Italian: "ciao",
-				English: "hello",
-				Parts:   map[string]interface{}{"part": "greeting"},
+				ID:          1,
+				Term:        "ciao",
+				Translation: "hello",
+				Parts:       map[string]interface{}{"part": "greeting"},
 			},
 		}
 		totalWords := 1
 
 		mockRepo.On("GetStudySessionWords", int64(1), 10, 0).Return(expectedWords, totalWords, nil)
 
-		response, err := service.GetStudySessionWords(1, 10, 0)
+		response, err := service.GetStudySessionWords(context.Background(), 1, 10, 0)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
@@ -36,9 +38,12 @@ func TestStudySessionService_GetStudySessionWords(t *testing.T) {
 	})
 
 	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewStudySessionService(mockRepo)
+
 		mockRepo.On("GetStudySessionWords", int64(1), 10, 0).Return(nil, 0, errors.New("repository error"))
 
-		response, err := service.GetStudySessionWords(1, 10, 0)
+		response, err := service.GetStudySessionWords(context.Background(), 1, 10, 0)
 
 		assert.Error(t, err)
 		assert.Nil(t, response)
@@ -52,42 +57,51 @@ func TestStudySessionService_GetAllStudySessions(t *testing.T) {
 		mockRepo := new(mocks.MockRepository)
 		service := NewStudySessionService(mockRepo)
 
-		sessions := []models.StudySession{
+		sessions := []models.StudySessionDetailResponse{
 			{
-				ID:              1,
-				GroupID:         1,
-				StudyActivityID: 1,
+				ID:           1,
+				ActivityName: "Test Activity",
+				GroupName:    "Test Group",
+				Stats:        models.StudySessionStats{TotalWords: 1, CorrectWords: 1, SuccessRate: 100},
 			},
 		}
-		activity := &models.StudyActivityResponse{
-			ID:           1,
-			Name:         "Test Activity",
-			Description:  models.StrPtr("Test Description"),
-			ThumbnailURL: models.StrPtr("http://example.com/thumb.jpg"),
-			LaunchURL:    models.StrPtr("http://example.com/launch"),
-		}
-		group := &models.GroupDetailResponse{
-			ID:    1,
-			Name:  "Test Group",
-			Stats: models.GroupStats{TotalWordCount: 10},
-		}
-		reviews := []models.WordReviewItem{{WordID: 1, Correct: true}}
 
-		mockRepo.On("GetAllStudySessions", 10, 0).Return(sessions, nil)
+		mockRepo.On("GetAllStudySessions", models.ListParams{Limit: 11}).Return(sessions, nil)
 		mockRepo.On("GetTotalStudySessions").Return(1, nil)
-		mockRepo.On("GetStudyActivity", int64(1)).Return(activity, nil)
-		mockRepo.On("GetGroupByID", int64(1)).Return(group, nil)
-		mockRepo.On("GetWordReviewsBySessionID", int64(1)).Return(reviews, nil)
 
-		response, err := service.GetAllStudySessions(10, 0)
+		response, err := service.GetAllStudySessions(context.Background(), models.ListParams{Limit: 10}, false)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
 		assert.Equal(t, 1, len(response.Items))
 		assert.Equal(t, sessions[0].ID, response.Items[0].ID)
-		assert.Equal(t, activity.Name, response.Items[0].ActivityName)
-		assert.Equal(t, group.Name, response.Items[0].GroupName)
+		assert.Equal(t, "Test Activity", response.Items[0].ActivityName)
+		assert.Equal(t, "Test Group", response.Items[0].GroupName)
 		assert.Equal(t, 100.0, response.Items[0].Stats.SuccessRate)
+		assert.Nil(t, response.Items[0].ReviewItems)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "GetWordReviewsBySessionIDs", []int64{1})
+	})
+
+	t.Run("include reviews", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewStudySessionService(mockRepo)
+
+		sessions := []models.StudySessionDetailResponse{{ID: 2}, {ID: 1}}
+		reviews := map[int64][]models.WordReviewItem{
+			1: {{WordID: 1, StudySessionID: 1, Correct: true}},
+		}
+
+		mockRepo.On("GetAllStudySessions", models.ListParams{Limit: 11}).Return(sessions, nil)
+		mockRepo.On("GetTotalStudySessions").Return(2, nil)
+		mockRepo.On("GetWordReviewsBySessionIDs", []int64{2, 1}).Return(reviews, nil).Once()
+
+		response, err := service.GetAllStudySessions(context.Background(), models.ListParams{Limit: 10}, true)
+
+		assert.NoError(t, err)
+		assert.Len(t, response.Items, 2)
+		assert.Empty(t, response.Items[0].ReviewItems)
+		assert.Equal(t, reviews[1], response.Items[1].ReviewItems)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -97,10 +111,10 @@ func TestStudySessionService_GetAllStudySessions(t *testing.T) {
 		service := NewStudySessionService(mockRepo)
 
 		// Only set up expectations needed for this test
-		mockRepo.On("GetAllStudySessions", 10, 0).Return([]models.StudySession{}, nil)
+		mockRepo.On("GetAllStudySessions", models.ListParams{Limit: 11}).Return([]models.StudySessionDetailResponse{}, nil)
 		mockRepo.On("GetTotalStudySessions").Return(0, nil)
 
-		response, err := service.GetAllStudySessions(10, 0)
+		response, err := service.GetAllStudySessions(context.Background(), models.ListParams{Limit: 10}, true)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
@@ -114,16 +128,58 @@ func TestStudySessionService_GetAllStudySessions(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("next cursor", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewStudySessionService(mockRepo)
+
+		createdAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+		sessions := []models.StudySessionDetailResponse{
+			{ID: 3, CreatedAt: createdAt},
+			{ID: 2, CreatedAt: createdAt},
+		}
+		cursor := &models.Cursor{CreatedAt: createdAt, ID: 4}
+
+		mockRepo.On("GetAllStudySessions", models.ListParams{Limit: 2, Cursor: cursor}).Return(sessions, nil)
+		mockRepo.On("GetTotalStudySessions").Return(5, nil)
+		mockRepo.On("GetWordReviewsBySessionIDs", []int64{3}).Return(map[int64][]models.WordReviewItem{}, nil)
+
+		response, err := service.GetAllStudySessions(context.Background(), models.ListParams{Limit: 1, Cursor: cursor}, true)
+
+		assert.NoError(t, err)
+		assert.Len(t, response.Items, 1)
+		assert.Equal(t, 0, response.Pagination.CurrentPage)
+		next, err := models.DecodeCursor(response.Pagination.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), next.ID)
+		assert.True(t, createdAt.Equal(next.CreatedAt))
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("repository error - GetTotalStudySessions", func(t *testing.T) {
 		// Create new mock for each test
 		mockRepo := new(mocks.MockRepository)
 		service := NewStudySessionService(mockRepo)
 
 		// Only set up expectations needed for this test
-		mockRepo.On("GetAllStudySessions", 10, 0).Return([]models.StudySession{}, nil)
+		mockRepo.On("GetAllStudySessions", models.ListParams{Limit: 11}).Return([]models.StudySessionDetailResponse{}, nil)
 		mockRepo.On("GetTotalStudySessions").Return(0, errors.New("repository error"))
 
-		response, err := service.GetAllStudySessions(10, 0)
+		response, err := service.GetAllStudySessions(context.Background(), models.ListParams{Limit: 10}, false)
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error - GetWordReviewsBySessionIDs", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewStudySessionService(mockRepo)
+
+		mockRepo.On("GetAllStudySessions", models.ListParams{Limit: 11}).Return([]models.StudySessionDetailResponse{{ID: 1}}, nil)
+		mockRepo.On("GetTotalStudySessions").Return(1, nil)
+		mockRepo.On("GetWordReviewsBySessionIDs", []int64{1}).Return(nil, errors.New("repository error"))
+
+		response, err := service.GetAllStudySessions(context.Background(), models.ListParams{Limit: 10}, true)
 
 		assert.Error(t, err)
 		assert.Nil(t, response)
@@ -132,13 +188,13 @@ func TestStudySessionService_GetAllStudySessions(t *testing.T) {
 }
 
 func TestStudySessionService_ReviewWord(t *testing.T) {
-	mockRepo := &mocks.MockRepository{}
-	service := NewStudySessionService(mockRepo)
-
 	t.Run("successful review", func(t *testing.T) {
-		mockRepo.On("CreateWordReview", int64(1), int64(1), true).Return(nil)
+		mockRepo := new(mocks.MockRepository)
+		service := NewStudySessionService(mockRepo)
+
+		mockRepo.On("CreateWordReview", int64(1), int64(1), true, "recognition").Return(nil)
 
-		response, err := service.ReviewWord(1, 1, true)
+		response, err := service.ReviewWord(context.Background(), 1, 1, true, "")
 
 		assert.NoError(t, err)
 		assert.NotNil(t, response)
@@ -148,12 +204,70 @@ func TestStudySessionService_ReviewWord(t *testing.T) {
 	})
 
 	t.Run("repository error", func(t *testing.T) {
-		mockRepo.On("CreateWordReview", int64(1), int64(1), true).Return(errors.New("repository error"))
+		mockRepo := new(mocks.MockRepository)
+		service := NewStudySessionService(mockRepo)
+
+		mockRepo.On("CreateWordReview", int64(1), int64(1), true, "recognition").Return(errors.New("repository error"))
 
-		response, err := service.ReviewWord(1, 1, true)
+		response, err := service.ReviewWord(context.Background(), 1, 1, true, "")
 
 		assert.Error(t, err)
 		assert.Nil(t, response)
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestStudySessionService_SubmitClozeAnswer(t *testing.T) {
+	word := &models.WordResponse{
+		ID:          1,
+		Term:        "sorella",
+		Translation: "sister",
+		Parts:       map[string]interface{}{"type": "noun", "gender": "feminine", "plural": "sorelle"},
+	}
+	sentence := &models.SentenceResponse{ID: 2, Text: "Ho due sorelle.", Translation: "I have two sisters."}
+
+	t.Run("inflected form answered correctly", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewStudySessionService(mockRepo)
+
+		mockRepo.On("GetWordByID", int64(1)).Return(word, nil)
+		mockRepo.On("GetWordSentence", int64(1), int64(2)).Return(sentence, nil)
+		mockRepo.On("CreateWordReview", int64(5), int64(1), true, "cloze").Return(nil)
+
+		response, err := service.SubmitClozeAnswer(context.Background(), 5, 1, &models.ClozeAnswerRequest{SentenceID: 2, Answer: " Sorelle "})
+
+		assert.NoError(t, err)
+		assert.True(t, response.Correct)
+		assert.Equal(t, "sorelle", response.Expected)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong answer is recorded", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewStudySessionService(mockRepo)
+
+		mockRepo.On("GetWordByID", int64(1)).Return(word, nil)
+		mockRepo.On("GetWordSentence", int64(1), int64(2)).Return(sentence, nil)
+		mockRepo.On("CreateWordReview", int64(5), int64(1), false, "cloze").Return(nil)
+
+		response, err := service.SubmitClozeAnswer(context.Background(), 5, 1, &models.ClozeAnswerRequest{SentenceID: 2, Answer: "sorella"})
+
+		assert.NoError(t, err)
+		assert.False(t, response.Correct)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("sentence not linked to word", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		service := NewStudySessionService(mockRepo)
+
+		mockRepo.On("GetWordByID", int64(1)).Return(word, nil)
+		mockRepo.On("GetWordSentence", int64(1), int64(3)).Return(nil, models.NotFoundError("sentence"))
+
+		response, err := service.SubmitClozeAnswer(context.Background(), 5, 1, &models.ClozeAnswerRequest{SentenceID: 3, Answer: "sorelle"})
+
+		assert.ErrorIs(t, err, models.ErrNotFound)
+		assert.Nil(t, response)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+type SyncServiceInterface interface {
+	Pull(ctx context.Context, token string, limit int) (*models.SyncPullResponse, error)
+	Push(ctx context.Context, req *models.SyncPushRequest) (*models.SyncPushResponse, error)
+}
+
+// SyncService lets offline clients pull the changes made since they last
+// synced and push the sessions and reviews they recorded offline
+type SyncService struct {
+	repo repository.Repository
+}
+
+func NewSyncService(repo repository.Repository) *SyncService {
+	return &SyncService{repo: repo}
+}
+
+// Pull returns up to limit changes after the sync token, or from the start
+// without one. A token of another change log, or from beyond its end, as
+// after a backup is restored, starts over from the start with Reset set.
+func (s *SyncService) Pull(ctx context.Context, token string, limit int) (*models.SyncPullResponse, error) {
+	epoch, latest, err := s.repo.GetChangeLogState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.SyncPullResponse{}
+	var since int64
+	if token != "" {
+		position, err := models.DecodeSyncToken(token)
+		if err != nil {
+			return nil, models.ValidationError("invalid_sync_token", err.Error())
+		}
+		if position.Epoch != epoch || position.Seq > latest {
+			response.Reset = true
+		} else {
+			since = position.Seq
+		}
+	}
+
+	// One change more than the page tells whether there are more
+	changes, err := s.repo.GetChanges(ctx, since, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) > limit {
+		changes = changes[:limit]
+		response.HasMore = true
+	}
+	if len(changes) > 0 {
+		since = changes[len(changes)-1].Seq
+	}
+
+	response.Changes = changes
+	response.Token = models.SyncToken{Epoch: epoch, Seq: since}.Encode()
+	return response, nil
+}
+
+// Push records the sessions and reviews a client recorded offline. The
+// outcome does not depend on the order the client lists them in or on how
+// often it pushes them:
+//   - items are applied oldest first, and by client ID when recorded at the
+//     same time;
+//   - an item pushed before is kept as first recorded and reported as a
+//     duplicate, with the ID it was given;
+//   - deletions on the server win, so an item referring to a deleted group,
+//     word or session is rejected rather than recreating it;
+//   - times after the server's clock are taken as the time of the push.
+func (s *SyncService) Push(ctx context.Context, req *models.SyncPushRequest) (*models.SyncPushResponse, error) {
+	now := time.Now().UTC()
+
+	// A UUID listed twice could be applied with either content
+	pushed := map[string]bool{}
+	sessions := make([]models.PushedSession, len(req.Sessions))
+	for i, session := range req.Sessions {
+		clientID, err := parseClientID(session.ClientID, pushed)
+		if err != nil {
+			return nil, err
+		}
+		session.ClientID = clientID
+		if session.StartedAt.After(now) {
+			session.StartedAt = now
+		}
+		sessions[i] = session
+	}
+
+	reviews := make([]models.PushedReview, len(req.Reviews))
+	for i, review := range req.Reviews {
+		clientID, err := parseClientID(review.ClientID, pushed)
+		if err != nil {
+			return nil, err
+		}
+		review.ClientID = clientID
+		if (review.StudySessionID == 0) == (review.SessionClientID == "") {
+			return nil, models.ValidationError("invalid_review", fmt.Sprintf("review %s needs either study_session_id or session_client_id", clientID))
+		}
+		if review.SessionClientID != "" {
+			if review.SessionClientID, err = parseClientID(review.SessionClientID, nil); err != nil {
+				return nil, err
+			}
+		}
+		if review.ReviewMode == "" {
+			review.ReviewMode = models.ReviewModeRecognition
+		}
+		if review.ReviewedAt.After(now) {
+			review.ReviewedAt = now
+		}
+		reviews[i] = review
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].StartedAt.Equal(sessions[j].StartedAt) {
+			return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+		}
+		return sessions[i].ClientID < sessions[j].ClientID
+	})
+	sort.Slice(reviews, func(i, j int) bool {
+		if !reviews[i].ReviewedAt.Equal(reviews[j].ReviewedAt) {
+			return reviews[i].ReviewedAt.Before(reviews[j].ReviewedAt)
+		}
+		return reviews[i].ClientID < reviews[j].ClientID
+	})
+
+	return s.repo.ApplySyncPush(ctx, sessions, reviews)
+}
+
+// parseClientID validates a client-generated UUID and returns it in its
+// lowercase form, so the same UUID always matches. With seen, it also
+// checks that the UUID was not seen before, and records it.
+func parseClientID(id string, seen map[string]bool) (string, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return "", models.ValidationError("invalid_client_id", fmt.Sprintf("client_id %q is not a UUID", id))
+	}
+	id = parsed.String()
+	if seen != nil {
+		if seen[id] {
+			return "", models.ValidationError("duplicate_client_id", fmt.Sprintf("client_id %s is pushed more than once", id))
+		}
+		seen[id] = true
+	}
+	return id, nil
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+)
+
+func TestSyncService_Pull(t *testing.T) {
+	changes := []models.SyncChange{
+		{Seq: 11, Entity: models.SyncEntityWord, ID: 1, Op: models.SyncOpInsert},
+		{Seq: 12, Entity: models.SyncEntityGroup, ID: 1, Op: models.SyncOpDelete},
+		{Seq: 14, Entity: models.SyncEntityWord, ID: 2, Op: models.SyncOpUpdate},
+	}
+
+	t.Run("first sync", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetChangeLogState").Return("epoch", int64(14), nil)
+		mockRepo.On("GetChanges", int64(0), 3).Return(changes, nil)
+
+		response, err := NewSyncService(mockRepo).Pull(context.Background(), "", 2)
+		require.NoError(t, err)
+		assert.Len(t, response.Changes, 2)
+		assert.True(t, response.HasMore)
+		assert.False(t, response.Reset)
+		assert.Equal(t, models.SyncToken{Epoch: "epoch", Seq: 12}.Encode(), response.Token)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("since a token", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetChangeLogState").Return("epoch", int64(14), nil)
+		mockRepo.On("GetChanges", int64(12), 3).Return(changes[2:], nil)
+
+		token := models.SyncToken{Epoch: "epoch", Seq: 12}.Encode()
+		response, err := NewSyncService(mockRepo).Pull(context.Background(), token, 2)
+		require.NoError(t, err)
+		assert.Len(t, response.Changes, 1)
+		assert.False(t, response.HasMore)
+		assert.Equal(t, models.SyncToken{Epoch: "epoch", Seq: 14}.Encode(), response.Token)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("nothing new keeps the token", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetChangeLogState").Return("epoch", int64(14), nil)
+		mockRepo.On("GetChanges", int64(14), 3).Return([]models.SyncChange{}, nil)
+
+		token := models.SyncToken{Epoch: "epoch", Seq: 14}.Encode()
+		response, err := NewSyncService(mockRepo).Pull(context.Background(), token, 2)
+		require.NoError(t, err)
+		assert.Empty(t, response.Changes)
+		assert.Equal(t, token, response.Token)
+	})
+
+	t.Run("token of another change log starts over", func(t *testing.T) {
+		for _, token := range []models.SyncToken{{Epoch: "restored", Seq: 3}, {Epoch: "epoch", Seq: 20}} {
+			mockRepo := new(mocks.MockRepository)
+			mockRepo.On("GetChangeLogState").Return("epoch", int64(14), nil)
+			mockRepo.On("GetChanges", int64(0), 3).Return(changes, nil)
+
+			response, err := NewSyncService(mockRepo).Pull(context.Background(), token.Encode(), 2)
+			require.NoError(t, err)
+			assert.True(t, response.Reset)
+			assert.Equal(t, int64(11), response.Changes[0].Seq)
+			mockRepo.AssertExpectations(t)
+		}
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("GetChangeLogState").Return("epoch", int64(14), nil)
+
+		_, err := NewSyncService(mockRepo).Pull(context.Background(), "garbage", 2)
+		assert.ErrorIs(t, err, models.ErrValidation)
+	})
+}
+
+func TestSyncService_Push(t *testing.T) {
+	at := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("applies items oldest first with normalized client IDs", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		var sessions []models.PushedSession
+		var reviews []models.PushedReview
+		mockRepo.On("ApplySyncPush", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			sessions = args.Get(0).([]models.PushedSession)
+			reviews = args.Get(1).([]models.PushedReview)
+		}).Return(&models.SyncPushResponse{}, nil)
+
+		_, err := NewSyncService(mockRepo).Push(context.Background(), &models.SyncPushRequest{
+			Sessions: []models.PushedSession{
+				{ClientID: "0B6F3C3E-8C1A-4D2E-9A51-6F0D7B2F4E11", GroupID: 1, StudyActivityID: 1, StartedAt: at},
+			},
+			Reviews: []models.PushedReview{
+				{ClientID: "9c4e51d0-bf99-4ec3-95b8-60bcd347eaf6", SessionClientID: "0B6F3C3E-8C1A-4D2E-9A51-6F0D7B2F4E11", WordID: 2, ReviewedAt: at.Add(2 * time.Minute)},
+				{ClientID: "8b3d40cf-ae88-4db2-84a7-5fabc236d9e5", SessionClientID: "0b6f3c3e-8c1a-4d2e-9a51-6f0d7b2f4e11", WordID: 1, ReviewedAt: at.Add(time.Minute)},
+				{ClientID: "7a2c3fbe-9d77-4ca1-b3f6-4e9ab125c8d4", StudySessionID: 5, WordID: 1, ReviewMode: models.ReviewModeRecall, ReviewedAt: at.Add(time.Minute)},
+				{ClientID: "6f1b2ead-8c66-4b90-a2e5-3d8fa014b7c3", StudySessionID: 5, WordID: 3, ReviewedAt: time.Now().Add(time.Hour)},
+			},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, sessions, 1)
+		assert.Equal(t, "0b6f3c3e-8c1a-4d2e-9a51-6f0d7b2f4e11", sessions[0].ClientID)
+		require.Len(t, reviews, 4)
+		assert.Equal(t, "7a2c3fbe-9d77-4ca1-b3f6-4e9ab125c8d4", reviews[0].ClientID)
+		assert.Equal(t, "8b3d40cf-ae88-4db2-84a7-5fabc236d9e5", reviews[1].ClientID)
+		assert.Equal(t, "0b6f3c3e-8c1a-4d2e-9a51-6f0d7b2f4e11", reviews[1].SessionClientID)
+		assert.Equal(t, models.ReviewModeRecognition, reviews[1].ReviewMode)
+		assert.Equal(t, models.ReviewModeRecall, reviews[0].ReviewMode)
+		assert.Equal(t, "9c4e51d0-bf99-4ec3-95b8-60bcd347eaf6", reviews[2].ClientID)
+		assert.False(t, reviews[3].ReviewedAt.After(time.Now()), "reviews from the future are taken as pushed now")
+		mockRepo.AssertExpectations(t)
+	})
+
+	invalid := map[string]models.SyncPushRequest{
+		"not a UUID": {Reviews: []models.PushedReview{
+			{ClientID: "42", StudySessionID: 5, WordID: 1, ReviewedAt: at},
+		}},
+		"UUID listed twice": {Reviews: []models.PushedReview{
+			{ClientID: "7a2c3fbe-9d77-4ca1-b3f6-4e9ab125c8d4", StudySessionID: 5, WordID: 1, ReviewedAt: at},
+			{ClientID: "7A2C3FBE-9D77-4CA1-B3F6-4E9AB125C8D4", StudySessionID: 5, WordID: 1, Correct: true, ReviewedAt: at},
+		}},
+		"no session": {Reviews: []models.PushedReview{
+			{ClientID: "7a2c3fbe-9d77-4ca1-b3f6-4e9ab125c8d4", WordID: 1, ReviewedAt: at},
+		}},
+		"two sessions": {Reviews: []models.PushedReview{
+			{ClientID: "7a2c3fbe-9d77-4ca1-b3f6-4e9ab125c8d4", StudySessionID: 5, SessionClientID: "0b6f3c3e-8c1a-4d2e-9a51-6f0d7b2f4e11", WordID: 1, ReviewedAt: at},
+		}},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := new(mocks.MockRepository)
+			_, err := NewSyncService(mockRepo).Push(context.Background(), &req)
+			assert.ErrorIs(t, err, models.ErrValidation)
+			mockRepo.AssertNotCalled(t, "ApplySyncPush", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockRepo.On("ApplySyncPush", mock.Anything, mock.Anything).Return(nil, errors.New("database is locked"))
+
+		_, err := NewSyncService(mockRepo).Push(context.Background(), &models.SyncPushRequest{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// textToken matches a word, keeping elisions such as l'amico and po' together
+var textToken = regexp.MustCompile(`\p{L}[\p{L}\p{M}]*(?:['’]\p{L}[\p{L}\p{M}]*)*['’]?`)
+
+// italianElisions maps elided Italian forms, without their apostrophe, to the
+// full words they stand for
+var italianElisions = map[string][]string{
+	"l":     {"lo", "la", "il"},
+	"un":    {"uno", "una"},
+	"dell":  {"dello", "della"},
+	"all":   {"allo", "alla"},
+	"dall":  {"dallo", "dalla"},
+	"nell":  {"nello", "nella"},
+	"sull":  {"sullo", "sulla"},
+	"coll":  {"collo", "colla"},
+	"quell": {"quello", "quella"},
+	"quest": {"questo", "questa"},
+	"bell":  {"bello", "bella"},
+	"sant":  {"santo", "santa"},
+	"c":     {"ci"},
+	"d":     {"di"},
+	"m":     {"mi"},
+	"t":     {"ti"},
+	"s":     {"si"},
+	"v":     {"vi"},
+	"n":     {"ne"},
+	"dov":   {"dove"},
+	"com":   {"come"},
+	"cos":   {"cosa"},
+	"anch":  {"anche"},
+	"po":    {"poco"},
+}
+
+// textTokenPart is one word of a tokenized text. Elided forms keep their
+// apostrophe and list the full words they may stand for as lookups.
+type textTokenPart struct {
+	surface string
+	lookups []string
+}
+
+// tokenizeText lower-cases text and splits it into words. Elisions are split
+// into the elided word and the word it is attached to, so l'amico becomes
+// "l'" and "amico"; for Italian the elided word also looks up its full forms.
+func tokenizeText(text, language string) []textTokenPart {
+	var tokens []textTokenPart
+	for _, match := range textToken.FindAllString(strings.ToLower(text), -1) {
+		pieces := strings.Split(strings.ReplaceAll(match, "’", "'"), "'")
+		for i, piece := range pieces {
+			if piece == "" {
+				continue
+			}
+
+			// Every piece but the last was cut short by an apostrophe, as is
+			// the last one when the match ends with one (po')
+			if i == len(pieces)-1 {
+				tokens = append(tokens, textTokenPart{surface: piece, lookups: []string{piece}})
+				continue
+			}
+			token := textTokenPart{surface: piece + "'", lookups: []string{piece + "'"}}
+			if language == "it" {
+				token.lookups = append(token.lookups, italianElisions[piece]...)
+			}
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// vocabularyIndex finds the vocabulary entry a token is a form of
+type vocabularyIndex struct {
+	forms   map[string]*models.WordResponse
+	stems   map[string]*models.WordResponse
+	phrases map[string][]vocabularyPhrase
+}
+
+// vocabularyPhrase is a vocabulary entry spanning several tokens, such as
+// "per favore" or "l'acqua"
+type vocabularyPhrase struct {
+	tokens []string
+	word   *models.WordResponse
+}
+
+// newVocabularyIndex indexes words by their lemma, the forms recorded in
+// their parts and their stems. Earlier words win when two share a form.
+func newVocabularyIndex(words []models.WordResponse, language string) *vocabularyIndex {
+	index := &vocabularyIndex{
+		forms:   make(map[string]*models.WordResponse),
+		stems:   make(map[string]*models.WordResponse),
+		phrases: make(map[string][]vocabularyPhrase),
+	}
+
+	// Lemmas are indexed before explicit forms so a form never hides a lemma
+	for i := range words {
+		word := &words[i]
+		tokens := tokenizeText(word.Term, language)
+		switch {
+		case len(tokens) == 0:
+			continue
+		case len(tokens) > 1:
+			phrase := vocabularyPhrase{word: word}
+			for _, token := range tokens {
+				phrase.tokens = append(phrase.tokens, token.surface)
+			}
+			index.phrases[phrase.tokens[0]] = append(index.phrases[phrase.tokens[0]], phrase)
+		default:
+			setIfMissing(index.forms, tokens[0].surface, word)
+		}
+	}
+	for i := range words {
+		word := &words[i]
+		for _, form := range knownForms(word.Term, word.Parts)[1:] {
+			setIfMissing(index.forms, form, word)
+		}
+		for _, stem := range wordStems(word.Term) {
+			setIfMissing(index.stems, stem, word)
+		}
+	}
+	return index
+}
+
+func setIfMissing(index map[string]*models.WordResponse, key string, word *models.WordResponse) {
+	if _, ok := index[key]; !ok {
+		index[key] = word
+	}
+}
+
+// lookupPhrase returns the longest vocabulary phrase starting at tokens[0]
+func (v *vocabularyIndex) lookupPhrase(tokens []textTokenPart) (*models.WordResponse, int) {
+	var best *models.WordResponse
+	length := 0
+	for _, phrase := range v.phrases[tokens[0].surface] {
+		if len(phrase.tokens) <= length || len(phrase.tokens) > len(tokens) {
+			continue
+		}
+		matched := true
+		for i, surface := range phrase.tokens {
+			if tokens[i].surface != surface {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			best, length = phrase.word, len(phrase.tokens)
+		}
+	}
+	return best, length
+}
+
+// lookup finds the vocabulary entry a single token is a form of: an exact
+// lemma or recorded form first, then a stem followed by a short inflection
+// ending that starts with a vowel (amic+i, parl+iamo)
+func (v *vocabularyIndex) lookup(token textTokenPart) *models.WordResponse {
+	for _, form := range token.lookups {
+		if word := v.forms[form]; word != nil {
+			return word
+		}
+	}
+
+	surface := token.surface
+	// The h of -che/-chi/-ghe/-ghi only keeps the stem's consonant hard
+	for _, ending := range []string{"che", "chi", "ghe", "ghi"} {
+		if strings.HasSuffix(surface, ending) {
+			if word := v.stems[strings.TrimSuffix(surface, ending[1:])]; word != nil {
+				return word
+			}
+		}
+	}
+
+	runes := []rune(surface)
+	for cut := 1; cut <= maxInflectionSuffix && len(runes)-cut >= 3; cut++ {
+		if !strings.ContainsRune("aeiouàèéìòù", runes[len(runes)-cut]) {
+			continue
+		}
+		if word := v.stems[string(runes[:len(runes)-cut])]; word != nil {
+			return word
+		}
+	}
+	return nil
+}
+
+// analyzeText maps every token of text to the vocabulary and summarizes which
+// words are known and which are not
+func analyzeText(text, language string, index *vocabularyIndex) *models.TextAnalysisResponse {
+	tokens := tokenizeText(text, language)
+	response := &models.TextAnalysisResponse{
+		KnownWords:    []models.KnownWordResponse{},
+		UnknownTokens: []models.UnknownTokenResponse{},
+	}
+
+	known := make(map[int64]int)
+	unknown := make(map[string]int)
+	for i := 0; i < len(tokens); {
+		word, length := index.lookupPhrase(tokens[i:])
+		if word == nil {
+			word, length = index.lookup(tokens[i]), 1
+		}
+
+		if word == nil {
+			surface := tokens[i].surface
+			if pos, ok := unknown[surface]; ok {
+				response.UnknownTokens[pos].Occurrences++
+			} else {
+				unknown[surface] = len(response.UnknownTokens)
+				response.UnknownTokens = append(response.UnknownTokens, models.UnknownTokenResponse{Token: surface, Occurrences: 1})
+			}
+			i++
+			continue
+		}
+
+		var forms []string
+		for _, token := range tokens[i : i+length] {
+			forms = append(forms, token.surface)
+		}
+		form := strings.Join(forms, " ")
+
+		pos, ok := known[word.ID]
+		if !ok {
+			pos = len(response.KnownWords)
+			known[word.ID] = pos
+			response.KnownWords = append(response.KnownWords, knownWord(word))
+		}
+		entry := &response.KnownWords[pos]
+		entry.Occurrences++
+		if !containsString(entry.Forms, form) {
+			entry.Forms = append(entry.Forms, form)
+		}
+
+		response.KnownTokens += length
+		i += length
+	}
+
+	// The most frequent unknown words are the most useful to learn next
+	sort.SliceStable(response.UnknownTokens, func(i, j int) bool {
+		return response.UnknownTokens[i].Occurrences > response.UnknownTokens[j].Occurrences
+	})
+
+	response.TotalTokens = len(tokens)
+	if response.TotalTokens > 0 {
+		response.Coverage = roundPercent(float64(response.KnownTokens) / float64(response.TotalTokens) * 100)
+	}
+	return response
+}
+
+func knownWord(word *models.WordResponse) models.KnownWordResponse {
+	entry := models.KnownWordResponse{
+		WordID:       word.ID,
+		Term:         word.Term,
+		Translation:  word.Translation,
+		CorrectCount: word.CorrectCount,
+		WrongCount:   word.WrongCount,
+		Forms:        []string{},
+	}
+	if reviews := word.CorrectCount + word.WrongCount; reviews > 0 {
+		entry.Mastery = roundPercent(float64(word.CorrectCount) / float64(reviews) * 100)
+	}
+	return entry
+}
+
+// roundPercent rounds a percentage to one decimal place
+func roundPercent(p float64) float64 {
+	return float64(int(p*10+0.5)) / 10
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
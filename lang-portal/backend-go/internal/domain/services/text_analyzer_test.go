@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+)
+
+// analyzerVocabulary is a small Italian vocabulary with the kinds of entries
+// the analyzer has to handle: nouns, verbs, recorded forms and phrases
+var analyzerVocabulary = []models.WordResponse{
+	{ID: 1, Term: "sorella", Translation: "sister", Parts: map[string]interface{}{"type": "noun", "plural": "sorelle"}, CorrectCount: 3, WrongCount: 1},
+	{ID: 2, Term: "amico", Translation: "friend", Parts: map[string]interface{}{"type": "noun", "plural": "amici"}},
+	{ID: 3, Term: "parlare", Translation: "to speak", Parts: map[string]interface{}{"type": "verb"}},
+	{ID: 4, Term: "il", Translation: "the", Parts: map[string]interface{}{"type": "article"}},
+	{ID: 5, Term: "per favore", Translation: "please", Parts: map[string]interface{}{"type": "phrase"}},
+	{ID: 6, Term: "bianco", Translation: "white", Parts: map[string]interface{}{"type": "adjective"}},
+	{ID: 7, Term: "cane", Translation: "dog", Parts: map[string]interface{}{"type": "noun"}},
+	{ID: 8, Term: "di", Translation: "of", Parts: map[string]interface{}{"type": "preposition"}},
+}
+
+func TestTokenizeText(t *testing.T) {
+	var surfaces []string
+	for _, token := range tokenizeText("L'amico dell’anno, un po' stanco: 3 volte!", "it") {
+		surfaces = append(surfaces, token.surface)
+	}
+	assert.Equal(t, []string{"l'", "amico", "dell'", "anno", "un", "po'", "stanco", "volte"}, surfaces)
+
+	elided := tokenizeText("l'amico", "it")[0]
+	assert.Equal(t, []string{"l'", "lo", "la", "il"}, elided.lookups)
+	assert.Equal(t, []string{"l'"}, tokenizeText("l'ami", "fr")[0].lookups)
+}
+
+func TestAnalyzeText(t *testing.T) {
+	index := newVocabularyIndex(analyzerVocabulary, "it")
+
+	response := analyzeText("L'amico di mia sorella parla. Le sorelle bianche, per favore! Cantare con gli amici.", "it", index)
+
+	assert.Equal(t, 15, response.TotalTokens)
+	assert.Equal(t, 10, response.KnownTokens)
+	assert.Equal(t, 66.7, response.Coverage)
+
+	byTerm := make(map[string]models.KnownWordResponse)
+	for _, word := range response.KnownWords {
+		byTerm[word.Term] = word
+	}
+	assert.Equal(t, []string{"il", "amico", "di", "sorella", "parlare", "bianco", "per favore"}, knownTerms(response))
+	assert.Equal(t, []string{"l'"}, byTerm["il"].Forms)
+	assert.Equal(t, []string{"amico", "amici"}, byTerm["amico"].Forms)
+	assert.Equal(t, []string{"sorella", "sorelle"}, byTerm["sorella"].Forms)
+	assert.Equal(t, []string{"parla"}, byTerm["parlare"].Forms)
+	assert.Equal(t, []string{"bianche"}, byTerm["bianco"].Forms)
+	assert.Equal(t, 2, byTerm["sorella"].Occurrences)
+	assert.Equal(t, 75.0, byTerm["sorella"].Mastery)
+	assert.Equal(t, 0.0, byTerm["amico"].Mastery)
+
+	var unknown []string
+	for _, token := range response.UnknownTokens {
+		unknown = append(unknown, token.Token)
+	}
+	// "cantare" must not be taken for a form of "cane"
+	assert.Equal(t, []string{"mia", "le", "cantare", "con", "gli"}, unknown)
+}
+
+func knownTerms(response *models.TextAnalysisResponse) []string {
+	var terms []string
+	for _, word := range response.KnownWords {
+		terms = append(terms, word.Term)
+	}
+	return terms
+}
+
+func TestAnalyzerService_AddUnknownWords(t *testing.T) {
+	mockRepo := new(mocks.MockRepository)
+	service := NewAnalyzerService(mockRepo, NewWordService(mockRepo))
+
+	mockRepo.On("GetGroupByID", int64(1)).Return(&models.GroupDetailResponse{ID: 1}, nil)
+	mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+	mockRepo.On("GetWords", models.DefaultCourseID, models.ListParams{Limit: vocabularyPageSize}).
+		Return(&models.WordListResponse{Items: analyzerVocabulary}, nil)
+	mockRepo.On("FindOrCreateWord", mock.MatchedBy(func(w *models.WordResponse) bool { return w.Term == "cantare" })).Return(int64(20), nil)
+	mockRepo.On("AddWordToGroup", int64(20), int64(1)).Return(nil)
+	mockRepo.On("UpdateGroupWordsCount", int64(1)).Return(nil)
+
+	response, err := service.AddUnknownWords(context.Background(), &models.AddUnknownWordsRequest{
+		GroupID: 1,
+		Words: []models.UnknownWordSelection{
+			{Term: "cantare", Translation: "to sing"},
+			{Term: "Cantare", Translation: "to sing"},
+			{Term: "amici", Translation: "friends"},
+			{Term: "ねこ", Translation: "cat"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, response.AddedCount)
+	assert.Equal(t, []string{"amici"}, response.AlreadyKnown)
+	assert.Equal(t, 1, response.SkippedCount)
+	mockRepo.AssertExpectations(t)
+}
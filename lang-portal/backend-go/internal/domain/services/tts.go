@@ -0,0 +1,152 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// TTSProvider synthesizes speech for a piece of text
+type TTSProvider interface {
+	// Synthesize returns the audio for text spoken in language (an ISO 639-1
+	// code) by voice, along with its MIME type
+	Synthesize(ctx context.Context, text, language, voice string) ([]byte, string, error)
+}
+
+// ttsRequestTimeout bounds a single synthesis request
+const ttsRequestTimeout = 30 * time.Second
+
+// maxTTSAudioSize caps the audio accepted from a TTS service; a single word
+// or sentence is far smaller
+const maxTTSAudioSize = 10 << 20
+
+// HTTPTTSProvider calls a local TTS service. It POSTs
+// {"text", "language", "voice"} as JSON and expects the audio as the
+// response body, with its MIME type in the Content-Type header.
+type HTTPTTSProvider struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPTTSProvider(url string) *HTTPTTSProvider {
+	return &HTTPTTSProvider{url: url, client: &http.Client{Timeout: ttsRequestTimeout}}
+}
+
+func (p *HTTPTTSProvider) Synthesize(ctx context.Context, text, language, voice string) ([]byte, string, error) {
+	body, err := json.Marshal(map[string]string{
+		"text":     text,
+		"language": language,
+		"voice":    voice,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("TTS request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, "", fmt.Errorf("TTS service returned %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+
+	contentType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || audioExtensions[contentType] == "" {
+		return nil, "", fmt.Errorf("TTS service returned unsupported content type %q", resp.Header.Get("Content-Type"))
+	}
+
+	audio, err := io.ReadAll(io.LimitReader(resp.Body, maxTTSAudioSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read TTS audio: %v", err)
+	}
+	if len(audio) > maxTTSAudioSize {
+		return nil, "", fmt.Errorf("TTS audio exceeds %d bytes", maxTTSAudioSize)
+	}
+	if len(audio) == 0 {
+		return nil, "", fmt.Errorf("TTS service returned no audio")
+	}
+
+	return audio, contentType, nil
+}
+
+// FakeTTSProvider returns a short silent WAV for any text. It stands in for a
+// real TTS service in tests and local development.
+type FakeTTSProvider struct{}
+
+func (FakeTTSProvider) Synthesize(ctx context.Context, text, language, voice string) ([]byte, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+	return silentWAV(500 * time.Millisecond), "audio/wav", nil
+}
+
+// silentWAV encodes duration of silence as 16-bit mono PCM at 16 kHz
+func silentWAV(duration time.Duration) []byte {
+	const (
+		sampleRate    = 16000
+		bitsPerSample = 16
+		channels      = 1
+	)
+	blockAlign := channels * bitsPerSample / 8
+	dataSize := int(duration.Seconds()*sampleRate) * blockAlign
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+dataSize))
+	buf.WriteString("WAVE")
+	buf.WriteString("fmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))
+	binary.Write(&buf, binary.LittleEndian, uint16(1)) // PCM
+	binary.Write(&buf, binary.LittleEndian, uint16(channels))
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate))
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate*blockAlign))
+	binary.Write(&buf, binary.LittleEndian, uint16(blockAlign))
+	binary.Write(&buf, binary.LittleEndian, uint16(bitsPerSample))
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(dataSize))
+	buf.Write(make([]byte, dataSize))
+	return buf.Bytes()
+}
+
+// newTTSProvider picks the provider named by TTS_PROVIDER: "http" calls the
+// service at TTS_URL and "fake" returns silence. When TTS_PROVIDER is unset
+// the HTTP provider is used if TTS_URL is set. It returns nil when no
+// provider is configured.
+func newTTSProvider() TTSProvider {
+	provider := os.Getenv("TTS_PROVIDER")
+	url := os.Getenv("TTS_URL")
+
+	switch provider {
+	case "fake":
+		return FakeTTSProvider{}
+	case "http", "":
+		if url == "" {
+			if provider != "" {
+				log.Warn().Msg("TTS_PROVIDER is http but TTS_URL is not set, text-to-speech is disabled")
+			}
+			return nil
+		}
+		return NewHTTPTTSProvider(url)
+	default:
+		log.Warn().Str("TTS_PROVIDER", provider).Msg("Unknown TTS provider, text-to-speech is disabled")
+		return nil
+	}
+}
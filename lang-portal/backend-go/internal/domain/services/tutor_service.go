@@ -0,0 +1,350 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+)
+
+// ChatCompleter sends a conversation to an LLM and returns its reply, either
+// in one piece or streamed token by token
+type ChatCompleter interface {
+	Chat(ctx context.Context, messages []models.ChatMessage) (string, error)
+	ChatStream(ctx context.Context, messages []models.ChatMessage, onDelta func(string) error) (string, error)
+}
+
+type TutorServiceInterface interface {
+	CreateConversation(ctx context.Context, req *models.CreateTutorConversationRequest) (*models.TutorConversationResponse, error)
+	GetConversation(ctx context.Context, id int64) (*models.TutorConversationResponse, error)
+	PostTurn(ctx context.Context, conversationID int64, req *models.TutorTurnRequest) (*models.TutorTurnResponse, error)
+	PostTurnStream(ctx context.Context, conversationID int64, req *models.TutorTurnRequest, onDelta func(string) error) (*models.TutorTurnResponse, error)
+}
+
+const (
+	// maxTutorAttempts is how many tries a learner gets before the answer is revealed
+	maxTutorAttempts = 3
+	// maxPreferredWords caps how many group words are listed in the system prompt
+	maxPreferredWords = 40
+)
+
+// tutorPersonas holds the persona introductions from the sentence-constructor
+// prompts. tutorText fills in {source}, {target} and {attempts}.
+var tutorPersonas = map[string]struct {
+	intro   string
+	welcome string
+}{
+	models.TutorPersonaLuca: {
+		intro:   "You are LUCA (Language Understanding & Cultural Assistant), an enthusiastic {target} tutor specializing in gamified learning for A1 students. Your teaching style emphasizes discovery learning through strategic scaffolding and positive reinforcement.",
+		welcome: "Hi! 👋 I'm Luca, your {target} learning companion! Give me a sentence in {source} and I'll help you discover the {target} version with hints. You get {attempts} attempts per sentence. What's your first sentence?",
+	},
+	models.TutorPersonaMaria: {
+		intro:   "You are MARIA (Motivational Adaptive Interactive Reinforcement Assistant), an AI {target} tutor specializing in scaffolded translation challenges. Your persona combines a friendly grandmother's warmth with rigorous CEFR-aligned instruction.",
+		welcome: "Welcome, dear! I'm Maria. Send me a sentence in {source} and we'll build the {target} together, one clue at a time. You have {attempts} attempts per sentence. Let's begin!",
+	},
+	models.TutorPersonaMaestro: {
+		intro:   "You are MAESTRO, an interactive {target} tutor specializing in scaffolded translation practice for beginners. Your teaching method combines gradual hint disclosure with lexical reinforcement, maintaining strict A1-level constraints.",
+		welcome: "Hello, student! 🌟 I'm Maestro, your guide to {target} essentials. You give me a sentence in {source}, I provide the building blocks, and we construct the {target} version together. What's your first sentence?",
+	},
+}
+
+const tutorRules = `
+# CORE MECHANICS
+1. The student provides a {source} sentence, or an attempt at translating the current sentence into {target}
+2. Give progressive hints (grammar, then structure, then vocabulary), never the full translation
+3. The student has {attempts} attempts per sentence; score the result out of 10 (10 first attempt, 8 second, 6 third; -1 for each grammar error such as agreement, conjugation or word order)
+4. After completion, propose the next {source} sentence
+
+# RULES
+- Communicate in {source}
+- Provide vocabulary in dictionary form, with the {target} word as "term" and its {source} meaning as "translation"
+- Never include the full {target} translation in "message" or "hints"
+- Put the full {target} translation only in the "answer" field
+
+# RESPONSE FORMAT
+Reply with a single JSON object and nothing else:
+{
+	"message": "feedback and guidance for the student",
+	"hints": ["hint 1", "hint 2"],
+	"vocabulary": [
+		{"term": "{target} word", "part_of_speech": "verb", "translation": "{source} meaning", "pronunciation": "pronunciation guide"}
+	],
+	"attempt": 1,
+	"score": null,
+	"solved": false,
+	"answer": "full {target} translation of the current sentence"
+}`
+
+// tutorReply is the JSON structure the tutor is instructed to answer with
+type tutorReply struct {
+	Message    string                        `json:"message"`
+	Hints      []string                      `json:"hints"`
+	Vocabulary []models.TutorVocabularyEntry `json:"vocabulary"`
+	Attempt    int                           `json:"attempt"`
+	Score      *int                          `json:"score"`
+	Solved     bool                          `json:"solved"`
+	Answer     string                        `json:"answer"`
+}
+
+type TutorService struct {
+	repo repository.Repository
+	llm  ChatCompleter
+}
+
+func NewTutorService(repo repository.Repository, llm ChatCompleter) *TutorService {
+	return &TutorService{repo: repo, llm: llm}
+}
+
+// CreateConversation starts a tutoring conversation, storing the system prompt
+// and the persona's welcome message
+func (s *TutorService) CreateConversation(ctx context.Context, req *models.CreateTutorConversationRequest) (*models.TutorConversationResponse, error) {
+	persona := req.Persona
+	if persona == "" {
+		persona = models.TutorPersonaLuca
+	}
+
+	course, err := getCourse(ctx, s.repo, req.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Groups may mix courses, so only the words of this course are preferred
+	var preferred []models.WordResponse
+	if req.GroupID != nil {
+		if _, err := s.repo.GetGroupByID(ctx, *req.GroupID); err != nil {
+			return nil, err
+		}
+
+		words, err := s.repo.GetGroupWords(ctx, *req.GroupID, maxPreferredWords, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, word := range words.Items {
+			if word.CourseID == course.ID {
+				preferred = append(preferred, word)
+			}
+		}
+	}
+
+	id, err := s.repo.CreateTutorConversation(ctx, course.ID, req.GroupID, persona)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.AddTutorMessage(ctx, id, models.ChatRoleSystem, buildTutorPrompt(persona, course, preferred)); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.AddTutorMessage(ctx, id, models.ChatRoleAssistant, tutorText(tutorPersonas[persona].welcome, course)); err != nil {
+		return nil, err
+	}
+
+	return s.GetConversation(ctx, id)
+}
+
+// GetConversation returns a conversation with its visible messages and vocabulary table
+func (s *TutorService) GetConversation(ctx context.Context, id int64) (*models.TutorConversationResponse, error) {
+	conversation, err := s.repo.GetTutorConversation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := s.repo.GetTutorMessages(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	vocabulary, err := s.repo.GetTutorVocabulary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The system prompt is an implementation detail, not part of the visible history
+	visible := []models.TutorMessage{}
+	for _, message := range messages {
+		if message.Role != models.ChatRoleSystem {
+			visible = append(visible, message)
+		}
+	}
+
+	return &models.TutorConversationResponse{
+		TutorConversation: *conversation,
+		Messages:          visible,
+		Vocabulary:        vocabulary,
+	}, nil
+}
+
+// PostTurn records a learner turn, asks the LLM for guidance and returns hints.
+// The full translation is withheld until the attempts are used up, the sentence
+// is solved or the learner explicitly asks for it.
+func (s *TutorService) PostTurn(ctx context.Context, conversationID int64, req *models.TutorTurnRequest) (*models.TutorTurnResponse, error) {
+	conversation, messages, content, err := s.turnMessages(ctx, conversationID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := s.llm.Chat(ctx, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completeTurn(ctx, conversation, req, content, raw)
+}
+
+// PostTurnStream works like PostTurn but streams the tutor's guidance text to
+// onDelta as it is generated. Only the "message" field is streamed so the
+// withheld answer never reaches the client early.
+func (s *TutorService) PostTurnStream(ctx context.Context, conversationID int64, req *models.TutorTurnRequest, onDelta func(string) error) (*models.TutorTurnResponse, error) {
+	conversation, messages, content, err := s.turnMessages(ctx, conversationID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	message := newJSONStringFieldStream("message")
+	raw, err := s.llm.ChatStream(ctx, messages, func(delta string) error {
+		if text := message.Write(delta); text != "" {
+			return onDelta(text)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completeTurn(ctx, conversation, req, content, raw)
+}
+
+// turnMessages builds the LLM conversation for a learner turn from the stored
+// history, returning it together with the conversation and the learner content
+// that will be stored
+func (s *TutorService) turnMessages(ctx context.Context, conversationID int64, req *models.TutorTurnRequest) (*models.TutorConversation, []models.ChatMessage, string, error) {
+	conversation, err := s.repo.GetTutorConversation(ctx, conversationID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	history, err := s.repo.GetTutorMessages(ctx, conversationID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	content := req.Content
+	if req.Reveal {
+		content += "\n\n(The student asks you to reveal the answer.)"
+	}
+
+	messages := make([]models.ChatMessage, 0, len(history)+1)
+	for _, message := range history {
+		messages = append(messages, models.ChatMessage{Role: message.Role, Content: message.Content})
+	}
+	messages = append(messages, models.ChatMessage{Role: models.ChatRoleUser, Content: content})
+
+	return conversation, messages, content, nil
+}
+
+// completeTurn parses the tutor's raw reply, stores both sides of the turn and
+// builds the response, withholding the answer unless it may be revealed
+func (s *TutorService) completeTurn(ctx context.Context, conversation *models.TutorConversation, req *models.TutorTurnRequest, content, raw string) (*models.TutorTurnResponse, error) {
+	conversationID := conversation.ID
+
+	var reply tutorReply
+	if err := parseJSONObject(raw, &reply); err != nil {
+		// Fall back to treating the whole reply as guidance text
+		reply = tutorReply{Message: strings.TrimSpace(raw)}
+	}
+
+	if len(reply.Vocabulary) > 0 {
+		course, err := getCourse(ctx, s.repo, conversation.CourseID)
+		if err != nil {
+			return nil, err
+		}
+		reply.Vocabulary = courseVocabulary(reply.Vocabulary, course)
+	}
+
+	response := &models.TutorTurnResponse{
+		ConversationID: conversationID,
+		Message:        reply.Message,
+		Hints:          reply.Hints,
+		Vocabulary:     reply.Vocabulary,
+		Attempt:        reply.Attempt,
+		Score:          reply.Score,
+	}
+	if response.Hints == nil {
+		response.Hints = []string{}
+	}
+	if response.Vocabulary == nil {
+		response.Vocabulary = []models.TutorVocabularyEntry{}
+	}
+
+	// The learner turn is only stored once the tutor has replied, so failed
+	// LLM calls don't leave unanswered turns in the history
+	if _, err := s.repo.AddTutorMessage(ctx, conversationID, models.ChatRoleUser, content); err != nil {
+		return nil, err
+	}
+
+	stored := reply.Message
+	if reply.Answer != "" && (req.Reveal || reply.Solved || reply.Attempt >= maxTutorAttempts) {
+		answer := reply.Answer
+		response.Answer = &answer
+		stored += "\n\nAnswer: " + answer
+	}
+
+	if _, err := s.repo.AddTutorMessage(ctx, conversationID, models.ChatRoleAssistant, stored); err != nil {
+		return nil, err
+	}
+	if len(reply.Vocabulary) > 0 {
+		if err := s.repo.SaveTutorVocabulary(ctx, conversationID, reply.Vocabulary); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// courseVocabulary drops the vocabulary entries that are not written in the
+// course's languages, so they don't end up in the learner's table
+func courseVocabulary(entries []models.TutorVocabularyEntry, course *models.CourseResponse) []models.TutorVocabularyEntry {
+	valid := make([]models.TutorVocabularyEntry, 0, len(entries))
+	for _, entry := range entries {
+		if err := validateCourseText(course, entry.Term, entry.Translation); err != nil {
+			log.Warn().Err(err).Int64("course_id", course.ID).Msg("Skipping tutor vocabulary entry")
+			continue
+		}
+		valid = append(valid, entry)
+	}
+	return valid
+}
+
+// tutorText fills the course's language names and the number of attempts
+// into a persona text or the tutor rules
+func tutorText(text string, course *models.CourseResponse) string {
+	return strings.NewReplacer(
+		"{source}", course.SourceLanguage.Name,
+		"{target}", course.TargetLanguage.Name,
+		"{attempts}", strconv.Itoa(maxTutorAttempts),
+	).Replace(text)
+}
+
+// buildTutorPrompt assembles the system prompt for a persona teaching a
+// course, listing the preferred vocabulary from the chosen group
+func buildTutorPrompt(persona string, course *models.CourseResponse, preferred []models.WordResponse) string {
+	var b strings.Builder
+	b.WriteString("# ROLE\n")
+	b.WriteString(tutorText(tutorPersonas[persona].intro, course))
+	b.WriteString("\n")
+	b.WriteString(tutorText(tutorRules, course))
+
+	if len(preferred) > 0 {
+		b.WriteString("\n\n# PREFERRED VOCABULARY\n")
+		b.WriteString("Whenever possible, build sentences and hints around these words the student is studying:\n")
+		for _, word := range preferred {
+			b.WriteString(fmt.Sprintf("- %s (%s)\n", word.Term, word.Translation))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockChatCompleter is a mock implementation of the ChatCompleter interface
+type MockChatCompleter struct {
+	mock.Mock
+}
+
+func (m *MockChatCompleter) Chat(_ context.Context, messages []models.ChatMessage) (string, error) {
+	args := m.Called(messages)
+	return args.String(0), args.Error(1)
+}
+
+// ChatStream replays the mocked chunks through onDelta
+func (m *MockChatCompleter) ChatStream(ctx context.Context, messages []models.ChatMessage, onDelta func(string) error) (string, error) {
+	args := m.Called(ctx, messages)
+	chunks, _ := args.Get(0).([]string)
+	for _, chunk := range chunks {
+		if err := onDelta(chunk); err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(chunks, ""), args.Error(1)
+}
+
+func TestTutorService_CreateConversation(t *testing.T) {
+	t.Run("prefers group words", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockLLM := new(MockChatCompleter)
+		service := NewTutorService(mockRepo, mockLLM)
+		groupID := int64(2)
+
+		mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+		mockRepo.On("GetGroupByID", groupID).Return(&models.GroupDetailResponse{ID: groupID, Name: "Food"}, nil)
+		mockRepo.On("GetGroupWords", groupID, maxPreferredWords, 0).Return(&models.GroupWordsResponse{
+			Items: []models.WordResponse{
+				{ID: 7, Term: "mela", Translation: "apple", CourseID: models.DefaultCourseID},
+				{ID: 8, Term: "りんご", Translation: "apple", CourseID: japaneseCourse.ID},
+			},
+		}, nil)
+		mockRepo.On("CreateTutorConversation", models.DefaultCourseID, &groupID, "luca").Return(int64(1), nil)
+		mockRepo.On("AddTutorMessage", int64(1), "system", mock.MatchedBy(func(prompt string) bool {
+			return strings.Contains(prompt, "LUCA") && strings.Contains(prompt, "- mela (apple)") && !strings.Contains(prompt, "りんご")
+		})).Return(int64(1), nil)
+		mockRepo.On("AddTutorMessage", int64(1), "assistant", mock.Anything).Return(int64(2), nil)
+		mockRepo.On("GetTutorConversation", int64(1)).Return(&models.TutorConversation{ID: 1, GroupID: &groupID, Persona: "luca"}, nil)
+		mockRepo.On("GetTutorMessages", int64(1)).Return([]models.TutorMessage{
+			{ID: 1, Role: "system", Content: "prompt"},
+			{ID: 2, Role: "assistant", Content: "Ciao!"},
+		}, nil)
+		mockRepo.On("GetTutorVocabulary", int64(1)).Return([]models.TutorVocabularyEntry{}, nil)
+
+		conversation, err := service.CreateConversation(context.Background(), &models.CreateTutorConversationRequest{GroupID: &groupID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), conversation.ID)
+		assert.Len(t, conversation.Messages, 1)
+		assert.Equal(t, "assistant", conversation.Messages[0].Role)
+		mockRepo.AssertExpectations(t)
+		mockLLM.AssertNotCalled(t, "Chat", mock.Anything)
+	})
+
+	t.Run("speaks the course's languages", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockLLM := new(MockChatCompleter)
+		service := NewTutorService(mockRepo, mockLLM)
+
+		mockRepo.On("GetCourse", japaneseCourse.ID).Return(japaneseCourse, nil)
+		mockRepo.On("CreateTutorConversation", japaneseCourse.ID, (*int64)(nil), "maria").Return(int64(1), nil)
+		mockRepo.On("AddTutorMessage", int64(1), "system", mock.MatchedBy(func(prompt string) bool {
+			return strings.Contains(prompt, "an AI Japanese tutor") &&
+				strings.Contains(prompt, "- Communicate in English") &&
+				strings.Contains(prompt, "full Japanese translation") &&
+				!strings.Contains(prompt, "Italian")
+		})).Return(int64(1), nil)
+		mockRepo.On("AddTutorMessage", int64(1), "assistant", "Welcome, dear! I'm Maria. Send me a sentence in English and we'll build the Japanese together, one clue at a time. You have 3 attempts per sentence. Let's begin!").Return(int64(2), nil)
+		mockRepo.On("GetTutorConversation", int64(1)).Return(&models.TutorConversation{ID: 1, CourseID: japaneseCourse.ID, Persona: "maria"}, nil)
+		mockRepo.On("GetTutorMessages", int64(1)).Return([]models.TutorMessage{}, nil)
+		mockRepo.On("GetTutorVocabulary", int64(1)).Return([]models.TutorVocabularyEntry{}, nil)
+
+		conversation, err := service.CreateConversation(context.Background(), &models.CreateTutorConversationRequest{CourseID: japaneseCourse.ID, Persona: "maria"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, japaneseCourse.ID, conversation.CourseID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockLLM := new(MockChatCompleter)
+		service := NewTutorService(mockRepo, mockLLM)
+		groupID := int64(99)
+
+		mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+		mockRepo.On("GetGroupByID", groupID).Return(nil, models.NotFoundError("group"))
+
+		conversation, err := service.CreateConversation(context.Background(), &models.CreateTutorConversationRequest{GroupID: &groupID})
+
+		assert.ErrorIs(t, err, models.ErrNotFound)
+		assert.Nil(t, conversation)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestTutorService_PostTurn(t *testing.T) {
+	history := []models.TutorMessage{
+		{ID: 1, Role: "system", Content: "prompt"},
+		{ID: 2, Role: "assistant", Content: "Ciao!"},
+	}
+
+	t.Run("answer withheld on first attempt", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockLLM := new(MockChatCompleter)
+		service := NewTutorService(mockRepo, mockLLM)
+
+		vocabulary := []models.TutorVocabularyEntry{{Term: "mela", Translation: "apple", PartOfSpeech: "noun"}}
+
+		mockRepo.On("GetTutorConversation", int64(1)).Return(&models.TutorConversation{ID: 1, CourseID: models.DefaultCourseID, Persona: "luca"}, nil)
+		mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+		mockRepo.On("GetTutorMessages", int64(1)).Return(history, nil)
+		mockRepo.On("AddTutorMessage", int64(1), "user", "I eat an apple").Return(int64(3), nil)
+		mockLLM.On("Chat", mock.MatchedBy(func(messages []models.ChatMessage) bool {
+			return len(messages) == 3 && messages[0].Role == "system" && messages[2].Content == "I eat an apple"
+		})).Return("```json\n"+`{"message": "Think about the verb mangiare.", "hints": ["First person singular"], "vocabulary": [{"term": "mela", "translation": "apple", "part_of_speech": "noun"}, {"term": "りんご", "translation": "apple"}], "attempt": 1, "solved": false, "answer": "Mangio una mela."}`+"\n```", nil)
+		mockRepo.On("AddTutorMessage", int64(1), "assistant", "Think about the verb mangiare.").Return(int64(4), nil)
+		mockRepo.On("SaveTutorVocabulary", int64(1), vocabulary).Return(nil)
+
+		response, err := service.PostTurn(context.Background(), 1, &models.TutorTurnRequest{Content: "I eat an apple"})
+
+		assert.NoError(t, err)
+		assert.Nil(t, response.Answer)
+		assert.Equal(t, []string{"First person singular"}, response.Hints)
+		assert.Equal(t, vocabulary, response.Vocabulary)
+		mockRepo.AssertExpectations(t)
+		mockLLM.AssertExpectations(t)
+	})
+
+	t.Run("answer revealed after last attempt", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockLLM := new(MockChatCompleter)
+		service := NewTutorService(mockRepo, mockLLM)
+
+		mockRepo.On("GetTutorConversation", int64(1)).Return(&models.TutorConversation{ID: 1, Persona: "luca"}, nil)
+		mockRepo.On("GetTutorMessages", int64(1)).Return(history, nil)
+		mockRepo.On("AddTutorMessage", int64(1), "user", "Mangiare mela").Return(int64(3), nil)
+		mockLLM.On("Chat", mock.Anything).Return(`{"message": "Close!", "attempt": 3, "score": 6, "solved": false, "answer": "Mangio una mela."}`, nil)
+		mockRepo.On("AddTutorMessage", int64(1), "assistant", "Close!\n\nAnswer: Mangio una mela.").Return(int64(4), nil)
+
+		response, err := service.PostTurn(context.Background(), 1, &models.TutorTurnRequest{Content: "Mangiare mela"})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, response.Answer)
+		assert.Equal(t, "Mangio una mela.", *response.Answer)
+		assert.Equal(t, 6, *response.Score)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("llm error", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockLLM := new(MockChatCompleter)
+		service := NewTutorService(mockRepo, mockLLM)
+
+		mockRepo.On("GetTutorConversation", int64(1)).Return(&models.TutorConversation{ID: 1, Persona: "luca"}, nil)
+		mockRepo.On("GetTutorMessages", int64(1)).Return(history, nil)
+		mockLLM.On("Chat", mock.Anything).Return("", errors.New("GROQ_API_KEY environment variable not set"))
+
+		response, err := service.PostTurn(context.Background(), 1, &models.TutorTurnRequest{Content: "I eat an apple"})
+
+		assert.Error(t, err)
+		assert.Nil(t, response)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "AddTutorMessage", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("conversation not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockLLM := new(MockChatCompleter)
+		service := NewTutorService(mockRepo, mockLLM)
+
+		mockRepo.On("GetTutorConversation", int64(42)).Return(nil, models.NotFoundError("conversation"))
+
+		response, err := service.PostTurn(context.Background(), 42, &models.TutorTurnRequest{Content: "I eat an apple"})
+
+		assert.ErrorIs(t, err, models.ErrNotFound)
+		assert.Nil(t, response)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestTutorService_PostTurnStream(t *testing.T) {
+	history := []models.TutorMessage{
+		{ID: 1, Role: "system", Content: "prompt"},
+		{ID: 2, Role: "assistant", Content: "Ciao!"},
+	}
+
+	t.Run("streams only the message", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockLLM := new(MockChatCompleter)
+		service := NewTutorService(mockRepo, mockLLM)
+
+		chunks := []string{`{"mess`, `age": "Think about `, `\"mangiare\".", "hints": [], `, `"attempt": 1, "answer": "Mangio una mela."}`}
+
+		mockRepo.On("GetTutorConversation", int64(1)).Return(&models.TutorConversation{ID: 1, Persona: "luca"}, nil)
+		mockRepo.On("GetTutorMessages", int64(1)).Return(history, nil)
+		mockLLM.On("ChatStream", mock.Anything, mock.Anything).Return(chunks, nil)
+		mockRepo.On("AddTutorMessage", int64(1), "user", "I eat an apple").Return(int64(3), nil)
+		mockRepo.On("AddTutorMessage", int64(1), "assistant", `Think about "mangiare".`).Return(int64(4), nil)
+
+		var streamed []string
+		response, err := service.PostTurnStream(context.Background(), 1, &models.TutorTurnRequest{Content: "I eat an apple"}, func(delta string) error {
+			streamed = append(streamed, delta)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Think about ", `"mangiare".`}, streamed)
+		assert.Equal(t, `Think about "mangiare".`, response.Message)
+		assert.Nil(t, response.Answer)
+		mockRepo.AssertExpectations(t)
+		mockLLM.AssertExpectations(t)
+	})
+
+	t.Run("client disconnect stops the turn", func(t *testing.T) {
+		mockRepo := new(mocks.MockRepository)
+		mockLLM := new(MockChatCompleter)
+		service := NewTutorService(mockRepo, mockLLM)
+
+		mockRepo.On("GetTutorConversation", int64(1)).Return(&models.TutorConversation{ID: 1, Persona: "luca"}, nil)
+		mockRepo.On("GetTutorMessages", int64(1)).Return(history, nil)
+		mockLLM.On("ChatStream", mock.Anything, mock.Anything).Return([]string{`{"message": "Hi`}, nil)
+
+		response, err := service.PostTurnStream(context.Background(), 1, &models.TutorTurnRequest{Content: "Hello"}, func(delta string) error {
+			return context.Canceled
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, response)
+		mockRepo.AssertNotCalled(t, "AddTutorMessage", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
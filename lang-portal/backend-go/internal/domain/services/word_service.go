@@ -1,7 +1,8 @@
 package services
 
 import (
-	"fmt"
+	"context"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
@@ -10,9 +11,13 @@ import (
 )
 
 type WordServiceInterface interface {
-	GetWords(limit, offset int) (*models.WordListResponse, error)
-	GetWordByID(id int64) (*models.WordResponse, error)
-	ImportWords(groupID int64, words []models.WordResponse) (*models.ImportWordsResponse, error)
+	GetWords(ctx context.Context, courseID int64, params models.ListParams) (*models.WordListResponse, error)
+	GetWordByID(ctx context.Context, id int64) (*models.WordResponse, error)
+	ImportWords(ctx context.Context, req *models.ImportWordsRequest) (*models.ImportWordsResponse, error)
+	ImportWordsWithProgress(ctx context.Context, req *models.ImportWordsRequest, progress func(done, total int)) (*models.ImportWordsResponse, error)
+	GetWordSentences(ctx context.Context, wordID int64, limit, offset int) (*models.WordSentencesResponse, error)
+	AddWordSentence(ctx context.Context, wordID int64, req *models.CreateSentenceRequest) (*models.SentenceResponse, error)
+	GetWordReviewStats(ctx context.Context, wordID int64) (*models.WordReviewStatsResponse, error)
 }
 
 type WordService struct {
@@ -23,41 +28,138 @@ func NewWordService(repo repository.Repository) *WordService {
 	return &WordService{repo: repo}
 }
 
-func (s *WordService) GetWords(limit, offset int) (*models.WordListResponse, error) {
-	return s.repo.GetWords(limit, offset)
+// GetWords lists words, only those of one course when courseID is not 0
+func (s *WordService) GetWords(ctx context.Context, courseID int64, params models.ListParams) (*models.WordListResponse, error) {
+	return s.repo.GetWords(ctx, courseID, params)
 }
 
-func (s *WordService) GetWordByID(id int64) (*models.WordResponse, error) {
-	return s.repo.GetWordByID(id)
+func (s *WordService) GetWordByID(ctx context.Context, id int64) (*models.WordResponse, error) {
+	return s.repo.GetWordByID(ctx, id)
 }
 
-func (s *WordService) ImportWords(groupID int64, words []models.WordResponse) (*models.ImportWordsResponse, error) {
+// ImportWords imports words into a group and the request's course, skipping
+// words that are not written in the course's languages
+func (s *WordService) ImportWords(ctx context.Context, req *models.ImportWordsRequest) (*models.ImportWordsResponse, error) {
+	return s.ImportWordsWithProgress(ctx, req, nil)
+}
+
+// ImportWordsWithProgress imports words into a group like ImportWords, calling
+// progress after each word and stopping early when ctx is cancelled. Words
+// the course already has are reused and added to the group at most once, so
+// importing the same words again, as a resumed job does, duplicates nothing.
+func (s *WordService) ImportWordsWithProgress(ctx context.Context, req *models.ImportWordsRequest, progress func(done, total int)) (*models.ImportWordsResponse, error) {
+	groupID, words := req.GroupID, req.Words
+
 	// Verify group exists
-	_, err := s.repo.GetGroupByID(groupID)
+	if _, err := s.repo.GetGroupByID(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	course, err := getCourse(ctx, s.repo, req.CourseID)
 	if err != nil {
-		return nil, fmt.Errorf("group not found: %v", err)
+		return nil, err
 	}
 
-	importedCount := 0
-	for _, word := range words {
-		// Create the word
-		wordID, err := s.repo.CreateWord(&word)
+	response := &models.ImportWordsResponse{}
+	for i, word := range words {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if progress != nil && i > 0 {
+			progress(i, len(words))
+		}
+
+		if err := validateCourseText(course, word.Term, word.Translation); err != nil {
+			log.Warn().Err(err).Int64("course_id", course.ID).Msg("Skipping imported word")
+			response.SkippedCount++
+			continue
+		}
+		word.CourseID = course.ID
+
+		wordID, err := s.repo.FindOrCreateWord(ctx, &word)
 		if err != nil {
+			log.Error().Err(err).Str("term", word.Term).Msg("Failed to store imported word")
+			response.FailedCount++
 			continue
 		}
 
 		// Associate word with group
-		if err := s.repo.AddWordToGroup(wordID, groupID); err != nil {
+		if err := s.repo.AddWordToGroup(ctx, wordID, groupID); err != nil {
+			log.Error().Err(err).Int64("word_id", wordID).Int64("group_id", groupID).Msg("Failed to add imported word to group")
+			response.FailedCount++
 			continue
 		}
 
-		importedCount++
+		response.ImportedCount++
 	}
 
 	// Update group words count
-	if err := s.repo.UpdateGroupWordsCount(groupID); err != nil {
+	if err := s.repo.UpdateGroupWordsCount(ctx, groupID); err != nil {
 		log.Error().Err(err).Msg("Failed to update group words count")
 	}
 
-	return &models.ImportWordsResponse{ImportedCount: importedCount}, nil
+	return response, nil
+}
+
+// GetWordSentences returns a paginated list of example sentences linked to a word
+func (s *WordService) GetWordSentences(ctx context.Context, wordID int64, limit, offset int) (*models.WordSentencesResponse, error) {
+	if _, err := s.repo.GetWordByID(ctx, wordID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetWordSentences(ctx, wordID, limit, offset)
+}
+
+// AddWordSentence stores a new example sentence and links it to the word
+func (s *WordService) AddWordSentence(ctx context.Context, wordID int64, req *models.CreateSentenceRequest) (*models.SentenceResponse, error) {
+	word, err := s.repo.GetWordByID(ctx, wordID)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := getCourse(ctx, s.repo, word.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCourseText(course, req.Text, req.Translation); err != nil {
+		return nil, models.ValidationError("invalid_sentence", "invalid sentence: "+err.Error())
+	}
+
+	sentence := &models.SentenceResponse{
+		Text:        req.Text,
+		Translation: req.Translation,
+		Source:      req.Source,
+	}
+	if sentence.Source == "" {
+		sentence.Source = models.SentenceSourceManual
+	}
+
+	id, err := s.repo.CreateSentence(ctx, sentence)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddSentenceToWord(ctx, id, wordID); err != nil {
+		return nil, err
+	}
+
+	sentence.ID = id
+	sentence.CreatedAt = time.Now()
+	return sentence, nil
+}
+
+// GetWordReviewStats returns review accuracy for a word split by review mode
+func (s *WordService) GetWordReviewStats(ctx context.Context, wordID int64) (*models.WordReviewStatsResponse, error) {
+	if _, err := s.repo.GetWordByID(ctx, wordID); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.GetWordReviewStats(ctx, wordID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WordReviewStatsResponse{
+		WordID: wordID,
+		Modes:  stats,
+	}, nil
 }
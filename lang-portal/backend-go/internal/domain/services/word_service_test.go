@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
+	"github.com/jeevanions/lang-portal/backend-go/internal/mocks"
+)
+
+func TestWordService_ImportWords(t *testing.T) {
+	isTerm := func(term string) interface{} {
+		return mock.MatchedBy(func(w *models.WordResponse) bool { return w.Term == term })
+	}
+
+	mockRepo := new(mocks.MockRepository)
+	mockRepo.On("GetGroupByID", int64(3)).Return(&models.GroupDetailResponse{ID: 3}, nil)
+	mockRepo.On("GetCourse", models.DefaultCourseID).Return(italianCourse, nil)
+	mockRepo.On("FindOrCreateWord", isTerm("madre")).Return(int64(17), nil)
+	mockRepo.On("FindOrCreateWord", isTerm("padre")).Return(int64(0), errors.New("database is locked"))
+	mockRepo.On("FindOrCreateWord", isTerm("figlio")).Return(int64(18), nil)
+	mockRepo.On("AddWordToGroup", int64(17), int64(3)).Return(nil)
+	mockRepo.On("AddWordToGroup", int64(18), int64(3)).Return(errors.New("database is locked"))
+	mockRepo.On("UpdateGroupWordsCount", int64(3)).Return(nil)
+
+	response, err := NewWordService(mockRepo).ImportWords(context.Background(), &models.ImportWordsRequest{
+		GroupID: 3,
+		Words: []models.WordResponse{
+			{Term: "madre", Translation: "mother"},
+			{Term: "padre", Translation: "father"},
+			{Term: "figlio", Translation: "son"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &models.ImportWordsResponse{ImportedCount: 1, FailedCount: 2}, response)
+	mockRepo.AssertExpectations(t)
+}
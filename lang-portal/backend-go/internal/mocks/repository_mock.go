@@ -1,19 +1,23 @@
 package mocks
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
 	"github.com/jeevanions/lang-portal/backend-go/internal/domain/models"
 	"github.com/stretchr/testify/mock"
 )
 
-// MockRepository implements the repository.Repository interface for testing
+// MockRepository implements the repository.Repository interface for testing.
+// Contexts are not recorded, so expectations are set up without them.
 type MockRepository struct {
 	mock.Mock
 }
 
 // Dashboard operations
-func (m *MockRepository) GetLastStudySession() (*models.DashboardLastStudySession, error) {
+func (m *MockRepository) GetLastStudySession(_ context.Context) (*models.DashboardLastStudySession, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -21,7 +25,7 @@ func (m *MockRepository) GetLastStudySession() (*models.DashboardLastStudySessio
 	return args.Get(0).(*models.DashboardLastStudySession), args.Error(1)
 }
 
-func (m *MockRepository) GetStudyProgress() (*models.DashboardStudyProgress, error) {
+func (m *MockRepository) GetStudyProgress(_ context.Context) (*models.DashboardStudyProgress, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -29,7 +33,7 @@ func (m *MockRepository) GetStudyProgress() (*models.DashboardStudyProgress, err
 	return args.Get(0).(*models.DashboardStudyProgress), args.Error(1)
 }
 
-func (m *MockRepository) GetQuickStats() (*models.DashboardQuickStats, error) {
+func (m *MockRepository) GetQuickStats(_ context.Context) (*models.DashboardQuickStats, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -38,7 +42,7 @@ func (m *MockRepository) GetQuickStats() (*models.DashboardQuickStats, error) {
 }
 
 // Study activities
-func (m *MockRepository) GetStudyActivities(limit, offset int) (*models.StudyActivityListResponse, error) {
+func (m *MockRepository) GetStudyActivities(_ context.Context, limit, offset int) (*models.StudyActivityListResponse, error) {
 	args := m.Called(limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -46,7 +50,7 @@ func (m *MockRepository) GetStudyActivities(limit, offset int) (*models.StudyAct
 	return args.Get(0).(*models.StudyActivityListResponse), args.Error(1)
 }
 
-func (m *MockRepository) GetStudyActivity(id int64) (*models.StudyActivityResponse, error) {
+func (m *MockRepository) GetStudyActivity(_ context.Context, id int64) (*models.StudyActivityResponse, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -55,20 +59,20 @@ func (m *MockRepository) GetStudyActivity(id int64) (*models.StudyActivityRespon
 }
 
 // Study sessions
-func (m *MockRepository) GetAllStudySessions(limit, offset int) ([]models.StudySession, error) {
-	args := m.Called(limit, offset)
+func (m *MockRepository) GetAllStudySessions(_ context.Context, params models.ListParams) ([]models.StudySessionDetailResponse, error) {
+	args := m.Called(params)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.StudySession), args.Error(1)
+	return args.Get(0).([]models.StudySessionDetailResponse), args.Error(1)
 }
 
-func (m *MockRepository) GetTotalStudySessions() (int, error) {
+func (m *MockRepository) GetTotalStudySessions(_ context.Context) (int, error) {
 	args := m.Called()
 	return args.Get(0).(int), args.Error(1)
 }
 
-func (m *MockRepository) GetStudySessionWords(sessionID int64, limit, offset int) ([]*models.WordResponse, int, error) {
+func (m *MockRepository) GetStudySessionWords(_ context.Context, sessionID int64, limit, offset int) ([]*models.WordResponse, int, error) {
 	args := m.Called(sessionID, limit, offset)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
@@ -76,7 +80,7 @@ func (m *MockRepository) GetStudySessionWords(sessionID int64, limit, offset int
 	return args.Get(0).([]*models.WordResponse), args.Get(1).(int), args.Error(2)
 }
 
-func (m *MockRepository) GetStudyActivitySessions(activityID int64, limit, offset int) ([]models.StudySession, error) {
+func (m *MockRepository) GetStudyActivitySessions(_ context.Context, activityID int64, limit, offset int) ([]models.StudySession, error) {
 	args := m.Called(activityID, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -84,7 +88,7 @@ func (m *MockRepository) GetStudyActivitySessions(activityID int64, limit, offse
 	return args.Get(0).([]models.StudySession), args.Error(1)
 }
 
-func (m *MockRepository) CreateStudyActivitySession(activityID, groupID int64) (*models.LaunchStudyActivityResponse, error) {
+func (m *MockRepository) CreateStudyActivitySession(_ context.Context, activityID, groupID int64) (*models.LaunchStudyActivityResponse, error) {
 	args := m.Called(activityID, groupID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -92,7 +96,7 @@ func (m *MockRepository) CreateStudyActivitySession(activityID, groupID int64) (
 	return args.Get(0).(*models.LaunchStudyActivityResponse), args.Error(1)
 }
 
-func (m *MockRepository) GetWordReviewsBySessionID(sessionID int64) ([]models.WordReviewItem, error) {
+func (m *MockRepository) GetWordReviewsBySessionID(_ context.Context, sessionID int64) ([]models.WordReviewItem, error) {
 	args := m.Called(sessionID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -100,13 +104,103 @@ func (m *MockRepository) GetWordReviewsBySessionID(sessionID int64) ([]models.Wo
 	return args.Get(0).([]models.WordReviewItem), args.Error(1)
 }
 
+func (m *MockRepository) GetWordReviewsBySessionIDs(_ context.Context, sessionIDs []int64) (map[int64][]models.WordReviewItem, error) {
+	args := m.Called(sessionIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64][]models.WordReviewItem), args.Error(1)
+}
+
+func (m *MockRepository) GetTableVersion(_ context.Context, tables ...string) (int64, error) {
+	args := m.Called(tables)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) GetGroupVersion(_ context.Context, id int64) (int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) UpdateGroup(_ context.Context, id int64, name string, ifVersion int64) (int64, error) {
+	args := m.Called(id, name, ifVersion)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) GetContentPacks(_ context.Context) ([]models.ContentPackResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ContentPackResponse), args.Error(1)
+}
+
+func (m *MockRepository) DeleteContentPack(_ context.Context, name string) (*models.UninstallPackResponse, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UninstallPackResponse), args.Error(1)
+}
+
+func (m *MockRepository) GetChangeLogState(_ context.Context) (string, int64, error) {
+	args := m.Called()
+	return args.String(0), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRepository) GetChanges(_ context.Context, since int64, limit int) ([]models.SyncChange, error) {
+	args := m.Called(since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SyncChange), args.Error(1)
+}
+
+func (m *MockRepository) ApplySyncPush(_ context.Context, sessions []models.PushedSession, reviews []models.PushedReview) (*models.SyncPushResponse, error) {
+	args := m.Called(sessions, reviews)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SyncPushResponse), args.Error(1)
+}
+
+// Learner data export and erasure
+func (m *MockRepository) CreateLearner(_ context.Context, learner *models.Learner) error {
+	args := m.Called(learner)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetLearner(_ context.Context) (*models.Learner, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Learner), args.Error(1)
+}
+
+func (m *MockRepository) GetLearnerData(_ context.Context) (*models.LearnerData, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LearnerData), args.Error(1)
+}
+
+func (m *MockRepository) EraseLearnerData(_ context.Context) (*models.EraseLearnerDataResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EraseLearnerDataResponse), args.Error(1)
+}
+
 // Transaction operations
-func (m *MockRepository) BeginTx() (*sql.Tx, error) {
+func (m *MockRepository) BeginTx(_ context.Context) (*repository.Tx, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*sql.Tx), args.Error(1)
+	return args.Get(0).(*repository.Tx), args.Error(1)
 }
 
 func (m *MockRepository) CommitTx(tx *sql.Tx) error {
@@ -118,15 +212,65 @@ func (m *MockRepository) RollbackTx(tx *sql.Tx) error {
 }
 
 // Word operations
-func (m *MockRepository) GetWords(limit, offset int) (*models.WordListResponse, error) {
-	args := m.Called(limit, offset)
+func (m *MockRepository) GetLanguages(_ context.Context) ([]models.LanguageResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LanguageResponse), args.Error(1)
+}
+
+func (m *MockRepository) GetLanguage(_ context.Context, code string) (*models.LanguageResponse, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LanguageResponse), args.Error(1)
+}
+
+func (m *MockRepository) CreateLanguage(_ context.Context, language *models.LanguageResponse) error {
+	args := m.Called(language)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetCourses(_ context.Context) ([]models.CourseResponse, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CourseResponse), args.Error(1)
+}
+
+func (m *MockRepository) GetCourse(_ context.Context, id int64) (*models.CourseResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseResponse), args.Error(1)
+}
+
+func (m *MockRepository) GetCourseByLanguages(_ context.Context, source, target string) (*models.CourseResponse, error) {
+	args := m.Called(source, target)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseResponse), args.Error(1)
+}
+
+func (m *MockRepository) CreateCourse(_ context.Context, name, source, target string) (int64, error) {
+	args := m.Called(name, source, target)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) GetWords(_ context.Context, courseID int64, params models.ListParams) (*models.WordListResponse, error) {
+	args := m.Called(courseID, params)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.WordListResponse), args.Error(1)
 }
 
-func (m *MockRepository) GetWordByID(id int64) (*models.WordResponse, error) {
+func (m *MockRepository) GetWordByID(_ context.Context, id int64) (*models.WordResponse, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -134,30 +278,69 @@ func (m *MockRepository) GetWordByID(id int64) (*models.WordResponse, error) {
 	return args.Get(0).(*models.WordResponse), args.Error(1)
 }
 
-func (m *MockRepository) CreateWord(word *models.WordResponse) (int64, error) {
+func (m *MockRepository) CreateWord(_ context.Context, word *models.WordResponse) (int64, error) {
 	args := m.Called(word)
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockRepository) CreateWordReview(sessionID, wordID int64, correct bool) error {
-	return m.Called(sessionID, wordID, correct).Error(0)
+func (m *MockRepository) FindOrCreateWord(_ context.Context, word *models.WordResponse) (int64, error) {
+	args := m.Called(word)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Sentence operations
+func (m *MockRepository) GetWordSentences(_ context.Context, wordID int64, limit, offset int) (*models.WordSentencesResponse, error) {
+	args := m.Called(wordID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WordSentencesResponse), args.Error(1)
+}
+
+func (m *MockRepository) CreateSentence(_ context.Context, sentence *models.SentenceResponse) (int64, error) {
+	args := m.Called(sentence)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) AddSentenceToWord(_ context.Context, sentenceID, wordID int64) error {
+	return m.Called(sentenceID, wordID).Error(0)
+}
+
+func (m *MockRepository) GetWordSentence(_ context.Context, wordID, sentenceID int64) (*models.SentenceResponse, error) {
+	args := m.Called(wordID, sentenceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SentenceResponse), args.Error(1)
+}
+
+func (m *MockRepository) CreateWordReview(_ context.Context, sessionID, wordID int64, correct bool, mode string) error {
+	return m.Called(sessionID, wordID, correct, mode).Error(0)
+}
+
+func (m *MockRepository) GetWordReviewStats(_ context.Context, wordID int64) ([]models.ReviewModeStats, error) {
+	args := m.Called(wordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ReviewModeStats), args.Error(1)
 }
 
 // Group operations
-func (m *MockRepository) CreateGroup(name string) (int64, error) {
+func (m *MockRepository) CreateGroup(_ context.Context, name string) (int64, error) {
 	args := m.Called(name)
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockRepository) GetGroups(limit, offset int) (*models.GroupListResponse, error) {
-	args := m.Called(limit, offset)
+func (m *MockRepository) GetGroups(_ context.Context, params models.ListParams) (*models.GroupListResponse, error) {
+	args := m.Called(params)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.GroupListResponse), args.Error(1)
 }
 
-func (m *MockRepository) GetGroupByID(id int64) (*models.GroupDetailResponse, error) {
+func (m *MockRepository) GetGroupByID(_ context.Context, id int64) (*models.GroupDetailResponse, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -165,12 +348,12 @@ func (m *MockRepository) GetGroupByID(id int64) (*models.GroupDetailResponse, er
 	return args.Get(0).(*models.GroupDetailResponse), args.Error(1)
 }
 
-func (m *MockRepository) GetGroupIDByName(name string) (int64, error) {
+func (m *MockRepository) GetGroupIDByName(_ context.Context, name string) (int64, error) {
 	args := m.Called(name)
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockRepository) GetGroupWords(groupID int64, limit, offset int) (*models.GroupWordsResponse, error) {
+func (m *MockRepository) GetGroupWords(_ context.Context, groupID int64, limit, offset int) (*models.GroupWordsResponse, error) {
 	args := m.Called(groupID, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -178,7 +361,7 @@ func (m *MockRepository) GetGroupWords(groupID int64, limit, offset int) (*model
 	return args.Get(0).(*models.GroupWordsResponse), args.Error(1)
 }
 
-func (m *MockRepository) GetGroupStudySessions(groupID int64, limit, offset int) (*models.GroupStudySessionsResponse, error) {
+func (m *MockRepository) GetGroupStudySessions(_ context.Context, groupID int64, limit, offset int) (*models.GroupStudySessionsResponse, error) {
 	args := m.Called(groupID, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -186,17 +369,205 @@ func (m *MockRepository) GetGroupStudySessions(groupID int64, limit, offset int)
 	return args.Get(0).(*models.GroupStudySessionsResponse), args.Error(1)
 }
 
-func (m *MockRepository) UpdateGroupWordsCount(groupID int64) error {
+func (m *MockRepository) UpdateGroupWordsCount(_ context.Context, groupID int64) error {
 	return m.Called(groupID).Error(0)
 }
 
 // Other operations
-func (m *MockRepository) AddWordToGroup(wordID, groupID int64) error {
+func (m *MockRepository) AddWordToGroup(_ context.Context, wordID, groupID int64) error {
 	return m.Called(wordID, groupID).Error(0)
 }
 
+// Tutor operations
+func (m *MockRepository) CreateTutorConversation(_ context.Context, courseID int64, groupID *int64, persona string) (int64, error) {
+	args := m.Called(courseID, groupID, persona)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) GetTutorConversation(_ context.Context, id int64) (*models.TutorConversation, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TutorConversation), args.Error(1)
+}
+
+func (m *MockRepository) AddTutorMessage(_ context.Context, conversationID int64, role, content string) (int64, error) {
+	args := m.Called(conversationID, role, content)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) GetTutorMessages(_ context.Context, conversationID int64) ([]models.TutorMessage, error) {
+	args := m.Called(conversationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TutorMessage), args.Error(1)
+}
+
+func (m *MockRepository) SaveTutorVocabulary(_ context.Context, conversationID int64, entries []models.TutorVocabularyEntry) error {
+	return m.Called(conversationID, entries).Error(0)
+}
+
+func (m *MockRepository) GetTutorVocabulary(_ context.Context, conversationID int64) ([]models.TutorVocabularyEntry, error) {
+	args := m.Called(conversationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TutorVocabularyEntry), args.Error(1)
+}
+
+// LLM completion cache
+func (m *MockRepository) GetLLMCacheEntry(_ context.Context, key string) (*models.LLMCacheEntry, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LLMCacheEntry), args.Error(1)
+}
+
+func (m *MockRepository) SaveLLMCacheEntry(_ context.Context, entry *models.LLMCacheEntry, ttl time.Duration) error {
+	args := m.Called(entry, ttl)
+	return args.Error(0)
+}
+
+func (m *MockRepository) RecordLLMCacheHit(_ context.Context, key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetLLMCacheStats(_ context.Context) (int, int64, error) {
+	args := m.Called()
+	return args.Int(0), args.Get(1).(int64), args.Error(2)
+}
+
+// LLM usage ledger
+func (m *MockRepository) RecordLLMUsage(_ context.Context, record *models.LLMUsageRecord) error {
+	args := m.Called(record)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetLLMUsageSummary(_ context.Context, groupBy string, days int) ([]models.LLMUsageSummaryItem, error) {
+	args := m.Called(groupBy, days)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LLMUsageSummaryItem), args.Error(1)
+}
+
+func (m *MockRepository) GetLLMMonthlyUsage(_ context.Context) (float64, int64, error) {
+	args := m.Called()
+	return args.Get(0).(float64), args.Get(1).(int64), args.Error(2)
+}
+
+// Background jobs
+func (m *MockRepository) CreateJob(_ context.Context, jobType string, payload []byte) (int64, error) {
+	args := m.Called(jobType, payload)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) GetJob(_ context.Context, id int64) (*models.Job, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Job), args.Error(1)
+}
+
+func (m *MockRepository) GetJobs(_ context.Context, status string, params models.ListParams) (*models.JobListResponse, error) {
+	args := m.Called(status, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.JobListResponse), args.Error(1)
+}
+
+func (m *MockRepository) ClaimNextJob(_ context.Context) (*models.Job, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Job), args.Error(1)
+}
+
+func (m *MockRepository) UpdateJobProgress(_ context.Context, id int64, progress int) error {
+	args := m.Called(id, progress)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CompleteJob(_ context.Context, id int64, result []byte) error {
+	args := m.Called(id, result)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FailJob(_ context.Context, id int64, message string) error {
+	args := m.Called(id, message)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CancelJob(_ context.Context, id int64) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) RequeueRunningJobs(_ context.Context) (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Listening exercises
+func (m *MockRepository) CreateListeningExercise(_ context.Context, exercise *models.ListeningExercise, contentHash string, segments []models.ListeningSegment, questions []models.ListeningQuestion) (int64, bool, error) {
+	args := m.Called(exercise, contentHash, segments, questions)
+	return args.Get(0).(int64), args.Bool(1), args.Error(2)
+}
+
+func (m *MockRepository) GetListeningExercises(_ context.Context, courseID int64, limit, offset int) (*models.ListeningExerciseListResponse, error) {
+	args := m.Called(courseID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ListeningExerciseListResponse), args.Error(1)
+}
+
+func (m *MockRepository) GetListeningExercise(_ context.Context, id int64) (*models.ListeningExercise, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ListeningExercise), args.Error(1)
+}
+
+func (m *MockRepository) GetListeningSegments(_ context.Context, exerciseID int64) ([]models.ListeningSegment, error) {
+	args := m.Called(exerciseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ListeningSegment), args.Error(1)
+}
+
+func (m *MockRepository) GetListeningQuestions(_ context.Context, exerciseID int64) ([]models.ListeningQuestion, error) {
+	args := m.Called(exerciseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ListeningQuestion), args.Error(1)
+}
+
+func (m *MockRepository) GetListeningQuestion(_ context.Context, id int64) (*models.ListeningQuestion, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ListeningQuestion), args.Error(1)
+}
+
+func (m *MockRepository) CreateListeningAnswer(_ context.Context, questionID, sessionID int64, selectedOption int, correct bool) error {
+	args := m.Called(questionID, sessionID, selectedOption, correct)
+	return args.Error(0)
+}
+
 // Settings/Reset operations
-func (m *MockRepository) ResetHistory() error {
+func (m *MockRepository) ResetHistory(_ context.Context) error {
 	return m.Called().Error(0)
 }
 
@@ -204,10 +575,10 @@ func (m *MockRepository) Close() error {
 	return m.Called().Error(0)
 }
 
-func (m *MockRepository) DropAllTables() error {
+func (m *MockRepository) DropAllTables(_ context.Context) error {
 	return m.Called().Error(0)
 }
 
-func (m *MockRepository) CreateTables() error {
+func (m *MockRepository) CreateTables(_ context.Context) error {
 	return m.Called().Error(0)
 }
@@ -0,0 +1,234 @@
+// Package prompts loads the versioned text/template prompts sent to the LLM.
+//
+// Templates are named <name>.<version>.tmpl, for example
+// generate_words.v2.tmpl. Old versions stay, so whatever a version produced
+// can be traced back to it and the prompt rendered again. The templates
+// embedded in the binary can be replaced, or new versions added, by placing
+// files with the same naming scheme in an override directory.
+package prompts
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var embedded embed.FS
+
+// ErrNotFound is returned for template names and versions that do not exist
+var ErrNotFound = errors.New("not found")
+
+// Template names used by the services
+const (
+	GenerateWords     = "generate_words"
+	GenerateSentences = "generate_sentences"
+	VocabularyTeacher = "vocabulary_teacher"
+)
+
+// Where a template was loaded from
+const (
+	SourceEmbedded = "embedded"
+	SourceOverride = "override"
+)
+
+// Vars are the variables available to every template. SourceLanguage and
+// TargetLanguage are language names, e.g. English and Italian for a course
+// teaching Italian to English speakers. English is the translation as the v1
+// templates, written before courses, call it; Render fills it in from
+// Translation.
+type Vars struct {
+	SourceLanguage string `json:"source_language,omitempty"`
+	TargetLanguage string `json:"target_language,omitempty"`
+	Category       string `json:"category,omitempty"`
+	Count          int    `json:"count,omitempty"`
+	Level          string `json:"level,omitempty"`
+	PartOfSpeech   string `json:"part_of_speech,omitempty"`
+	Word           string `json:"word,omitempty"`
+	Translation    string `json:"translation,omitempty"`
+	English        string `json:"-"`
+}
+
+// Info describes a loaded template version
+type Info struct {
+	Name    string
+	Version string
+	Source  string
+	Active  bool
+	Text    string
+}
+
+type version struct {
+	name     string
+	version  string
+	number   int
+	source   string
+	text     string
+	template *template.Template
+}
+
+// Store holds every loaded template version and which version of each
+// template is active
+type Store struct {
+	versions map[string]map[string]*version
+	active   map[string]string
+}
+
+// Load reads the embedded templates, then the templates in dir when it is not
+// empty. active pins template names to versions (e.g. generate_words=v1);
+// unpinned templates use their highest version.
+func Load(dir string, active map[string]string) (*Store, error) {
+	s := &Store{
+		versions: make(map[string]map[string]*version),
+		active:   make(map[string]string),
+	}
+
+	if err := s.addFS(embedded, "templates", SourceEmbedded); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := s.addFS(os.DirFS(dir), ".", SourceOverride); err != nil {
+			return nil, fmt.Errorf("failed to load prompt overrides from %s: %v", dir, err)
+		}
+	}
+
+	for name, versions := range s.versions {
+		latest := ""
+		for v, tmpl := range versions {
+			if latest == "" || tmpl.number > versions[latest].number {
+				latest = v
+			}
+		}
+		s.active[name] = latest
+	}
+	for name, v := range active {
+		if _, ok := s.versions[name][v]; !ok {
+			return nil, fmt.Errorf("prompt %s has no version %s", name, v)
+		}
+		s.active[name] = v
+	}
+
+	return s, nil
+}
+
+// ParseActive parses a pin list such as "generate_words=v1,generate_sentences=v2"
+func ParseActive(value string) (map[string]string, error) {
+	active := make(map[string]string)
+	for _, pin := range strings.Split(value, ",") {
+		pin = strings.TrimSpace(pin)
+		if pin == "" {
+			continue
+		}
+		name, v, ok := strings.Cut(pin, "=")
+		if !ok || name == "" || v == "" {
+			return nil, fmt.Errorf("invalid prompt version pin %q", pin)
+		}
+		active[strings.TrimSpace(name)] = strings.TrimSpace(v)
+	}
+	return active, nil
+}
+
+func (s *Store) addFS(fsys fs.FS, dir, source string) error {
+	paths, err := fs.Glob(fsys, path.Join(dir, "*.tmpl"))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range paths {
+		name, v, number, err := parseFileName(filepath.Base(file))
+		if err != nil {
+			return err
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		text := strings.TrimSpace(string(content))
+
+		tmpl, err := template.New(name + "." + v).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return fmt.Errorf("failed to parse prompt %s: %v", filepath.Base(file), err)
+		}
+
+		if s.versions[name] == nil {
+			s.versions[name] = make(map[string]*version)
+		}
+		s.versions[name][v] = &version{
+			name:     name,
+			version:  v,
+			number:   number,
+			source:   source,
+			text:     text,
+			template: tmpl,
+		}
+	}
+	return nil
+}
+
+// parseFileName splits "generate_words.v2.tmpl" into its name and version
+func parseFileName(file string) (name, v string, number int, err error) {
+	parts := strings.Split(strings.TrimSuffix(file, ".tmpl"), ".")
+	if len(parts) != 2 || !strings.HasPrefix(parts[1], "v") {
+		return "", "", 0, fmt.Errorf("prompt file %s must be named <name>.v<number>.tmpl", file)
+	}
+
+	number, err = strconv.Atoi(strings.TrimPrefix(parts[1], "v"))
+	if err != nil {
+		return "", "", 0, fmt.Errorf("prompt file %s must be named <name>.v<number>.tmpl", file)
+	}
+	return parts[0], parts[1], number, nil
+}
+
+// Render executes a template version with vars. An empty version renders the
+// active one. It returns the text together with the version identifier
+// (name@version) that should be recorded with whatever the prompt produces.
+func (s *Store) Render(name, v string, vars Vars) (text, id string, err error) {
+	if v == "" {
+		v = s.active[name]
+	}
+
+	tmpl, ok := s.versions[name][v]
+	if !ok {
+		return "", "", fmt.Errorf("prompt %s@%s %w", name, v, ErrNotFound)
+	}
+
+	vars.English = vars.Translation
+	var b strings.Builder
+	if err := tmpl.template.Execute(&b, vars); err != nil {
+		return "", "", fmt.Errorf("failed to render prompt %s@%s: %v", name, v, err)
+	}
+	return b.String(), name + "@" + v, nil
+}
+
+// List returns every loaded template version, sorted by name and version
+func (s *Store) List() []Info {
+	var infos []Info
+	for name, versions := range s.versions {
+		for v, tmpl := range versions {
+			infos = append(infos, Info{
+				Name:    name,
+				Version: v,
+				Source:  tmpl.source,
+				Active:  s.active[name] == v,
+				Text:    tmpl.text,
+			})
+		}
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Name != infos[j].Name {
+			return infos[i].Name < infos[j].Name
+		}
+		return s.versions[infos[i].Name][infos[i].Version].number < s.versions[infos[j].Name][infos[j].Version].number
+	})
+	return infos
+}
@@ -0,0 +1,108 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Embedded(t *testing.T) {
+	store, err := Load("", nil)
+	require.NoError(t, err)
+
+	text, id, err := store.Render(GenerateWords, "", Vars{SourceLanguage: "English", TargetLanguage: "Japanese", Category: "food", Count: 5, Level: "A2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "generate_words@v2", id)
+	assert.Contains(t, text, "Generate 5 Japanese words for the thematic category: food.")
+	assert.Contains(t, text, "Accurate English translation")
+	assert.Contains(t, text, "CEFR A2")
+	assert.NotContains(t, text, "part of speech is")
+
+	for _, info := range store.List() {
+		assert.Equal(t, SourceEmbedded, info.Source)
+		assert.Equal(t, info.Version == "v2", info.Active)
+	}
+
+	// Words generated before courses were recorded with the v1 templates
+	text, id, err = store.Render(GenerateSentences, "v1", Vars{Count: 2, Word: "madre", Translation: "mother"})
+	assert.NoError(t, err)
+	assert.Equal(t, "generate_sentences@v1", id)
+	assert.Contains(t, text, `Write 2 short, natural Italian example sentences that use the word "madre" (mother).`)
+}
+
+func TestLoad_Override(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "generate_words.v3.tmpl"), []byte("List {{.Count}} words about {{.Category}}\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vocabulary_teacher.v1.tmpl"), []byte("You teach {{.TargetLanguage}}."), 0o644))
+
+	t.Run("highest version is active", func(t *testing.T) {
+		store, err := Load(dir, nil)
+		require.NoError(t, err)
+
+		text, id, err := store.Render(GenerateWords, "", Vars{Category: "food", Count: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, "generate_words@v3", id)
+		assert.Equal(t, "List 3 words about food", text)
+
+		text, _, err = store.Render(VocabularyTeacher, "v1", Vars{TargetLanguage: "Italian"})
+		assert.NoError(t, err)
+		assert.Equal(t, "You teach Italian.", text)
+	})
+
+	t.Run("pinned version", func(t *testing.T) {
+		store, err := Load(dir, map[string]string{GenerateWords: "v2"})
+		require.NoError(t, err)
+
+		_, id, err := store.Render(GenerateWords, "", Vars{Category: "food", Count: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, "generate_words@v2", id)
+
+		var versions []string
+		for _, info := range store.List() {
+			if info.Name == GenerateWords {
+				versions = append(versions, info.Version+":"+info.Source)
+				assert.Equal(t, info.Version == "v2", info.Active)
+			}
+		}
+		assert.Equal(t, []string{"v1:embedded", "v2:embedded", "v3:override"}, versions)
+	})
+
+	t.Run("unknown pin", func(t *testing.T) {
+		_, err := Load(dir, map[string]string{GenerateWords: "v9"})
+		assert.EqualError(t, err, "prompt generate_words has no version v9")
+	})
+}
+
+func TestLoad_InvalidOverride(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "generate_words.tmpl"), []byte("x"), 0o644))
+
+	_, err := Load(dir, nil)
+	assert.Error(t, err)
+}
+
+func TestRender_MissingVersion(t *testing.T) {
+	store, err := Load("", nil)
+	require.NoError(t, err)
+
+	_, _, err = store.Render("unknown", "", Vars{})
+	assert.Error(t, err)
+	_, _, err = store.Render(GenerateWords, "v7", Vars{})
+	assert.EqualError(t, err, "prompt generate_words@v7 not found")
+}
+
+func TestParseActive(t *testing.T) {
+	active, err := ParseActive(" generate_words=v2, generate_sentences=v1 ")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"generate_words": "v2", "generate_sentences": "v1"}, active)
+
+	active, err = ParseActive("")
+	assert.NoError(t, err)
+	assert.Empty(t, active)
+
+	_, err = ParseActive("generate_words")
+	assert.Error(t, err)
+}
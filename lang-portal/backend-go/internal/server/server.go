@@ -0,0 +1,72 @@
+// Package server runs the HTTP API until the process is told to stop
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/jeevanions/lang-portal/backend-go/internal/api/router"
+	"github.com/jeevanions/lang-portal/backend-go/internal/config"
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/repository"
+	"github.com/jeevanions/lang-portal/backend-go/internal/db/seeder"
+)
+
+// Run serves the API on the configured port until SIGINT or SIGTERM, then
+// shuts down gracefully
+func Run(cfg *config.Config, db *repository.SQLRepository) error {
+	// Cancelled on shutdown, stopping the job workers and the requests that
+	// are still running once the shutdown timeout has passed
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize router
+	r := router.Setup(ctx, db, seeder.New(db), cfg.Timeouts)
+
+	// Initialize HTTP server
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.Port),
+		Handler: r,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	// Start server in a goroutine
+	failed := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			failed <- err
+		}
+	}()
+
+	// Wait for interrupt signal to gracefully shutdown the server
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-failed:
+		return fmt.Errorf("failed to start server: %w", err)
+	case <-quit:
+	}
+	log.Info().Msg("Shutting down server...")
+
+	// In-flight requests get the shutdown timeout to finish; whatever is still
+	// running afterwards is cancelled through the base context
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.Timeouts.Shutdown)
+	defer cancelShutdown()
+	err := srv.Shutdown(shutdownCtx)
+	cancel()
+	if err != nil {
+		log.Error().Err(err).Msg("Server forced to shutdown")
+		srv.Close()
+	}
+
+	log.Info().Msg("Server exiting")
+	return nil
+}
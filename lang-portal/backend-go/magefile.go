@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -90,10 +91,20 @@ func Swagger() error {
 
 type DB mg.Namespace
 
+// database returns the goose migrations directory, goose driver and
+// connection string of the database selected by DB_DRIVER
+func database() (dir, driver, dsn string) {
+	if os.Getenv("DB_DRIVER") == "postgres" {
+		return "internal/db/migrations/postgres", "postgres", os.Getenv("DATABASE_URL")
+	}
+	return "internal/db/migrations", "sqlite3", dbFile
+}
+
 // DB:Migrate runs database migrations
 func (DB) Migrate() error {
 	fmt.Println("Running database migrations...")
-	return sh.Run("goose", "-dir", "internal/db/migrations", "sqlite3", dbFile, "up")
+	dir, driver, dsn := database()
+	return sh.Run("goose", "-dir", dir, driver, dsn, "up")
 }
 
 // DB:Seed seeds the database with initial data
@@ -104,7 +115,12 @@ func (DB) Seed() error {
 	mg.SerialDeps(DB.Migrate)
 
 	// Initialize database repository
-	db, err := repository.NewDB(dbFile)
+	// goose calls the SQLite driver sqlite3
+	_, driver, dsn := database()
+	if driver == "sqlite3" {
+		driver = "sqlite"
+	}
+	db, err := repository.Open(driver, dsn)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -114,7 +130,7 @@ func (DB) Seed() error {
 	seeder := seeder.New(db)
 
 	// Run seeder
-	if err := seeder.SeedFromJSON("internal/db/seeds"); err != nil {
+	if err := seeder.Seed(context.Background()); err != nil {
 		return fmt.Errorf("failed to seed database: %w", err)
 	}
 
@@ -125,8 +141,11 @@ func (DB) Seed() error {
 // DB:Reset resets the database
 func (DB) Reset() error {
 	fmt.Println("Resetting database...")
-	if err := os.Remove(dbFile); err != nil && !os.IsNotExist(err) {
-		return err
+	// The write-ahead log and its index go with the database
+	for _, file := range []string{dbFile, dbFile + "-wal", dbFile + "-shm"} {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
 	}
 	return nil
 }
@@ -134,7 +153,8 @@ func (DB) Reset() error {
 // DB:Status shows migration status
 func (DB) Status() error {
 	fmt.Println("Checking migration status...")
-	return sh.Run("goose", "-dir", "internal/db/migrations", "sqlite3", dbFile, "status")
+	dir, driver, dsn := database()
+	return sh.Run("goose", "-dir", dir, driver, dsn, "status")
 }
 
 // Run runs the application
@@ -4,7 +4,7 @@ import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card"
 import { Progress } from "@/components/ui/progress"
 import { Button } from "@/components/ui/button"
 import { Link } from "@tanstack/react-router"
-import { ArrowRight, BookOpen, Calendar, Trophy, Users } from "lucide-react"
+import { ArrowRight, BookOpen, Calendar, Headphones, Trophy, Users } from "lucide-react"
 import { formatDistanceToNow, parseISO } from "date-fns"
 
 export function DashboardPage() {
@@ -82,7 +82,7 @@ export function DashboardPage() {
       )}
 
       {quickStats && (
-        <div className="grid gap-4 md:grid-cols-2 lg:grid-cols-4">
+        <div className="grid gap-4 md:grid-cols-2 lg:grid-cols-5">
           <Card>
             <CardHeader className="flex flex-row items-center justify-between space-y-0 pb-2">
               <CardTitle className="text-sm font-medium">Success Rate</CardTitle>
@@ -122,6 +122,19 @@ export function DashboardPage() {
               <div className="text-2xl font-bold">{quickStats.study_streak_days} days</div>
             </CardContent>
           </Card>
+
+          <Card>
+            <CardHeader className="flex flex-row items-center justify-between space-y-0 pb-2">
+              <CardTitle className="text-sm font-medium">Listening</CardTitle>
+              <Headphones className="h-4 w-4 text-muted-foreground" />
+            </CardHeader>
+            <CardContent>
+              <div className="text-2xl font-bold">{Math.round(quickStats.listening_success_rate ?? 0)}%</div>
+              <p className="text-xs text-muted-foreground">
+                {quickStats.listening_questions_answered ?? 0} questions answered
+              </p>
+            </CardContent>
+          </Card>
         </div>
       )}
     </div>
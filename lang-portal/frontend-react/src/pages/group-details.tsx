@@ -110,11 +110,11 @@ export function GroupDetailsPage() {
             <Card className="hover:shadow-md transition-shadow hover:border-primary cursor-pointer h-full">
               <CardHeader>
                 <CardTitle className="text-xl font-bold text-primary">
-                  {word.italian}
+                  {word.term}
                 </CardTitle>
               </CardHeader>
               <CardContent className="space-y-4">
-                <p className="text-lg">{word.english}</p>
+                <p className="text-lg">{word.translation}</p>
                 <div className="flex items-center gap-4 text-sm">
                   <div className="flex items-center gap-1">
                     <CheckCircle2 className="h-4 w-4 text-green-600" />
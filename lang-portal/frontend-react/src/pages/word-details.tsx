@@ -44,13 +44,13 @@ export function WordDetailsPage() {
         <Card>
           <CardHeader>
             <CardTitle className="text-3xl font-bold text-primary">
-              {word.italian}
+              {word.term}
             </CardTitle>
           </CardHeader>
           <CardContent className="space-y-6">
             <div>
               <h2 className="text-xl font-semibold mb-2">Translation</h2>
-              <p className="text-lg">{word.english}</p>
+              <p className="text-lg">{word.translation}</p>
             </div>
 
             {word.parts && (
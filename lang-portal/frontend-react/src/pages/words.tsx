@@ -104,12 +104,12 @@ export function WordsPage() {
                 <Card className="hover:shadow-md transition-shadow hover:border-primary cursor-pointer">
                   <CardHeader>
                     <CardTitle className="text-xl font-bold text-primary">
-                      {word.italian}
+                      {word.term}
                     </CardTitle>
                   </CardHeader>
                   <CardContent className="space-y-4">
                     <div>
-                      <p className="text-lg">{word.english}</p>
+                      <p className="text-lg">{word.translation}</p>
                       {word.parts && word.parts.length > 0 && (
                         <p className="text-sm text-muted-foreground mt-1">
                           {word.parts.join(", ")}
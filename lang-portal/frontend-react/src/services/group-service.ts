@@ -7,8 +7,8 @@ export interface Group {
   word_count: number;
   words?: {
     id: number;
-    italian: string;
-    english: string;
+    term: string;
+    translation: string;
     correct_count: number;
     wrong_count: number;
   }[];
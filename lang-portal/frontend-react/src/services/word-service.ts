@@ -2,8 +2,9 @@ import { API_CONFIG } from '@/config/api';
 
 export interface Word {
   id: number;
-  italian: string;
-  english: string;
+  term: string;
+  translation: string;
+  course_id: number;
   parts: {
     type: string;
     gender?: string;